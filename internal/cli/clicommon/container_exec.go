@@ -0,0 +1,39 @@
+package clicommon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	clierrors "github.com/tuxgal/homelab/internal/cli/errors"
+	"github.com/tuxgal/homelab/internal/deployment"
+	"github.com/tuxgal/homelab/internal/docker"
+)
+
+func ExecContainerExecCmd(ctx context.Context, cmd, group, container string, command []string, dep *deployment.Deployment, opts *GlobalCmdOptions, execOpts *ExecCmdOptions, in io.Reader, out, errOut io.Writer) error {
+	res, err := dep.QueryContainer(ctx, group, container)
+	if err != nil {
+		return fmt.Errorf("%s failed while querying containers, reason: %w", cmd, err)
+	}
+	ct := res[0]
+
+	ctx, rec := withDryRun(ctx, opts)
+	dc := docker.NewClient(ctx)
+	defer dc.Close()
+
+	if rec != nil {
+		rec.Begin(ct.Name())
+	}
+	exitCode, err := ct.Exec(ctx, dc, command, execOpts.ExecOptions(ct, in), in, out, errOut)
+	if rec != nil {
+		logDryRunPlan(ctx, rec, "container", ct.Name())
+	}
+	if err != nil {
+		return fmt.Errorf("%s failed, reason: %w", cmd, err)
+	}
+	if exitCode != 0 {
+		return clierrors.NewCommandExitError(fmt.Errorf("%s failed, reason: command %q exited with exit code %d", cmd, strings.Join(command, " "), exitCode), exitCode)
+	}
+	return nil
+}
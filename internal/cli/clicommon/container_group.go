@@ -4,24 +4,49 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/tuxgal/homelab/internal/deployment"
 	"github.com/tuxgal/homelab/internal/docker"
 	"github.com/tuxgal/homelab/internal/host"
+	l "github.com/tuxgal/homelab/internal/log"
 )
 
 const (
 	AllGroups = "all"
 )
 
-func ExecContainerGroupCmd(ctx context.Context, cmd, action, group, container string, dep *deployment.Deployment, fn func(context.Context, *deployment.Container, *host.HostInfo, *docker.Client) error) error {
+// ContainerGroupCmdOptions represents the options controlling the set of
+// containers a container group command acts upon and their order.
+type ContainerGroupCmdOptions struct {
+	// WithDeps also selects all the dependencies of the selected
+	// containers.
+	WithDeps bool
+	// StopOrder acts upon the containers in the reverse order of their
+	// dependencies, i.e. dependents before their dependencies.
+	StopOrder bool
+}
+
+func ExecContainerGroupCmd(ctx context.Context, cmd, action, group, container string, dep *deployment.Deployment, opts *GlobalCmdOptions, cmdOpts *ContainerGroupCmdOptions, fn func(context.Context, *deployment.Container, *host.HostInfo, *docker.Client) error) error {
+	if opts.parallelism < 1 {
+		return fmt.Errorf("%s failed, reason: parallelism %d must be at least 1", cmd, opts.parallelism)
+	}
+
 	res, err := queryContainers(ctx, dep, group, container)
 	if err != nil {
 		return fmt.Errorf("%s failed while querying containers, reason: %w", cmd, err)
 	}
+	if cmdOpts.WithDeps {
+		res = dep.WithDependencies(ctx, res)
+	}
+	if cmdOpts.StopOrder {
+		res = res.InStopOrder()
+	}
 
+	ctx, rec := withDryRun(ctx, opts)
 	dc := docker.NewClient(ctx)
 	defer dc.Close()
+	fn = withContainerResults(opts, cmd, res, fn)
 
 	log(ctx).Debugf("%s command - %s: ", cmd, action)
 	for _, c := range res {
@@ -31,11 +56,23 @@ func ExecContainerGroupCmd(ctx context.Context, cmd, action, group, container st
 
 	h := host.MustHostInfo(ctx)
 	var errList []error
-	for _, ct := range res {
-		// We ignore the errors to keep moving forward even if the action
-		// fails on one or more containers.
-		if err := fn(ctx, ct, h, dc); err != nil {
-			errList = append(errList, err)
+	// The dry run plan is recorded for one container at a time, hence
+	// the containers are always acted upon sequentially in dry run mode.
+	if opts.parallelism > 1 && rec == nil {
+		errList = execContainersInParallel(ctx, res, h, dc, opts.parallelism, fn)
+	} else {
+		for _, ct := range res {
+			if rec != nil {
+				rec.Begin(ct.Name())
+			}
+			// We ignore the errors to keep moving forward even if the
+			// action fails on one or more containers.
+			if err := fn(ctx, ct, h, dc); err != nil {
+				errList = append(errList, err)
+			}
+			if rec != nil {
+				logDryRunPlan(ctx, rec, "container", ct.Name())
+			}
 		}
 	}
 
@@ -53,29 +90,140 @@ func ExecContainerGroupCmd(ctx context.Context, cmd, action, group, container st
 	return nil
 }
 
-func ExecStartContainer(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
-	started, err := c.Start(ctx, dc)
-	if err == nil && !started {
-		log(ctx).Warnf("Container %s not allowed to run on host %s", c.Name(), h.HumanFriendlyHostName)
+// execContainersInParallel acts upon the containers within each order
+// level concurrently using at most parallelism workers, while the order
+// levels themselves are acted upon one after the other. The log lines of
+// every container are prefixed with the container name, and the errors
+// are returned in the order of the containers.
+func execContainersInParallel(ctx context.Context, containers deployment.ContainerList, h *host.HostInfo, dc *docker.Client, parallelism int, fn func(context.Context, *deployment.Container, *host.HostInfo, *docker.Client) error) []error {
+	ctx = docker.WithPullProgressDisabled(ctx)
+	var errList []error
+	for _, level := range containers.OrderLevels() {
+		errs := make([]error, len(level))
+		workers := make(chan struct{}, parallelism)
+		var wg sync.WaitGroup
+		for i, ct := range level {
+			workers <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-workers }()
+				ctLog := l.NewPrefixLogger(log(ctx), fmt.Sprintf("[%s]", ct.Name()))
+				// We ignore the errors to keep moving forward even if the
+				// action fails on one or more containers.
+				errs[i] = fn(l.WithLogger(ctx, ctLog), ct, h, dc)
+			}()
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				errList = append(errList, err)
+			}
+		}
+	}
+	return errList
+}
+
+func ExecStartContainer(opts *StartCmdOptions) func(context.Context, *deployment.Container, *host.HostInfo, *docker.Client) error {
+	return func(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
+		started, err := c.Start(ctx, dc, deployment.StartOptions{ChangedOnly: opts.changedOnly})
+		if err == nil && !started {
+			log(ctx).Warnf("Container %s not allowed to run on host %s", c.Name(), h.HumanFriendlyHostName)
+			log(ctx).WarnEmpty()
+			recordSkipped(ctx, "not allowed to run on host")
+		}
+		return err
+	}
+}
+
+func ExecStopContainer(opts *StopCmdOptions) func(context.Context, *deployment.Container, *host.HostInfo, *docker.Client) error {
+	return func(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
+		stopped, err := c.Stop(ctx, dc, opts.StopOptions())
+		if err == nil && !stopped {
+			recordSkipped(ctx, "not found")
+		}
+		return err
+	}
+}
+
+func ExecPurgeContainer(opts *StopCmdOptions) func(context.Context, *deployment.Container, *host.HostInfo, *docker.Client) error {
+	return func(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
+		purged, err := c.Purge(ctx, dc, opts.StopOptions())
+		if err == nil && !purged {
+			log(ctx).Warnf("Container %s cannot be purged since it was not found", c.Name())
+			log(ctx).WarnEmpty()
+			recordSkipped(ctx, "not found")
+		}
+		return err
+	}
+}
+
+func ExecRestartContainer(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
+	restarted, err := c.Restart(ctx, dc)
+	if err == nil && !restarted {
+		log(ctx).Warnf("Container %s cannot be restarted since it was not found", c.Name())
 		log(ctx).WarnEmpty()
+		recordSkipped(ctx, "not found")
 	}
 	return err
 }
 
-func ExecStopContainer(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
-	_, err := c.Stop(ctx, dc)
+func ExecPauseContainer(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
+	paused, err := c.Pause(ctx, dc)
+	if err == nil && !paused {
+		recordSkipped(ctx, "not running")
+	}
 	return err
 }
 
-func ExecPurgeContainer(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
-	purged, err := c.Purge(ctx, dc)
-	if err == nil && !purged {
-		log(ctx).Warnf("Container %s cannot be purged since it was not found", c.Name())
-		log(ctx).WarnEmpty()
+func ExecUnpauseContainer(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
+	unpaused, err := c.Unpause(ctx, dc)
+	if err == nil && !unpaused {
+		recordSkipped(ctx, "not paused")
 	}
 	return err
 }
 
+// ExecRecreateContainer purges the container if it exists and starts a
+// freshly created container in its place, irrespective of whether its
+// effective configuration or image changed.
+func ExecRecreateContainer(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
+	return ExecStartContainer(&StartCmdOptions{})(ctx, c, h, dc)
+}
+
+func ExecStatusContainer(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
+	st, err := c.Status(ctx, dc)
+	if err != nil {
+		return err
+	}
+	log(ctx).Infof("%s", st)
+	log(ctx).InfoEmpty()
+	recordContainerStatus(ctx, st)
+	return nil
+}
+
+func ExecUnmanagedContainersStatus(ctx context.Context, cmd, group string, dep *deployment.Deployment, opts *GlobalCmdOptions) error {
+	dc := docker.NewClient(ctx)
+	defer dc.Close()
+
+	if group == AllGroups {
+		group = ""
+	}
+	unmanaged, err := dep.QueryUnmanagedContainers(ctx, dc, group)
+	if err != nil {
+		return fmt.Errorf("%s failed while querying unmanaged containers, reason: %w", cmd, err)
+	}
+	recordUnmanagedContainers(opts, unmanaged)
+	for _, n := range unmanaged {
+		log(ctx).Warnf("Container %s looks like a homelab managed container, but is missing in the config", n)
+	}
+	if len(unmanaged) > 0 {
+		log(ctx).WarnEmpty()
+	}
+	return nil
+}
+
 func queryContainers(ctx context.Context, dep *deployment.Deployment, group, container string) (deployment.ContainerList, error) {
 	if group == AllGroups {
 		return dep.QueryAllContainersInAllGroups(ctx)
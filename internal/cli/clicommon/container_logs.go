@@ -0,0 +1,155 @@
+package clicommon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/moby/term"
+	"github.com/tuxgal/homelab/internal/deployment"
+	"github.com/tuxgal/homelab/internal/docker"
+)
+
+var (
+	// ANSI color codes used for the prefixes of the log lines from the
+	// different containers.
+	logPrefixColors = []int{36, 33, 32, 35, 34, 31}
+)
+
+func ExecContainerLogsCmd(ctx context.Context, cmd, group, container string, dep *deployment.Deployment, logsOpts *LogsCmdOptions, out io.Writer) error {
+	if !isValidLogsTail(logsOpts.tail) {
+		return fmt.Errorf("%s failed, reason: invalid tail %s, must be either all or a non-negative number of lines", cmd, logsOpts.tail)
+	}
+
+	res, err := queryContainers(ctx, dep, group, container)
+	if err != nil {
+		return fmt.Errorf("%s failed while querying containers, reason: %w", cmd, err)
+	}
+
+	dc := docker.NewClient(ctx)
+	defer dc.Close()
+
+	opts := docker.ContainerLogsOptions{
+		Follow: logsOpts.follow,
+		Since:  logsOpts.since,
+		Tail:   logsOpts.tail,
+	}
+	width := 0
+	for _, ct := range res {
+		width = max(width, len(containerLogPrefix(ct)))
+	}
+	_, isTerm := term.GetFdInfo(out)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	found := make([]bool, len(res))
+	errs := make([]error, len(res))
+	for i, ct := range res {
+		// The logs of a single container are written as is, while the
+		// logs of multiple containers are interleaved line by line with
+		// every line prefixed by the container it belongs to.
+		var w *linePrefixWriter
+		if len(res) > 1 {
+			prefix := fmt.Sprintf("%-*s | ", width, containerLogPrefix(ct))
+			if isTerm {
+				prefix = fmt.Sprintf("\x1b[%dm%s\x1b[0m", logPrefixColors[i%len(logPrefixColors)], prefix)
+			}
+			w = &linePrefixWriter{mu: &mu, out: out, prefix: prefix}
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if w == nil {
+				found[i], errs[i] = ct.Logs(ctx, dc, opts, out)
+				return
+			}
+			found[i], errs[i] = ct.Logs(ctx, dc, opts, w)
+			w.flush()
+		}()
+	}
+	wg.Wait()
+
+	for i, ct := range res {
+		if errs[i] == nil && !found[i] {
+			log(ctx).Warnf("Container %s has no logs since it was not found", ct.Name())
+		}
+	}
+	if len(res) == 0 {
+		log(ctx).Warnf("%s is a no-op since no containers were found matching the specified criteria", cmd)
+	}
+
+	var errList []error
+	for _, err := range errs {
+		if err != nil {
+			errList = append(errList, err)
+		}
+	}
+	if len(errList) > 0 {
+		var sb strings.Builder
+		for i, e := range errList {
+			fmt.Fprintf(&sb, "\n%d - %s", i+1, e)
+		}
+		return fmt.Errorf("%s failed for %d containers, reason(s):%s", cmd, len(errList), sb.String())
+	}
+	return nil
+}
+
+func containerLogPrefix(c *deployment.Container) string {
+	ref := c.Reference()
+	return fmt.Sprintf("%s/%s", ref.Group, ref.Container)
+}
+
+func isValidLogsTail(tail string) bool {
+	if tail == "all" {
+		return true
+	}
+	n, err := strconv.Atoi(tail)
+	return err == nil && n >= 0
+}
+
+// linePrefixWriter writes every complete line written to it prefixed
+// with the specified prefix to the output shared with other writers. The
+// lines are written while holding the shared lock, so the lines written
+// by the different writers never get interleaved mid-line.
+type linePrefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (l *linePrefixWriter) Write(p []byte) (int, error) {
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := l.writeLine(l.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		l.buf = l.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (l *linePrefixWriter) flush() {
+	if len(l.buf) == 0 {
+		return
+	}
+	//nolint:errcheck
+	l.writeLine(append(l.buf, '\n'))
+	l.buf = nil
+}
+
+func (l *linePrefixWriter) writeLine(line []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err := fmt.Fprintf(l.out, "%s%s", l.prefix, line)
+	return err
+}
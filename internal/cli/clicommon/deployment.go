@@ -3,7 +3,9 @@ package clicommon
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/tuxgal/homelab/internal/config"
 	"github.com/tuxgal/homelab/internal/deployment"
 )
 
@@ -20,3 +22,41 @@ func BuildDeployment(ctx context.Context, cmd string, opts *GlobalCmdOptions) (*
 
 	return dep, nil
 }
+
+// ValidateConfigs validates the homelab configs, reporting all the
+// errors encountered along with the config file and line (whenever
+// known) where the offending element is defined.
+func ValidateConfigs(ctx context.Context, cmd string, opts *GlobalCmdOptions) error {
+	path, err := configsPath(ctx, cmd, opts)
+	if err != nil {
+		return err
+	}
+
+	merged, err := config.ReadMergedConfigs(ctx, path)
+	if err != nil {
+		return fmt.Errorf("%s failed while parsing the configs, reason: %w", cmd, err)
+	}
+	r, err := merged.Reader()
+	if err != nil {
+		return fmt.Errorf("%s failed while parsing the configs, reason: %w", cmd, err)
+	}
+	conf := config.Homelab{}
+	if err := conf.Parse(ctx, r); err != nil {
+		return fmt.Errorf("%s failed while parsing the configs, reason: %w", cmd, err)
+	}
+
+	errs := deployment.ValidateConfig(ctx, &conf, path)
+	if len(errs) == 0 {
+		log(ctx).Infof("Homelab config is valid")
+		return nil
+	}
+
+	errs.AnnotateSources(merged.SourceIndex())
+	recordConfigErrors(opts, errs)
+
+	var sb strings.Builder
+	for i, e := range errs {
+		fmt.Fprintf(&sb, "\n%d - %s", i+1, e)
+	}
+	return fmt.Errorf("%s failed with %d errors in the configs, reason(s):%s", cmd, len(errs), sb.String())
+}
@@ -0,0 +1,33 @@
+package clicommon
+
+import (
+	"context"
+
+	"github.com/tuxgal/homelab/internal/cmdexec"
+	"github.com/tuxgal/homelab/internal/docker"
+	"github.com/tuxgal/homelab/internal/dryrun"
+)
+
+// withDryRun returns a context where all the mutating operations against
+// the docker host and the system are recorded instead of being executed
+// if dry run mode was requested, along with the recorder. The recorder
+// is nil otherwise.
+func withDryRun(ctx context.Context, opts *GlobalCmdOptions) (context.Context, *dryrun.Recorder) {
+	if !opts.dryRun {
+		return ctx, nil
+	}
+
+	log(ctx).Warnf("Running in dry run mode, no changes will be made to the docker host")
+	log(ctx).WarnEmpty()
+
+	rec := dryrun.NewRecorder()
+	ctx = dryrun.WithRecorder(ctx, rec)
+	ctx = docker.WithAPIClient(ctx, docker.NewDryRunAPIClient(docker.MustAPIClient(ctx), rec))
+	ctx = cmdexec.WithExecutor(ctx, cmdexec.NewDryRunExecutor(rec))
+	return ctx, rec
+}
+
+func logDryRunPlan(ctx context.Context, rec *dryrun.Recorder, kind, name string) {
+	log(ctx).Infof("Dry run plan for %s %s:\n%s", kind, name, rec.Plan(name))
+	log(ctx).InfoEmpty()
+}
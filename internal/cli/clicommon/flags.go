@@ -3,19 +3,140 @@ package clicommon
 import (
 	"context"
 	"fmt"
+	"io"
 
+	"github.com/moby/term"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/tuxgal/homelab/internal/cli/cliconfig"
+	"github.com/tuxgal/homelab/internal/deployment"
 )
 
 const (
-	cliConfigFlagStr  = "cli-config"
-	configsDirFlagStr = "configs-dir"
+	cliConfigFlagStr   = "cli-config"
+	configsDirFlagStr  = "configs-dir"
+	dryRunFlagStr      = "dry-run"
+	parallelismFlagStr = "parallelism"
+	changedOnlyFlagStr = "changed-only"
+	withDepsFlagStr    = "with-deps"
+	followFlagStr      = "follow"
+	sinceFlagStr       = "since"
+	tailFlagStr        = "tail"
+	userFlagStr        = "user"
+	shellFlagStr       = "shell"
+	ttyFlagStr         = "tty"
+	signalFlagStr      = "signal"
+	timeoutFlagStr     = "timeout"
+	outputFlagStr      = "output"
+	outputShortFlagStr = "o"
+	annotateFlagStr    = "annotate"
+	groupFlagStr       = "group"
 )
 
 type GlobalCmdOptions struct {
-	cliConfig  string
-	configsDir string
+	cliConfig   string
+	configsDir  string
+	dryRun      bool
+	parallelism int
+	output      string
+	result      *Result
+}
+
+type ShowConfigCmdOptions struct {
+	annotate bool
+}
+
+type ExportComposeCmdOptions struct {
+	group string
+}
+
+type ImportComposeCmdOptions struct {
+	group string
+}
+
+type StartCmdOptions struct {
+	changedOnly bool
+	withDeps    bool
+}
+
+type StopCmdOptions struct {
+	signal      string
+	timeout     int
+	timeoutFlag *pflag.Flag
+}
+
+type LogsCmdOptions struct {
+	follow bool
+	since  string
+	tail   string
+}
+
+type ExecCmdOptions struct {
+	user    string
+	shell   string
+	tty     bool
+	ttyFlag *pflag.Flag
+}
+
+func (s *ShowConfigCmdOptions) Annotate() bool {
+	return s.annotate
+}
+
+func (e *ExportComposeCmdOptions) Group() string {
+	return e.group
+}
+
+func (i *ImportComposeCmdOptions) Group() string {
+	return i.group
+}
+
+// Validate returns an error if the import compose options are invalid.
+func (i *ImportComposeCmdOptions) Validate(cmd string) error {
+	if len(i.group) == 0 {
+		return fmt.Errorf("%s failed, reason: the group must be specified using the --%s flag", cmd, groupFlagStr)
+	}
+	return nil
+}
+
+func (e *ExecCmdOptions) Shell() string {
+	return e.shell
+}
+
+// ExecOptions returns the options for executing the command in the
+// container. Unless overridden using the flag, a TTY is attached only
+// if the container is configured to be attached to a TTY and the
+// specified stdin is a terminal.
+func (e *ExecCmdOptions) ExecOptions(ct *deployment.Container, stdin io.Reader) deployment.ExecOptions {
+	tty := e.tty
+	if e.ttyFlag == nil || !e.ttyFlag.Changed {
+		_, isTerminal := term.GetFdInfo(stdin)
+		tty = ct.AttachToTty() && isTerminal
+	}
+	return deployment.ExecOptions{User: e.user, Tty: tty}
+}
+
+func (s *StartCmdOptions) ContainerGroupCmdOptions() *ContainerGroupCmdOptions {
+	return &ContainerGroupCmdOptions{WithDeps: s.withDeps}
+}
+
+// Validate returns an error if the stop options are invalid.
+func (s *StopCmdOptions) Validate(cmd string) error {
+	if s.timeoutFlag != nil && s.timeoutFlag.Changed && s.timeout < -1 {
+		return fmt.Errorf("%s failed, reason: stop timeout %d must be either -1 or a non-negative number of seconds", cmd, s.timeout)
+	}
+	return nil
+}
+
+// StopOptions returns the options for stopping the containers, which
+// only override the configured stop signal and timeout for the flags
+// that were specified.
+func (s *StopCmdOptions) StopOptions() deployment.StopOptions {
+	res := deployment.StopOptions{Signal: s.signal}
+	if s.timeoutFlag != nil && s.timeoutFlag.Changed {
+		t := s.timeout
+		res.Timeout = &t
+	}
+	return res
 }
 
 func configsPath(ctx context.Context, cmd string, opts *GlobalCmdOptions) (string, error) {
@@ -38,4 +159,63 @@ func AddHomelabFlags(ctx context.Context, cmd *cobra.Command, opts *GlobalCmdOpt
 		log(ctx).Fatalf("failed to mark --%s flag as dirname flag", configsDirFlagStr)
 	}
 	cmd.MarkFlagsMutuallyExclusive(cliConfigFlagStr, configsDirFlagStr)
+	cmd.PersistentFlags().BoolVar(
+		&opts.dryRun, dryRunFlagStr, false, "Print the plan of actions that would be performed on the docker host without performing them")
+	cmd.PersistentFlags().IntVar(
+		&opts.parallelism, parallelismFlagStr, 1, "The maximum number of containers sharing the same group and container order to act upon concurrently")
+	cmd.PersistentFlags().StringVarP(
+		&opts.output, outputFlagStr, outputShortFlagStr, string(OutputFormatText), "The output format, one of text, json or yaml. The json and yaml formats emit a machine readable result document on stdout and write the logs to stderr")
+}
+
+func AddShowConfigCmdFlags(cmd *cobra.Command, opts *ShowConfigCmdOptions) {
+	cmd.Flags().BoolVar(
+		&opts.annotate, annotateFlagStr, false, "Annotate every value with the config file and line where it is defined")
+}
+
+func AddExportComposeCmdFlags(cmd *cobra.Command, opts *ExportComposeCmdOptions) {
+	cmd.Flags().StringVar(
+		&opts.group, groupFlagStr, "", "Only export the containers within the specified group")
+}
+
+func AddImportComposeCmdFlags(cmd *cobra.Command, opts *ImportComposeCmdOptions) {
+	cmd.Flags().StringVar(
+		&opts.group, groupFlagStr, "", "The group to place the containers translated from the compose services in")
+}
+
+func AddStartCmdFlags(cmd *cobra.Command, opts *StartCmdOptions) {
+	cmd.Flags().BoolVar(
+		&opts.changedOnly, changedOnlyFlagStr, false, "Only recreate the containers whose effective configuration or image changed, or which are not running")
+	cmd.Flags().BoolVar(
+		&opts.withDeps, withDepsFlagStr, false, "Also start all the containers the selected containers depend on")
+}
+
+func AddLogsCmdFlags(cmd *cobra.Command, opts *LogsCmdOptions) {
+	cmd.Flags().BoolVarP(
+		&opts.follow, followFlagStr, "f", false, "Keep streaming the new logs as they are written")
+	cmd.Flags().StringVar(
+		&opts.since, sinceFlagStr, "", "Only show the logs since the specified timestamp (e.g. 2024-01-02T13:23:37Z) or relative duration (e.g. 42m)")
+	cmd.Flags().StringVar(
+		&opts.tail, tailFlagStr, "all", "The number of lines to show from the end of the logs, or all to show all the lines")
+}
+
+func AddExecCmdFlags(cmd *cobra.Command, opts *ExecCmdOptions) {
+	cmd.Flags().StringVarP(
+		&opts.user, userFlagStr, "u", "", "The user (and optionally the group in the user:group format) to run the command as, defaults to the user configured for the container")
+	cmd.Flags().BoolVarP(
+		&opts.tty, ttyFlagStr, "t", false, "Attach a TTY to the command, defaults to true only when the container is configured to be attached to a TTY and stdin is a terminal")
+	opts.ttyFlag = cmd.Flags().Lookup(ttyFlagStr)
+}
+
+func AddShellCmdFlags(cmd *cobra.Command, opts *ExecCmdOptions) {
+	AddExecCmdFlags(cmd, opts)
+	cmd.Flags().StringVar(
+		&opts.shell, shellFlagStr, "/bin/sh", "The path to the shell inside the container")
+}
+
+func AddStopCmdFlags(cmd *cobra.Command, opts *StopCmdOptions) {
+	cmd.Flags().StringVar(
+		&opts.signal, signalFlagStr, "", "The signal to stop the containers with, overriding the configured stop signal")
+	cmd.Flags().IntVar(
+		&opts.timeout, timeoutFlagStr, 0, "The number of seconds to wait for the containers to stop before killing them (-1 to wait indefinitely), overriding the configured stop timeout")
+	opts.timeoutFlag = cmd.Flags().Lookup(timeoutFlagStr)
 }
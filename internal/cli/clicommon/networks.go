@@ -13,14 +13,16 @@ const (
 	AllNetworks = "all"
 )
 
-func ExecNetworksCmd(ctx context.Context, cmd, action, network string, dep *deployment.Deployment, fn func(context.Context, *deployment.Network, *docker.Client) error) error {
+func ExecNetworksCmd(ctx context.Context, cmd, action, network string, dep *deployment.Deployment, opts *GlobalCmdOptions, fn func(context.Context, *deployment.Network, *docker.Client) error) error {
 	res, err := dep.QueryNetwork(ctx, network)
 	if err != nil {
 		return fmt.Errorf("%s failed while querying networks, reason: %w", cmd, err)
 	}
 
+	ctx, rec := withDryRun(ctx, opts)
 	dc := docker.NewClient(ctx)
 	defer dc.Close()
+	fn = withNetworkResults(opts, cmd, fn)
 
 	log(ctx).Debugf("%s command - %s: ", cmd, action)
 	for _, n := range res {
@@ -30,11 +32,17 @@ func ExecNetworksCmd(ctx context.Context, cmd, action, network string, dep *depl
 
 	var errList []error
 	for _, n := range res {
+		if rec != nil {
+			rec.Begin(n.Name())
+		}
 		// We ignore the errors to keep moving forward even if the action
 		// fails on one or more networks.
 		if err := fn(ctx, n, dc); err != nil {
 			errList = append(errList, err)
 		}
+		if rec != nil {
+			logDryRunPlan(ctx, rec, "network", n.Name())
+		}
 	}
 
 	if len(errList) > 0 {
@@ -52,6 +60,7 @@ func ExecCreateNetwork(ctx context.Context, n *deployment.Network, dc *docker.Cl
 	if err == nil && !created {
 		log(ctx).Warnf("Network %s not created since it already exists", n.Name())
 		log(ctx).WarnEmpty()
+		recordSkipped(ctx, "already exists")
 	}
 	return err
 }
@@ -61,6 +70,7 @@ func ExecDeleteNetwork(ctx context.Context, n *deployment.Network, dc *docker.Cl
 	if err == nil && !deleted {
 		log(ctx).Warnf("Network %s not deleted since it doesn't exist already", n.Name())
 		log(ctx).WarnEmpty()
+		recordSkipped(ctx, "not found")
 	}
 	return err
 }
@@ -0,0 +1,359 @@
+package clicommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/config"
+	"github.com/tuxgal/homelab/internal/deployment"
+	"github.com/tuxgal/homelab/internal/docker"
+	"github.com/tuxgal/homelab/internal/dryrun"
+	"github.com/tuxgal/homelab/internal/host"
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat represents the format in which the result of a command
+// is emitted.
+type OutputFormat string
+
+const (
+	OutputFormatText OutputFormat = "text"
+	OutputFormatJSON OutputFormat = "json"
+	OutputFormatYAML OutputFormat = "yaml"
+)
+
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeSkipped   = "skipped"
+	OutcomeFailed    = "failed"
+)
+
+var (
+	resultKey = ctxKeyResult{}
+)
+
+type ctxKeyResult struct{}
+
+// Result represents the machine readable result document emitted on
+// the standard output by a command when a non-text output format is
+// requested.
+type Result struct {
+	mu                  sync.Mutex
+	Command             string               `yaml:"command" json:"command"`
+	Success             bool                 `yaml:"success" json:"success"`
+	Error               string               `yaml:"error,omitempty" json:"error,omitempty"`
+	DryRun              bool                 `yaml:"dryRun,omitempty" json:"dryRun,omitempty"`
+	Containers          []*ContainerResult   `yaml:"containers,omitempty" json:"containers,omitempty"`
+	UnmanagedContainers []string             `yaml:"unmanagedContainers,omitempty" json:"unmanagedContainers,omitempty"`
+	Networks            []*NetworkResult     `yaml:"networks,omitempty" json:"networks,omitempty"`
+	Config              *config.Homelab      `yaml:"config,omitempty" json:"config,omitempty"`
+	ConfigErrors        []*ConfigErrorResult `yaml:"configErrors,omitempty" json:"configErrors,omitempty"`
+}
+
+// ContainerResult represents the outcome of acting upon a single
+// container.
+type ContainerResult struct {
+	Container  string                 `yaml:"container" json:"container"`
+	Action     string                 `yaml:"action" json:"action"`
+	Outcome    string                 `yaml:"outcome" json:"outcome"`
+	Reason     string                 `yaml:"reason,omitempty" json:"reason,omitempty"`
+	Error      string                 `yaml:"error,omitempty" json:"error,omitempty"`
+	Status     *ContainerStatusResult `yaml:"status,omitempty" json:"status,omitempty"`
+	DryRunPlan []string               `yaml:"dryRunPlan,omitempty" json:"dryRunPlan,omitempty"`
+}
+
+// ConfigErrorResult represents an error encountered while validating
+// the homelab config.
+type ConfigErrorResult struct {
+	Element string `yaml:"element" json:"element"`
+	Source  string `yaml:"source,omitempty" json:"source,omitempty"`
+	Error   string `yaml:"error" json:"error"`
+}
+
+// ContainerStatusResult represents the configured and the live state of
+// a container.
+type ContainerStatusResult struct {
+	InSync        bool                             `yaml:"inSync" json:"inSync"`
+	AllowedOnHost bool                             `yaml:"allowedOnHost" json:"allowedOnHost"`
+	WantState     string                           `yaml:"wantState" json:"wantState"`
+	State         string                           `yaml:"state" json:"state"`
+	WantImage     string                           `yaml:"wantImage,omitempty" json:"wantImage,omitempty"`
+	Image         string                           `yaml:"image,omitempty" json:"image,omitempty"`
+	Endpoints     []*ContainerEndpointStatusResult `yaml:"endpoints,omitempty" json:"endpoints,omitempty"`
+}
+
+// ContainerEndpointStatusResult represents the configured and the live
+// IP addresses of a container's network endpoint.
+type ContainerEndpointStatusResult struct {
+	Network  string `yaml:"network" json:"network"`
+	WantIPv4 string `yaml:"wantIPv4,omitempty" json:"wantIPv4,omitempty"`
+	WantIPv6 string `yaml:"wantIPv6,omitempty" json:"wantIPv6,omitempty"`
+	IPv4     string `yaml:"ipv4,omitempty" json:"ipv4,omitempty"`
+	IPv6     string `yaml:"ipv6,omitempty" json:"ipv6,omitempty"`
+}
+
+// NetworkResult represents the outcome of acting upon a single network.
+type NetworkResult struct {
+	Network    string   `yaml:"network" json:"network"`
+	Action     string   `yaml:"action" json:"action"`
+	Outcome    string   `yaml:"outcome" json:"outcome"`
+	Reason     string   `yaml:"reason,omitempty" json:"reason,omitempty"`
+	Error      string   `yaml:"error,omitempty" json:"error,omitempty"`
+	DryRunPlan []string `yaml:"dryRunPlan,omitempty" json:"dryRunPlan,omitempty"`
+}
+
+// subjectResult is the outcome of acting upon a single container or
+// network as reported by the action itself.
+type subjectResult struct {
+	skipReason string
+	status     *ContainerStatusResult
+}
+
+// OutputFormatFromFlags returns the output format requested using the
+// parsed flags of the specified command. Invalid output formats are
+// reported later while executing the command.
+func OutputFormatFromFlags(cmd *cobra.Command) OutputFormat {
+	f := cmd.Flags().Lookup(outputFlagStr)
+	if f == nil {
+		return OutputFormatText
+	}
+	return OutputFormat(f.Value.String())
+}
+
+// IsExportCmd returns true if the specified command is one of the config
+// export commands, which always write the exported document to the
+// standard output.
+func IsExportCmd(cmd *cobra.Command) bool {
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if c.Name() == "export" && c.Parent().Name() == "config" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMachineReadable returns true if the output format is meant to be
+// consumed by other programs rather than humans.
+func (o OutputFormat) IsMachineReadable() bool {
+	return o == OutputFormatJSON || o == OutputFormatYAML
+}
+
+func (o OutputFormat) validate() error {
+	switch o {
+	case OutputFormatText, OutputFormatJSON, OutputFormatYAML:
+		return nil
+	}
+	return fmt.Errorf("invalid output format %q, must be one of %s, %s or %s", o, OutputFormatText, OutputFormatJSON, OutputFormatYAML)
+}
+
+// InitResult validates the requested output format and initializes the
+// result document for the specified command being executed, if a
+// machine readable output format was requested.
+func (g *GlobalCmdOptions) InitResult(cmd *cobra.Command) error {
+	format := OutputFormat(g.output)
+	if err := format.validate(); err != nil {
+		return err
+	}
+	if format.IsMachineReadable() {
+		g.result = &Result{
+			Command: strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+			DryRun:  g.dryRun,
+		}
+	}
+	return nil
+}
+
+// ValidateTextOutputOnly returns an error if a machine readable output
+// format was requested for a command which streams its output.
+func ValidateTextOutputOnly(cmd string, opts *GlobalCmdOptions) error {
+	if format := OutputFormat(opts.output); format.IsMachineReadable() {
+		return fmt.Errorf("%s does not support the %s output format", cmd, format)
+	}
+	return nil
+}
+
+// WriteResult writes the result document (if any) to the specified
+// writer in the requested output format, marking it as failed if the
+// command returned an error.
+func WriteResult(w io.Writer, opts *GlobalCmdOptions, cmdErr error) error {
+	res := opts.result
+	if res == nil {
+		return nil
+	}
+
+	res.Success = cmdErr == nil
+	if cmdErr != nil {
+		res.Error = cmdErr.Error()
+	}
+
+	switch OutputFormat(opts.output) {
+	case OutputFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(res); err != nil {
+			return fmt.Errorf("failed to write the result as json, reason: %w", err)
+		}
+	case OutputFormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(res); err != nil {
+			return fmt.Errorf("failed to write the result as yaml, reason: %w", err)
+		}
+		if err := enc.Close(); err != nil {
+			return fmt.Errorf("failed to write the result as yaml, reason: %w", err)
+		}
+	}
+	return nil
+}
+
+// RecordConfig records the specified homelab config in the result
+// document (if any).
+func RecordConfig(opts *GlobalCmdOptions, conf *config.Homelab) {
+	if opts.result != nil {
+		opts.result.Config = conf
+	}
+}
+
+// recordConfigErrors records the specified config validation errors in
+// the result document (if any).
+func recordConfigErrors(opts *GlobalCmdOptions, errs deployment.ValidationErrors) {
+	if opts.result == nil {
+		return
+	}
+	for _, e := range errs {
+		r := &ConfigErrorResult{
+			Element: e.Element.String(),
+			Error:   e.Err.Error(),
+		}
+		if e.Source != nil {
+			r.Source = e.Source.String()
+		}
+		opts.result.ConfigErrors = append(opts.result.ConfigErrors, r)
+	}
+}
+
+// recordUnmanagedContainers records the specified unmanaged containers
+// in the result document (if any).
+func recordUnmanagedContainers(opts *GlobalCmdOptions, unmanaged []string) {
+	if opts.result != nil {
+		opts.result.UnmanagedContainers = unmanaged
+	}
+}
+
+// withContainerResults returns a function that acts upon a container
+// using the specified function while also recording its outcome in the
+// result document (if any). The containers are recorded in the order
+// they are specified irrespective of the order they are acted upon.
+func withContainerResults(opts *GlobalCmdOptions, cmd string, containers deployment.ContainerList, fn func(context.Context, *deployment.Container, *host.HostInfo, *docker.Client) error) func(context.Context, *deployment.Container, *host.HostInfo, *docker.Client) error {
+	res := opts.result
+	if res == nil {
+		return fn
+	}
+
+	byName := make(map[string]*ContainerResult, len(containers))
+	res.mu.Lock()
+	for _, c := range containers {
+		cr := &ContainerResult{Container: c.Name(), Action: cmdAction(cmd)}
+		res.Containers = append(res.Containers, cr)
+		byName[c.Name()] = cr
+	}
+	res.mu.Unlock()
+
+	return func(ctx context.Context, c *deployment.Container, h *host.HostInfo, dc *docker.Client) error {
+		sr := &subjectResult{}
+		err := fn(context.WithValue(ctx, resultKey, sr), c, h, dc)
+
+		cr := byName[c.Name()]
+		cr.Outcome, cr.Reason, cr.Error = outcome(sr, err)
+		cr.Status = sr.status
+		if rec, found := dryrun.RecorderFromContext(ctx); found {
+			cr.DryRunPlan = rec.Actions(c.Name())
+		}
+		return err
+	}
+}
+
+// withNetworkResults returns a function that acts upon a network using
+// the specified function while also recording its outcome in the result
+// document (if any).
+func withNetworkResults(opts *GlobalCmdOptions, cmd string, fn func(context.Context, *deployment.Network, *docker.Client) error) func(context.Context, *deployment.Network, *docker.Client) error {
+	res := opts.result
+	if res == nil {
+		return fn
+	}
+
+	return func(ctx context.Context, n *deployment.Network, dc *docker.Client) error {
+		sr := &subjectResult{}
+		err := fn(context.WithValue(ctx, resultKey, sr), n, dc)
+
+		nr := &NetworkResult{Network: n.Name(), Action: cmdAction(cmd)}
+		nr.Outcome, nr.Reason, nr.Error = outcome(sr, err)
+		if rec, found := dryrun.RecorderFromContext(ctx); found {
+			nr.DryRunPlan = rec.Actions(n.Name())
+		}
+		res.mu.Lock()
+		res.Networks = append(res.Networks, nr)
+		res.mu.Unlock()
+		return err
+	}
+}
+
+func outcome(sr *subjectResult, err error) (string, string, string) {
+	if err != nil {
+		return OutcomeFailed, "", err.Error()
+	}
+	if len(sr.skipReason) > 0 {
+		return OutcomeSkipped, sr.skipReason, ""
+	}
+	return OutcomeSucceeded, "", ""
+}
+
+// recordSkipped records the action upon the current container or
+// network as skipped for the specified reason.
+func recordSkipped(ctx context.Context, reason string) {
+	if sr, found := ctx.Value(resultKey).(*subjectResult); found {
+		sr.skipReason = reason
+	}
+}
+
+// recordContainerStatus records the specified status of the current
+// container.
+func recordContainerStatus(ctx context.Context, st *deployment.ContainerStatus) {
+	sr, found := ctx.Value(resultKey).(*subjectResult)
+	if !found {
+		return
+	}
+
+	sr.status = &ContainerStatusResult{
+		InSync:        st.InSync(),
+		AllowedOnHost: st.AllowedOnHost,
+		WantState:     st.WantState.String(),
+		State:         st.State.String(),
+		WantImage:     st.WantImage,
+		Image:         st.Image,
+	}
+	for _, e := range st.Endpoints {
+		sr.status.Endpoints = append(sr.status.Endpoints, &ContainerEndpointStatusResult{
+			Network:  e.Network,
+			WantIPv4: e.WantIPv4,
+			WantIPv6: e.WantIPv6,
+			IPv4:     e.IPv4,
+			IPv6:     e.IPv6,
+		})
+	}
+}
+
+// cmdAction returns the action performed by the specified command,
+// i.e. the last word of the command name.
+func cmdAction(cmd string) string {
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 {
+		return cmd
+	}
+	return fields[len(fields)-1]
+}
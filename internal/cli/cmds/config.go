@@ -12,6 +12,10 @@ import (
 func ConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
 	cmd := buildConfigCmd(ctx)
 	cmd.AddCommand(config.ShowConfigCmd(ctx, opts))
+	cmd.AddCommand(config.ValidateConfigCmd(ctx, opts))
+	cmd.AddCommand(config.SchemaConfigCmd(ctx, opts))
+	cmd.AddCommand(config.ExportConfigCmd(ctx, opts))
+	cmd.AddCommand(config.ImportConfigCmd(ctx, opts))
 	return cmd
 }
 
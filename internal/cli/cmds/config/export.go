@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/cli/clicommon"
+	"github.com/tuxgal/homelab/internal/cli/clicontext"
+	"github.com/tuxgal/homelab/internal/cli/errors"
+	"github.com/tuxgal/homelab/internal/deployment"
+)
+
+func ExportConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports the homelab config to other formats",
+		Long:  `Exports the homelab configuration to other formats.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("homelab config export sub-command is required")
+		},
+	}
+	cmd.AddCommand(ExportComposeConfigCmd(ctx, opts))
+	return cmd
+}
+
+func ExportComposeConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	exportOpts := &clicommon.ExportComposeCmdOptions{}
+	cmd := &cobra.Command{
+		Use:   "compose",
+		Short: "Exports the homelab config as a compose document",
+		Long:  `Exports the containers and the networks of the homelab configuration as a docker compose document. The constructs which cannot be represented in the compose document are reported as warnings in the comments at the top of the document.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := execExportComposeConfigCmd(clicontext.HomelabContext(ctx), opts, exportOpts, cmd.OutOrStdout())
+			if err != nil {
+				return errors.NewHomelabRuntimeError(err)
+			}
+			return nil
+		},
+	}
+	clicommon.AddExportComposeCmdFlags(cmd, exportOpts)
+	return cmd
+}
+
+func execExportComposeConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions, exportOpts *clicommon.ExportComposeCmdOptions, out io.Writer) error {
+	if err := clicommon.ValidateTextOutputOnly("config export compose", opts); err != nil {
+		return err
+	}
+
+	dep, err := clicommon.BuildDeployment(ctx, "config export compose", opts)
+	if err != nil {
+		return err
+	}
+
+	var containers deployment.ContainerList
+	if len(exportOpts.Group()) > 0 {
+		containers, err = dep.QueryAllContainersInGroup(ctx, exportOpts.Group())
+	} else {
+		containers, err = dep.QueryAllContainersInAllGroups(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("config export compose failed while querying containers, reason: %w", err)
+	}
+
+	res := dep.ExportCompose(ctx, containers)
+	doc, err := res.Project.YAML()
+	if err != nil {
+		return fmt.Errorf("config export compose failed, reason: %w", err)
+	}
+
+	// The warnings are emitted as comments within the document so that
+	// they stay with the document once it is redirected to a file. The
+	// logs on the other hand are written to stderr for this command.
+	var sb strings.Builder
+	for _, w := range res.Warnings {
+		fmt.Fprintf(&sb, "# WARNING: %s\n", w)
+	}
+	sb.Write(doc)
+	if _, err := io.WriteString(out, sb.String()); err != nil {
+		return fmt.Errorf("config export compose failed while writing the compose document, reason: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/cli/clicommon"
+	"github.com/tuxgal/homelab/internal/cli/clicontext"
+	"github.com/tuxgal/homelab/internal/cli/errors"
+	"github.com/tuxgal/homelab/internal/compose"
+	"github.com/tuxgal/homelab/internal/utils"
+)
+
+func ImportConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Imports the homelab config from other formats",
+		Long:  `Imports the homelab configuration from other formats.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("homelab config import sub-command is required")
+		},
+	}
+	cmd.AddCommand(ImportComposeConfigCmd(ctx, opts))
+	return cmd
+}
+
+func ImportComposeConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	importOpts := &clicommon.ImportComposeCmdOptions{}
+	cmd := &cobra.Command{
+		Use:   "compose [file]",
+		Short: "Imports a compose document as homelab config",
+		Long:  `Translates the services and the networks of a docker compose document into the homelab configuration of a single group, printing the config ready to be placed in the configs dir. The unsupported keys and the settings which cannot be translated are reported as warnings in the comments at the top of the config. The variables interpolated within the document are translated to the config env variable references, and the relative bind mount sources are resolved against the directory of the compose document.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				//nolint:staticcheck
+				return fmt.Errorf("Expected exactly one compose file argument to be specified, but found %d instead", len(args))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := execImportComposeConfigCmd(clicontext.HomelabContext(ctx), args[0], opts, importOpts, cmd.OutOrStdout())
+			if err != nil {
+				return errors.NewHomelabRuntimeError(err)
+			}
+			return nil
+		},
+	}
+	clicommon.AddImportComposeCmdFlags(cmd, importOpts)
+	return cmd
+}
+
+func execImportComposeConfigCmd(ctx context.Context, file string, opts *clicommon.GlobalCmdOptions, importOpts *clicommon.ImportComposeCmdOptions, out io.Writer) error {
+	if err := clicommon.ValidateTextOutputOnly("config import compose", opts); err != nil {
+		return err
+	}
+	if err := importOpts.Validate("config import compose"); err != nil {
+		return err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("config import compose failed while reading the compose file, reason: %w", err)
+	}
+	defer f.Close()
+
+	dir, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return fmt.Errorf("config import compose failed while determining the directory of the compose file, reason: %w", err)
+	}
+	project, unsupported, err := compose.Parse(f, dir)
+	if err != nil {
+		return fmt.Errorf("config import compose failed while parsing the compose file %s, reason: %w", file, err)
+	}
+	conf, warnings := project.HomelabConfig(importOpts.Group())
+
+	// The unsupported keys and the warnings are emitted as comments within
+	// the config since the logs are written to stdout along with the config.
+	var sb strings.Builder
+	for _, k := range unsupported {
+		fmt.Fprintf(&sb, "# WARNING: unsupported key %s was ignored\n", k)
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(&sb, "# WARNING: %s\n", w)
+	}
+	sb.WriteString(utils.PrettyPrintYAML(conf))
+	if _, err := io.WriteString(out, sb.String()); err != nil {
+		return fmt.Errorf("config import compose failed while writing the config, reason: %w", err)
+	}
+	return nil
+}
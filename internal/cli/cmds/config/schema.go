@@ -0,0 +1,45 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/cli/clicommon"
+	"github.com/tuxgal/homelab/internal/cli/clicontext"
+	"github.com/tuxgal/homelab/internal/cli/errors"
+	"github.com/tuxgal/homelab/internal/config/schema"
+)
+
+func SchemaConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Prints the JSON Schema of the homelab config",
+		Long:  `Prints the JSON Schema of the homelab configuration, which can be used by editors (e.g. through yaml-language-server) for validation and completion while editing the homelab config files.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := execSchemaConfigCmd(clicontext.HomelabContext(ctx), opts, cmd.OutOrStdout())
+			if err != nil {
+				return errors.NewHomelabRuntimeError(err)
+			}
+			return nil
+		},
+	}
+}
+
+func execSchemaConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions, out io.Writer) error {
+	if err := clicommon.ValidateTextOutputOnly("config schema", opts); err != nil {
+		return err
+	}
+
+	s, err := schema.Generate().JSON()
+	if err != nil {
+		return fmt.Errorf("config schema failed, reason: %w", err)
+	}
+	if _, err := out.Write(s); err != nil {
+		return fmt.Errorf("config schema failed while writing the schema, reason: %w", err)
+	}
+	return nil
+}
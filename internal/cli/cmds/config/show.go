@@ -2,37 +2,53 @@ package config
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spf13/cobra"
 	"github.com/tuxgal/homelab/internal/cli/clicommon"
 	"github.com/tuxgal/homelab/internal/cli/clicontext"
 	"github.com/tuxgal/homelab/internal/cli/errors"
+	"github.com/tuxgal/homelab/internal/config"
 	"github.com/tuxgal/homelab/internal/utils"
 )
 
 func ShowConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
-	return &cobra.Command{
+	showOpts := &clicommon.ShowConfigCmdOptions{}
+	cmd := &cobra.Command{
 		Use:   "show",
 		Short: "Shows the homelab config",
-		Long:  `Displays the homelab configuration.`,
+		Long:  `Displays the homelab configuration. The values of the secrets read from the files and the process env are redacted wherever they appear within the configuration. The secret commands are never run just for displaying the configuration, hence the values of the secrets read from the commands are not redacted, e.g. when the same value is also read into a config env variable through a valueCommand.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 			cmd.SilenceErrors = true
-			err := execShowConfigCmd(clicontext.HomelabContext(ctx), opts)
+			err := execShowConfigCmd(clicontext.HomelabContext(ctx), opts, showOpts)
 			if err != nil {
 				return errors.NewHomelabRuntimeError(err)
 			}
 			return nil
 		},
 	}
+	clicommon.AddShowConfigCmdFlags(cmd, showOpts)
+	return cmd
 }
 
-func execShowConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) error {
+func execShowConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions, showOpts *clicommon.ShowConfigCmdOptions) error {
 	dep, err := clicommon.BuildDeployment(ctx, "config show", opts)
 	if err != nil {
 		return err
 	}
 
-	log(ctx).Infof("Homelab config:\n%s", utils.PrettyPrintYAML(dep.Config))
+	// Never print the values of the secrets.
+	conf := dep.RedactedConfig()
+	if showOpts.Annotate() {
+		out, err := config.AnnotatedYAML(conf, dep.Provenance)
+		if err != nil {
+			return fmt.Errorf("config show failed, reason: %w", err)
+		}
+		log(ctx).Infof("Homelab config:\n%s", out)
+	} else {
+		log(ctx).Infof("Homelab config:\n%s", utils.PrettyPrintYAML(conf))
+	}
+	clicommon.RecordConfig(opts, conf)
 	return nil
 }
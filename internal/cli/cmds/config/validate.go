@@ -0,0 +1,31 @@
+package config
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/cli/clicommon"
+	"github.com/tuxgal/homelab/internal/cli/clicontext"
+	"github.com/tuxgal/homelab/internal/cli/errors"
+)
+
+func ValidateConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validates the homelab config",
+		Long:  `Validates the homelab configuration, reporting all the errors found along with the config file and line where the offending element is defined.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := execValidateConfigCmd(clicontext.HomelabContext(ctx), opts)
+			if err != nil {
+				return errors.NewHomelabRuntimeError(err)
+			}
+			return nil
+		},
+	}
+}
+
+func execValidateConfigCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) error {
+	return clicommon.ValidateConfigs(ctx, "config validate", opts)
+}
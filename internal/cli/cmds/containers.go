@@ -13,7 +13,15 @@ func ContainersCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra
 	cmd := buildContainersCmd(ctx)
 	cmd.AddCommand(containers.StartCmd(ctx, opts))
 	cmd.AddCommand(containers.StopCmd(ctx, opts))
+	cmd.AddCommand(containers.RestartCmd(ctx, opts))
+	cmd.AddCommand(containers.RecreateCmd(ctx, opts))
+	cmd.AddCommand(containers.PauseCmd(ctx, opts))
+	cmd.AddCommand(containers.UnpauseCmd(ctx, opts))
 	cmd.AddCommand(containers.PurgeCmd(ctx, opts))
+	cmd.AddCommand(containers.StatusCmd(ctx, opts))
+	cmd.AddCommand(containers.LogsCmd(ctx, opts))
+	cmd.AddCommand(containers.ExecCmd(ctx, opts))
+	cmd.AddCommand(containers.ShellCmd(ctx, opts))
 	return cmd
 }
 
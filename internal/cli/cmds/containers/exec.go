@@ -0,0 +1,58 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/cli/clicommon"
+	"github.com/tuxgal/homelab/internal/cli/clicontext"
+	"github.com/tuxgal/homelab/internal/cli/errors"
+)
+
+func ExecCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	execOpts := &clicommon.ExecCmdOptions{}
+	cmd := &cobra.Command{
+		Use:   "exec [container] -- [command]...",
+		Short: "Executes a command in the container",
+		Long:  `Executes the command in the requested running container as specified in the homelab configuration. The name is specified in the group/container format. The command is executed as the user configured for the container, and is attached to a TTY when the container is configured to be attached to a TTY and stdin is a terminal, unless overridden using the --tty flag. The exit code of homelab is the exit code of the command.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				//nolint:staticcheck
+				return fmt.Errorf("Expected a container name argument followed by the command to execute, but found %d arguments instead", len(args))
+			}
+			_, _, err := validateContainerName(args[0])
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := execContainerExecCmd(clicontext.HomelabContext(ctx), cmd, args[0], args[1:], opts, execOpts)
+			if err != nil {
+				return errors.NewHomelabRuntimeError(err)
+			}
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return clicommon.AutoCompleteContainers(ctx, args, "containers exec autocomplete", opts)
+		},
+	}
+	clicommon.AddExecCmdFlags(cmd, execOpts)
+	return cmd
+}
+
+func execContainerExecCmd(ctx context.Context, cmd *cobra.Command, containerArg string, command []string, opts *clicommon.GlobalCmdOptions, execOpts *clicommon.ExecCmdOptions) error {
+	if err := clicommon.ValidateTextOutputOnly("containers exec", opts); err != nil {
+		return err
+	}
+	g, ct := mustContainerName(containerArg)
+	dep, err := clicommon.BuildDeployment(ctx, "containers exec", opts)
+	if err != nil {
+		return err
+	}
+
+	return clicommon.ExecContainerExecCmd(ctx, "containers exec", g, ct, command, dep, opts, execOpts, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
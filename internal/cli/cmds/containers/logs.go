@@ -0,0 +1,58 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/cli/clicommon"
+	"github.com/tuxgal/homelab/internal/cli/clicontext"
+	"github.com/tuxgal/homelab/internal/cli/errors"
+)
+
+func LogsCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	logsOpts := &clicommon.LogsCmdOptions{}
+	cmd := &cobra.Command{
+		Use:   "logs [container]",
+		Short: "Shows the logs of the container",
+		Long:  `Shows the logs of the requested container as specified in the homelab configuration. The name is specified in the group/container format.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				//nolint:staticcheck
+				return fmt.Errorf("Expected exactly one container name argument to be specified, but found %d instead", len(args))
+			}
+			_, _, err := validateContainerName(args[0])
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := execContainerLogsCmd(clicontext.HomelabContext(ctx), cmd, args[0], opts, logsOpts)
+			if err != nil {
+				return errors.NewHomelabRuntimeError(err)
+			}
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return clicommon.AutoCompleteContainers(ctx, args, "containers logs autocomplete", opts)
+		},
+	}
+	clicommon.AddLogsCmdFlags(cmd, logsOpts)
+	return cmd
+}
+
+func execContainerLogsCmd(ctx context.Context, cmd *cobra.Command, containerArg string, opts *clicommon.GlobalCmdOptions, logsOpts *clicommon.LogsCmdOptions) error {
+	if err := clicommon.ValidateTextOutputOnly("containers logs", opts); err != nil {
+		return err
+	}
+	g, ct := mustContainerName(containerArg)
+	dep, err := clicommon.BuildDeployment(ctx, "containers logs", opts)
+	if err != nil {
+		return err
+	}
+
+	return clicommon.ExecContainerLogsCmd(ctx, "containers logs", g, ct, dep, logsOpts, cmd.OutOrStdout())
+}
@@ -0,0 +1,62 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/cli/clicommon"
+	"github.com/tuxgal/homelab/internal/cli/clicontext"
+	"github.com/tuxgal/homelab/internal/cli/errors"
+)
+
+func PauseCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause [container]",
+		Short: "Pauses the container",
+		Long:  `Pauses all processes within the requested container if it is running, freezing it until it is unpaused. The name is specified in the group/container format.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				//nolint:staticcheck
+				return fmt.Errorf("Expected exactly one container name argument to be specified, but found %d instead", len(args))
+			}
+			_, _, err := validateContainerName(args[0])
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := execContainerPauseCmd(clicontext.HomelabContext(ctx), args[0], opts)
+			if err != nil {
+				return errors.NewHomelabRuntimeError(err)
+			}
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return clicommon.AutoCompleteContainers(ctx, args, "containers pause autocomplete", opts)
+		},
+	}
+}
+
+func execContainerPauseCmd(ctx context.Context, containerArg string, opts *clicommon.GlobalCmdOptions) error {
+	g, ct := mustContainerName(containerArg)
+	dep, err := clicommon.BuildDeployment(ctx, "containers pause", opts)
+	if err != nil {
+		return err
+	}
+
+	return clicommon.ExecContainerGroupCmd(
+		ctx,
+		"containers pause",
+		fmt.Sprintf("Pausing container %s in group %s", ct, g),
+		g,
+		ct,
+		dep,
+		opts,
+		&clicommon.ContainerGroupCmdOptions{},
+		clicommon.ExecPauseContainer,
+	)
+}
@@ -11,7 +11,8 @@ import (
 )
 
 func PurgeCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
-	return &cobra.Command{
+	stopOpts := &clicommon.StopCmdOptions{}
+	cmd := &cobra.Command{
 		Use:   "purge [container]",
 		Short: "Purges the container",
 		Long:  `Purges the requested container as specified in the homelab configuration. The name is specified in the group/container format.`,
@@ -29,7 +30,7 @@ func PurgeCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comm
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 			cmd.SilenceErrors = true
-			err := execContainerPurgeCmd(clicontext.HomelabContext(ctx), args[0], opts)
+			err := execContainerPurgeCmd(clicontext.HomelabContext(ctx), args[0], opts, stopOpts)
 			if err != nil {
 				return errors.NewHomelabRuntimeError(err)
 			}
@@ -39,9 +40,14 @@ func PurgeCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comm
 			return clicommon.AutoCompleteContainers(ctx, args, "containers purge autocomplete", opts)
 		},
 	}
+	clicommon.AddStopCmdFlags(cmd, stopOpts)
+	return cmd
 }
 
-func execContainerPurgeCmd(ctx context.Context, containerArg string, opts *clicommon.GlobalCmdOptions) error {
+func execContainerPurgeCmd(ctx context.Context, containerArg string, opts *clicommon.GlobalCmdOptions, stopOpts *clicommon.StopCmdOptions) error {
+	if err := stopOpts.Validate("containers purge"); err != nil {
+		return err
+	}
 	g, ct := mustContainerName(containerArg)
 	dep, err := clicommon.BuildDeployment(ctx, "containers purge", opts)
 	if err != nil {
@@ -55,6 +61,8 @@ func execContainerPurgeCmd(ctx context.Context, containerArg string, opts *clico
 		g,
 		ct,
 		dep,
-		clicommon.ExecPurgeContainer,
+		opts,
+		&clicommon.ContainerGroupCmdOptions{StopOrder: true},
+		clicommon.ExecPurgeContainer(stopOpts),
 	)
 }
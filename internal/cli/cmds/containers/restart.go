@@ -0,0 +1,62 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/cli/clicommon"
+	"github.com/tuxgal/homelab/internal/cli/clicontext"
+	"github.com/tuxgal/homelab/internal/cli/errors"
+)
+
+func RestartCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restart [container]",
+		Short: "Restarts the container",
+		Long:  `Restarts the requested container in place without recreating it, using the stop signal and timeout specified in the homelab configuration. The name is specified in the group/container format.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				//nolint:staticcheck
+				return fmt.Errorf("Expected exactly one container name argument to be specified, but found %d instead", len(args))
+			}
+			_, _, err := validateContainerName(args[0])
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := execContainerRestartCmd(clicontext.HomelabContext(ctx), args[0], opts)
+			if err != nil {
+				return errors.NewHomelabRuntimeError(err)
+			}
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return clicommon.AutoCompleteContainers(ctx, args, "containers restart autocomplete", opts)
+		},
+	}
+}
+
+func execContainerRestartCmd(ctx context.Context, containerArg string, opts *clicommon.GlobalCmdOptions) error {
+	g, ct := mustContainerName(containerArg)
+	dep, err := clicommon.BuildDeployment(ctx, "containers restart", opts)
+	if err != nil {
+		return err
+	}
+
+	return clicommon.ExecContainerGroupCmd(
+		ctx,
+		"containers restart",
+		fmt.Sprintf("Restarting container %s in group %s", ct, g),
+		g,
+		ct,
+		dep,
+		opts,
+		&clicommon.ContainerGroupCmdOptions{},
+		clicommon.ExecRestartContainer,
+	)
+}
@@ -0,0 +1,58 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/cli/clicommon"
+	"github.com/tuxgal/homelab/internal/cli/clicontext"
+	"github.com/tuxgal/homelab/internal/cli/errors"
+)
+
+func ShellCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	execOpts := &clicommon.ExecCmdOptions{}
+	cmd := &cobra.Command{
+		Use:   "shell [container]",
+		Short: "Opens a shell in the container",
+		Long:  `Opens an interactive shell in the requested running container as specified in the homelab configuration. The name is specified in the group/container format. The shell is run as the user configured for the container, and is attached to a TTY when the container is configured to be attached to a TTY and stdin is a terminal, unless overridden using the --tty flag. The exit code of homelab is the exit code of the shell.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				//nolint:staticcheck
+				return fmt.Errorf("Expected exactly one container name argument to be specified, but found %d instead", len(args))
+			}
+			_, _, err := validateContainerName(args[0])
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := execContainerShellCmd(clicontext.HomelabContext(ctx), cmd, args[0], opts, execOpts)
+			if err != nil {
+				return errors.NewHomelabRuntimeError(err)
+			}
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return clicommon.AutoCompleteContainers(ctx, args, "containers shell autocomplete", opts)
+		},
+	}
+	clicommon.AddShellCmdFlags(cmd, execOpts)
+	return cmd
+}
+
+func execContainerShellCmd(ctx context.Context, cmd *cobra.Command, containerArg string, opts *clicommon.GlobalCmdOptions, execOpts *clicommon.ExecCmdOptions) error {
+	if err := clicommon.ValidateTextOutputOnly("containers shell", opts); err != nil {
+		return err
+	}
+	g, ct := mustContainerName(containerArg)
+	dep, err := clicommon.BuildDeployment(ctx, "containers shell", opts)
+	if err != nil {
+		return err
+	}
+
+	return clicommon.ExecContainerExecCmd(ctx, "containers shell", g, ct, []string{execOpts.Shell()}, dep, opts, execOpts, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
@@ -11,7 +11,8 @@ import (
 )
 
 func StartCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
-	return &cobra.Command{
+	startOpts := &clicommon.StartCmdOptions{}
+	cmd := &cobra.Command{
 		Use:   "start [container]",
 		Short: "Starts the container",
 		Long:  `Starts the requested container as specified in the homelab configuration. The name is specified in the group/container format.`,
@@ -29,7 +30,7 @@ func StartCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comm
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 			cmd.SilenceErrors = true
-			err := execContainerStartCmd(clicontext.HomelabContext(ctx), args[0], opts)
+			err := execContainerStartCmd(clicontext.HomelabContext(ctx), args[0], opts, startOpts)
 			if err != nil {
 				return errors.NewHomelabRuntimeError(err)
 			}
@@ -39,9 +40,11 @@ func StartCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comm
 			return clicommon.AutoCompleteContainers(ctx, args, "containers start autocomplete", opts)
 		},
 	}
+	clicommon.AddStartCmdFlags(cmd, startOpts)
+	return cmd
 }
 
-func execContainerStartCmd(ctx context.Context, containerArg string, opts *clicommon.GlobalCmdOptions) error {
+func execContainerStartCmd(ctx context.Context, containerArg string, opts *clicommon.GlobalCmdOptions, startOpts *clicommon.StartCmdOptions) error {
 	g, ct := mustContainerName(containerArg)
 	dep, err := clicommon.BuildDeployment(ctx, "containers start", opts)
 	if err != nil {
@@ -61,6 +64,8 @@ func execContainerStartCmd(ctx context.Context, containerArg string, opts *clico
 		g,
 		ct,
 		dep,
-		clicommon.ExecStartContainer,
+		opts,
+		startOpts.ContainerGroupCmdOptions(),
+		clicommon.ExecStartContainer(startOpts),
 	)
 }
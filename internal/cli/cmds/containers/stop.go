@@ -11,7 +11,8 @@ import (
 )
 
 func StopCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
-	return &cobra.Command{
+	stopOpts := &clicommon.StopCmdOptions{}
+	cmd := &cobra.Command{
 		Use:   "stop [container]",
 		Short: "Stops the container",
 		Long:  `Stops the requested container as specified in the homelab configuration. The name is specified in the group/container format.`,
@@ -29,7 +30,7 @@ func StopCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comma
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 			cmd.SilenceErrors = true
-			err := execContainerStopCmd(clicontext.HomelabContext(ctx), args[0], opts)
+			err := execContainerStopCmd(clicontext.HomelabContext(ctx), args[0], opts, stopOpts)
 			if err != nil {
 				return errors.NewHomelabRuntimeError(err)
 			}
@@ -39,9 +40,14 @@ func StopCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comma
 			return clicommon.AutoCompleteContainers(ctx, args, "containers stop autocomplete", opts)
 		},
 	}
+	clicommon.AddStopCmdFlags(cmd, stopOpts)
+	return cmd
 }
 
-func execContainerStopCmd(ctx context.Context, containerArg string, opts *clicommon.GlobalCmdOptions) error {
+func execContainerStopCmd(ctx context.Context, containerArg string, opts *clicommon.GlobalCmdOptions, stopOpts *clicommon.StopCmdOptions) error {
+	if err := stopOpts.Validate("containers stop"); err != nil {
+		return err
+	}
 	g, ct := mustContainerName(containerArg)
 	dep, err := clicommon.BuildDeployment(ctx, "containers stop", opts)
 	if err != nil {
@@ -55,6 +61,8 @@ func execContainerStopCmd(ctx context.Context, containerArg string, opts *clicom
 		g,
 		ct,
 		dep,
-		clicommon.ExecStopContainer,
+		opts,
+		&clicommon.ContainerGroupCmdOptions{StopOrder: true},
+		clicommon.ExecStopContainer(stopOpts),
 	)
 }
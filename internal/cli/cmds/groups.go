@@ -13,7 +13,13 @@ func GroupsCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Com
 	cmd := buildGroupsCmd(ctx)
 	cmd.AddCommand(groups.StartCmd(ctx, opts))
 	cmd.AddCommand(groups.StopCmd(ctx, opts))
+	cmd.AddCommand(groups.RestartCmd(ctx, opts))
+	cmd.AddCommand(groups.RecreateCmd(ctx, opts))
+	cmd.AddCommand(groups.PauseCmd(ctx, opts))
+	cmd.AddCommand(groups.UnpauseCmd(ctx, opts))
 	cmd.AddCommand(groups.PurgeCmd(ctx, opts))
+	cmd.AddCommand(groups.StatusCmd(ctx, opts))
+	cmd.AddCommand(groups.LogsCmd(ctx, opts))
 	return cmd
 }
 
@@ -0,0 +1,53 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/cli/clicommon"
+	"github.com/tuxgal/homelab/internal/cli/clicontext"
+	"github.com/tuxgal/homelab/internal/cli/errors"
+)
+
+func LogsCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	logsOpts := &clicommon.LogsCmdOptions{}
+	cmd := &cobra.Command{
+		Use:   "logs [group]",
+		Short: "Shows the logs of the containers in the group",
+		Long:  `Shows the logs of the containers in the requested group as specified in the homelab configuration, with every line prefixed by the container it belongs to. Logs can be shown for a single group or all groups (by using 'all' as the group name).`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				//nolint:staticcheck
+				return fmt.Errorf("Expected exactly one group name argument to be specified, but found %d instead", len(args))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := execGroupLogsCmd(clicontext.HomelabContext(ctx), cmd, args[0], opts, logsOpts)
+			if err != nil {
+				return errors.NewHomelabRuntimeError(err)
+			}
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return clicommon.AutoCompleteGroups(ctx, args, "groups logs autocomplete", opts)
+		},
+	}
+	clicommon.AddLogsCmdFlags(cmd, logsOpts)
+	return cmd
+}
+
+func execGroupLogsCmd(ctx context.Context, cmd *cobra.Command, group string, opts *clicommon.GlobalCmdOptions, logsOpts *clicommon.LogsCmdOptions) error {
+	if err := clicommon.ValidateTextOutputOnly("groups logs", opts); err != nil {
+		return err
+	}
+	dep, err := clicommon.BuildDeployment(ctx, "groups logs", opts)
+	if err != nil {
+		return err
+	}
+
+	return clicommon.ExecContainerLogsCmd(ctx, "groups logs", group, "", dep, logsOpts, cmd.OutOrStdout())
+}
@@ -11,7 +11,8 @@ import (
 )
 
 func PurgeCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
-	return &cobra.Command{
+	stopOpts := &clicommon.StopCmdOptions{}
+	cmd := &cobra.Command{
 		Use:   "purge [group]",
 		Short: "Purges one or more containers in the group",
 		Long:  `Purges one or more containers in the requested group as specified in the homelab configuration. Containers can be purged individually, as a group or all groups (by using 'all' as the group name).`,
@@ -25,7 +26,7 @@ func PurgeCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comm
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 			cmd.SilenceErrors = true
-			err := execGroupPurgeCmd(clicontext.HomelabContext(ctx), args[0], opts)
+			err := execGroupPurgeCmd(clicontext.HomelabContext(ctx), args[0], opts, stopOpts)
 			if err != nil {
 				return errors.NewHomelabRuntimeError(err)
 			}
@@ -35,9 +36,14 @@ func PurgeCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comm
 			return clicommon.AutoCompleteGroups(ctx, args, "groups purge autocomplete", opts)
 		},
 	}
+	clicommon.AddStopCmdFlags(cmd, stopOpts)
+	return cmd
 }
 
-func execGroupPurgeCmd(ctx context.Context, group string, opts *clicommon.GlobalCmdOptions) error {
+func execGroupPurgeCmd(ctx context.Context, group string, opts *clicommon.GlobalCmdOptions, stopOpts *clicommon.StopCmdOptions) error {
+	if err := stopOpts.Validate("groups purge"); err != nil {
+		return err
+	}
 	dep, err := clicommon.BuildDeployment(ctx, "groups purge", opts)
 	if err != nil {
 		return err
@@ -56,6 +62,8 @@ func execGroupPurgeCmd(ctx context.Context, group string, opts *clicommon.Global
 		group,
 		"",
 		dep,
-		clicommon.ExecPurgeContainer,
+		opts,
+		&clicommon.ContainerGroupCmdOptions{StopOrder: true},
+		clicommon.ExecPurgeContainer(stopOpts),
 	)
 }
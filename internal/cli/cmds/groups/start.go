@@ -11,7 +11,8 @@ import (
 )
 
 func StartCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
-	return &cobra.Command{
+	startOpts := &clicommon.StartCmdOptions{}
+	cmd := &cobra.Command{
 		Use:   "start [group]",
 		Short: "Starts one or more containers in the group",
 		Long:  `Starts one or more containers in the requested group as specified in the homelab configuration. Containers can be started individually, as a group or all groups (by using 'all' as the group name).`,
@@ -25,7 +26,7 @@ func StartCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comm
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 			cmd.SilenceErrors = true
-			err := execGroupStartCmd(clicontext.HomelabContext(ctx), args[0], opts)
+			err := execGroupStartCmd(clicontext.HomelabContext(ctx), args[0], opts, startOpts)
 			if err != nil {
 				return errors.NewHomelabRuntimeError(err)
 			}
@@ -35,9 +36,11 @@ func StartCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comm
 			return clicommon.AutoCompleteGroups(ctx, args, "groups start autocomplete", opts)
 		},
 	}
+	clicommon.AddStartCmdFlags(cmd, startOpts)
+	return cmd
 }
 
-func execGroupStartCmd(ctx context.Context, group string, opts *clicommon.GlobalCmdOptions) error {
+func execGroupStartCmd(ctx context.Context, group string, opts *clicommon.GlobalCmdOptions, startOpts *clicommon.StartCmdOptions) error {
 	dep, err := clicommon.BuildDeployment(ctx, "groups start", opts)
 	if err != nil {
 		return err
@@ -57,6 +60,8 @@ func execGroupStartCmd(ctx context.Context, group string, opts *clicommon.Global
 		group,
 		"",
 		dep,
-		clicommon.ExecStartContainer,
+		opts,
+		startOpts.ContainerGroupCmdOptions(),
+		clicommon.ExecStartContainer(startOpts),
 	)
 }
@@ -0,0 +1,68 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tuxgal/homelab/internal/cli/clicommon"
+	"github.com/tuxgal/homelab/internal/cli/clicontext"
+	"github.com/tuxgal/homelab/internal/cli/errors"
+)
+
+func StatusCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status [group]",
+		Short: "Shows the status of one or more containers in the group",
+		Long:  `Shows the configured and the live state of one or more containers in the requested group as specified in the homelab configuration. Containers can be queried individually, as a group or all groups (by using 'all' as the group name).`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				//nolint:staticcheck
+				return fmt.Errorf("Expected exactly one group name argument to be specified, but found %d instead", len(args))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := execGroupStatusCmd(clicontext.HomelabContext(ctx), args[0], opts)
+			if err != nil {
+				return errors.NewHomelabRuntimeError(err)
+			}
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return clicommon.AutoCompleteGroups(ctx, args, "groups status autocomplete", opts)
+		},
+	}
+}
+
+func execGroupStatusCmd(ctx context.Context, group string, opts *clicommon.GlobalCmdOptions) error {
+	dep, err := clicommon.BuildDeployment(ctx, "groups status", opts)
+	if err != nil {
+		return err
+	}
+
+	var action string
+	if group == clicommon.AllGroups {
+		action = "Querying status of containers in all groups"
+	} else {
+		action = fmt.Sprintf("Querying status of containers in group %s", group)
+	}
+	err = clicommon.ExecContainerGroupCmd(
+		ctx,
+		"groups status",
+		action,
+		group,
+		"",
+		dep,
+		opts,
+		&clicommon.ContainerGroupCmdOptions{},
+		clicommon.ExecStatusContainer,
+	)
+	if err != nil {
+		return err
+	}
+
+	return clicommon.ExecUnmanagedContainersStatus(ctx, "groups status", group, dep, opts)
+}
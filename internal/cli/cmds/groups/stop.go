@@ -11,7 +11,8 @@ import (
 )
 
 func StopCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Command {
-	return &cobra.Command{
+	stopOpts := &clicommon.StopCmdOptions{}
+	cmd := &cobra.Command{
 		Use:   "stop [group]",
 		Short: "Stops one or more containers in the group",
 		Long:  `Stops one or more containers in the requested group as specified in the homelab configuration. Containers can be stopped individually, as a group or all groups (by using 'all' as the group name).`,
@@ -25,7 +26,7 @@ func StopCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comma
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 			cmd.SilenceErrors = true
-			err := execGroupStopCmd(clicontext.HomelabContext(ctx), args[0], opts)
+			err := execGroupStopCmd(clicontext.HomelabContext(ctx), args[0], opts, stopOpts)
 			if err != nil {
 				return errors.NewHomelabRuntimeError(err)
 			}
@@ -35,9 +36,14 @@ func StopCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cobra.Comma
 			return clicommon.AutoCompleteGroups(ctx, args, "groups stop autocomplete", opts)
 		},
 	}
+	clicommon.AddStopCmdFlags(cmd, stopOpts)
+	return cmd
 }
 
-func execGroupStopCmd(ctx context.Context, group string, opts *clicommon.GlobalCmdOptions) error {
+func execGroupStopCmd(ctx context.Context, group string, opts *clicommon.GlobalCmdOptions, stopOpts *clicommon.StopCmdOptions) error {
+	if err := stopOpts.Validate("groups stop"); err != nil {
+		return err
+	}
 	dep, err := clicommon.BuildDeployment(ctx, "groups stop", opts)
 	if err != nil {
 		return err
@@ -56,6 +62,8 @@ func execGroupStopCmd(ctx context.Context, group string, opts *clicommon.GlobalC
 		group,
 		"",
 		dep,
-		clicommon.ExecStopContainer,
+		opts,
+		&clicommon.ContainerGroupCmdOptions{StopOrder: true},
+		clicommon.ExecStopContainer(stopOpts),
 	)
 }
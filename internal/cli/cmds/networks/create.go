@@ -49,6 +49,7 @@ func execNetworksCreateCmd(ctx context.Context, network string, opts *clicommon.
 		"Creating networks",
 		network,
 		dep,
+		opts,
 		clicommon.ExecCreateNetwork,
 	)
 }
@@ -49,6 +49,7 @@ func execNetworksDeleteCmd(ctx context.Context, network string, opts *clicommon.
 		"Deleting networks",
 		network,
 		dep,
+		opts,
 		clicommon.ExecDeleteNetwork,
 	)
 }
@@ -11,6 +11,29 @@ func (e *HomelabRuntimeError) Error() string {
 	return e.err.Error()
 }
 
+func (e *HomelabRuntimeError) Unwrap() error {
+	return e.err
+}
+
 func NewHomelabRuntimeError(err error) *HomelabRuntimeError {
 	return &HomelabRuntimeError{err: err}
 }
+
+// CommandExitError represents a command executed inside a container
+// exiting with a non-zero exit code, which homelab exits with as well.
+type CommandExitError struct {
+	err      error
+	exitCode int
+}
+
+func (e *CommandExitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *CommandExitError) ExitCode() int {
+	return e.exitCode
+}
+
+func NewCommandExitError(err error, exitCode int) *CommandExitError {
+	return &CommandExitError{err: err, exitCode: exitCode}
+}
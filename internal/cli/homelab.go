@@ -9,6 +9,7 @@ import (
 	"github.com/tuxgal/homelab/internal/cli/clicommon"
 	"github.com/tuxgal/homelab/internal/cli/cmds"
 	"github.com/tuxgal/homelab/internal/cli/version"
+	"github.com/tuxgal/homelab/internal/docker"
 )
 
 const (
@@ -36,6 +37,9 @@ func buildHomelabCmd(ctx context.Context, opts *clicommon.GlobalCmdOptions) *cob
 		Long: `A CLI for managing both the configuration and deployment of groups of docker containers on a given host.
 
 The configuration is managed using a yaml file. The configuration specifies the container groups and individual containers, their properties and how to deploy them.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.InitResult(cmd)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// TODO: print ascii art.
 			return fmt.Errorf("homelab sub-command is required")
@@ -61,20 +65,51 @@ The configuration is managed using a yaml file. The configuration specifies the
 	return cmd
 }
 
-func initHomelabCmd(ctx context.Context) *cobra.Command {
-	globalOpts := clicommon.GlobalCmdOptions{}
-	homelabCmd := buildHomelabCmd(ctx, &globalOpts)
-	homelabCmd.AddCommand(cmds.ConfigCmd(ctx, &globalOpts))
-	homelabCmd.AddCommand(cmds.GroupsCmd(ctx, &globalOpts))
-	homelabCmd.AddCommand(cmds.ContainersCmd(ctx, &globalOpts))
-	homelabCmd.AddCommand(cmds.NetworksCmd(ctx, &globalOpts))
+func initHomelabCmd(ctx context.Context, globalOpts *clicommon.GlobalCmdOptions) *cobra.Command {
+	homelabCmd := buildHomelabCmd(ctx, globalOpts)
+	homelabCmd.AddCommand(cmds.ConfigCmd(ctx, globalOpts))
+	homelabCmd.AddCommand(cmds.GroupsCmd(ctx, globalOpts))
+	homelabCmd.AddCommand(cmds.ContainersCmd(ctx, globalOpts))
+	homelabCmd.AddCommand(cmds.NetworksCmd(ctx, globalOpts))
 	return homelabCmd
 }
 
-func Exec(ctx context.Context, outW, errW io.Writer, args ...string) error {
-	homelab := initHomelabCmd(ctx)
+func Exec(ctx context.Context, inR io.Reader, outW, errW io.Writer, args ...string) error {
+	// The image pull progress is rendered along with the logs, to keep
+	// it out of the result document written to outW.
+	ctx = docker.WithPullProgressWriter(ctx, LogWriter(ctx, outW, errW, args...))
+	globalOpts := clicommon.GlobalCmdOptions{}
+	homelab := initHomelabCmd(ctx, &globalOpts)
+	homelab.SetIn(inR)
 	homelab.SetOut(outW)
 	homelab.SetErr(errW)
 	homelab.SetArgs(args)
-	return homelab.Execute()
+	err := homelab.Execute()
+	if werr := clicommon.WriteResult(outW, &globalOpts, err); werr != nil && err == nil {
+		return werr
+	}
+	return err
+}
+
+// LogWriter returns the writer where the logs must be written to for the
+// specified command line arguments. The logs are written to errW when a
+// machine readable output format is requested or when the config is
+// being exported, leaving outW exclusively for the emitted document.
+// Since the destination of the logs needs to be determined even before
+// executing the command, the arguments are parsed upfront using a
+// separate instance of the command tree, and any errors are reported
+// later while executing the command.
+func LogWriter(ctx context.Context, outW, errW io.Writer, args ...string) io.Writer {
+	homelab := initHomelabCmd(ctx, &clicommon.GlobalCmdOptions{})
+	cmd, flags, err := homelab.Find(args)
+	if err != nil {
+		return outW
+	}
+	if err := cmd.ParseFlags(flags); err != nil {
+		return outW
+	}
+	if clicommon.OutputFormatFromFlags(cmd).IsMachineReadable() || clicommon.IsExportCmd(cmd) {
+		return errW
+	}
+	return outW
 }
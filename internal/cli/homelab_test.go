@@ -3,7 +3,9 @@ package cli
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/tuxgal/homelab/internal/cli/version"
 	"github.com/tuxgal/homelab/internal/cmdexec/fakecmdexec"
@@ -13,6 +15,7 @@ import (
 	"github.com/tuxgal/homelab/internal/testutils"
 	"github.com/tuxgal/homelab/internal/utils"
 	"github.com/tuxgal/tuxlog"
+	"gopkg.in/yaml.v3"
 )
 
 var executeHomelabCmdTests = []struct {
@@ -171,6 +174,203 @@ containers:
     lifecycle:
       order: 10`,
 	},
+	{
+		name: "Homelab Command - Show Config - Annotate",
+		args: []string{
+			"config",
+			"show",
+			"--annotate",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/show-config-cmd-minimal", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Homelab config:
+global:
+  baseDir: testdata/dummy-base-dir # common/global\.yaml:2
+ipam:
+  networks:
+    bridgeModeNetworks:
+      - name: net1 # common/ipam\.yaml:4
+        hostInterfaceName: docker-net1 # common/ipam\.yaml:5
+        cidr:
+          v4: 172\.18\.100\.0/24 # common/ipam\.yaml:7
+        priority: 1 # common/ipam\.yaml:8
+        containers:
+          - ip:
+              v4: 172\.18\.100\.11 # common/ipam\.yaml:11
+            container:
+              group: g1 # common/ipam\.yaml:13
+              container: c1 # common/ipam\.yaml:14
+hosts:
+  - name: fakehost # common/hosts\.yaml:2
+    allowedContainers:
+      - group: g1 # common/hosts\.yaml:4
+        container: c1 # common/hosts\.yaml:5
+groups:
+  - name: g1 # common/groups\.yaml:2
+    order: 1 # common/groups\.yaml:3
+containers:
+  - info:
+      group: g1 # g1/c1\.yaml:3
+      container: c1 # g1/c1\.yaml:4
+    image:
+      image: abc/xyz # g1/c1\.yaml:6
+    lifecycle:
+      order: 10 # g1/c1\.yaml:8`,
+	},
+	{
+		name: "Homelab Command - Show Config - Redacted Secrets",
+		args: []string{
+			"config",
+			"show",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/show-config-cmd-secrets", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Homelab config:
+global:
+  baseDir: testdata/dummy-base-dir
+  env:
+    - var: DB_PASSWORD
+      valueFile: secrets/db-password\.txt
+groups:
+  - name: g1
+    order: 1
+containers:
+  - info:
+      group: g1
+      container: c1
+    image:
+      image: abc/xyz
+    lifecycle:
+      order: 10
+    runtime:
+      env:
+        - var: DB_PASSWORD
+          value: <redacted>
+    secrets:
+      - name: db-password
+secrets:
+  - name: db-password
+    file: secrets/db-password\.txt`,
+	},
+	{
+		name: "Homelab Command - Config Schema",
+		args: []string{
+			"config",
+			"schema",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `(?s)\{
+  "\$schema": "https://json-schema\.org/draft/2020-12/schema",
+  "title": "Homelab config",
+  "type": "object",
+  "properties": \{
+    "containers": \{
+      "type": "array",
+      "items": \{
+        "\$ref": "#/\$defs/Container"
+      \}
+    \},
+.+
+  "additionalProperties": false,
+  "\$defs": \{
+.+
+\}`,
+	},
+	{
+		name: "Homelab Command - Config Export Compose",
+		args: []string{
+			"config",
+			"export",
+			"compose",
+			"--group",
+			"g2",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/export-compose-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `# WARNING: Container g2-c3 depends on container g1-c1 which is not part of the compose document
+services:
+  g2-c3:
+    container_name: g2-c3
+    image: abc/xyz3
+    domainname: example\.tld
+    restart: unless-stopped
+    networks:
+      net2:
+        ipv4_address: 172\.18\.101\.31
+networks:
+  net2:
+    name: net2
+    driver: bridge
+(?s).+
+    ipam:
+      driver: default
+      config:
+        - subnet: 172\.18\.101\.0/24
+          gateway: 172\.18\.101\.1`,
+	},
+	{
+		name: "Homelab Command - Config Import Compose",
+		args: []string{
+			"config",
+			"import",
+			"compose",
+			fmt.Sprintf("%s/testdata/import-compose-cmd/docker-compose.yml", testhelpers.Pwd()),
+			"--group",
+			"legacy",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `# WARNING: unsupported key services\.web\.networks\.backend\.aliases was ignored
+# WARNING: unsupported key services\.web\.build was ignored
+# WARNING: unsupported key volumes was ignored
+# WARNING: network backend does not specify the bridge interface name, using docker-backend
+# WARNING: network backend uses the gateway 172\.21\.0\.254 which is not the first address within the subnet 172\.21\.0\.0/24
+# WARNING: service db is attached to the network backend without a static IPv4 address, which is not supported
+# WARNING: service web specifies the container name web, which is replaced by the homelab container name legacy-web
+# WARNING: service web passes through the environment variable PASS_THROUGH without a value, which was skipped
+# WARNING: service web publishes the port 9000 on a random host port, which is not supported
+# WARNING: service web mounts a volume at /var/log/nginx, only bind and tmpfs mounts are supported
+ipam:
+  networks:
+    bridgeModeNetworks:
+      - name: backend
+(?s).+
+groups:
+  - name: legacy
+    order: 1
+containers:
+  - info:
+      group: legacy
+      container: db
+(?s).+
+    resources:
+      memory: "268435456"`,
+	},
+	{
+		name: "Homelab Command - Validate Config",
+		args: []string{
+			"config",
+			"validate",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/show-config-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Homelab config is valid`,
+	},
 	{
 		name: "Homelab Command - Groups Start - All Groups With Real Host Info",
 		args: []string{
@@ -209,11 +409,13 @@ Container g2-c3 not allowed to run on host [^\s]+`,
 			UseRealUserInfo: true,
 		},
 		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
 Created network net1
 Creating container g1-c1
 Starting container g1-c1
 Container g1-c2 not allowed to run on host FakeHost
 Pulling image: abc/xyz3
+Pulled image abc/xyz3 on the fake docker host
 Created network net2
 Creating container g2-c3
 Starting container g2-c3`,
@@ -236,11 +438,13 @@ Starting container g2-c3`,
 			}),
 		},
 		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
 Created network net1
 Creating container g1-c1
 Starting container g1-c1
 Container g1-c2 not allowed to run on host FakeHost
 Pulling image: abc/xyz3
+Pulled image abc/xyz3 on the fake docker host
 Created network net2
 Creating container g2-c3
 Starting container g2-c3`,
@@ -266,6 +470,7 @@ Starting container g2-c3`,
 			}),
 		},
 		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
 Created network net1
 Creating container g1-c1
 Warnings encountered while creating the container g1-c1
@@ -275,6 +480,7 @@ Warnings encountered while creating the container g1-c1
 Starting container g1-c1
 Container g1-c2 not allowed to run on host FakeHost
 Pulling image: abc/xyz3
+Pulled image abc/xyz3 on the fake docker host
 Created network net2
 Creating container g2-c3
 Starting container g2-c3`,
@@ -300,6 +506,7 @@ Starting container g2-c3`,
 			}),
 		},
 		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
 Warning encountered while creating the network net1
 warning generated during network create for network net1 on the fake docker host
 Created network net1
@@ -307,6 +514,7 @@ Creating container g1-c1
 Starting container g1-c1
 Container g1-c2 not allowed to run on host FakeHost
 Pulling image: abc/xyz3
+Pulled image abc/xyz3 on the fake docker host
 Created network net2
 Creating container g2-c3
 Starting container g2-c3`,
@@ -332,6 +540,7 @@ Starting container g2-c3`,
 			}),
 		},
 		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
 Created network net1
 Creating container g1-c1
 Starting container g1-c1
@@ -360,14 +569,17 @@ Starting container g2-c3`,
 			}),
 		},
 		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
 Created network net1
 Creating container g1-c1
 Starting container g1-c1
 Container g1-c2 not allowed to run on host FakeHost
 Pulling image: abc/xyz3
+Pulled image abc/xyz3 on the fake docker host
 Creating container g1-c3
 Starting container g1-c3
 Pulling image: abc/xyz4
+Pulled image abc/xyz4 on the fake docker host
 Created network net2
 Creating container g2-c4
 Starting container g2-c4`,
@@ -391,15 +603,18 @@ Starting container g2-c4`,
 			}),
 		},
 		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
 Created network net1
 Creating container g1-c1
 Starting container g1-c1
 Container g1-c2 not allowed to run on host FakeHost
 Pulling image: abc/xyz3
+Pulled image abc/xyz3 on the fake docker host
 Container g1-c3 has no network endpoints configured, this is uncommon!
 Creating container g1-c3
 Starting container g1-c3
 Pulling image: abc/xyz4
+Pulled image abc/xyz4 on the fake docker host
 Created network net2
 Creating container g2-c4
 Starting container g2-c4`,
@@ -434,6 +649,7 @@ Starting container g2-c4`,
 			}),
 		},
 		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
 Created network net1
 Creating container g1-c1
 Starting container g1-c1
@@ -441,6 +657,7 @@ Container g1-c2 not allowed to run on host FakeHost
 Output from start pre-hook for container g2-c3 >>>
 Output from a custom start prehook
 Pulling image: abc/xyz3
+Pulled image abc/xyz3 on the fake docker host
 Created network net2
 Creating container g2-c3
 Starting container g2-c3`,
@@ -472,6 +689,7 @@ Creating container g1-c1
 Starting container g1-c1
 Container g1-c2 not allowed to run on host FakeHost
 Pulling image: abc/xyz3
+Pulled image abc/xyz3 on the fake docker host
 Created network net2
 Creating container g2-c3
 Starting container g2-c3`,
@@ -493,6 +711,7 @@ Starting container g2-c3`,
 			}),
 		},
 		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
 Created network net1
 Creating container g1-c1
 Starting container g1-c1
@@ -515,38 +734,49 @@ Container g1-c2 not allowed to run on host FakeHost`,
 			}),
 		},
 		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
 Created network net1
 Creating container g1-c1
 Starting container g1-c1`,
 	},
 	{
-		name: "Homelab Command - Containers Start - One Container With Wait After Start Delay",
+		name: "Homelab Command - Containers Start - One Container - Changed Only",
 		args: []string{
 			"containers",
 			"start",
 			"g1/c1",
 			"--configs-dir",
-			fmt.Sprintf("%s/testdata/start-cmd-with-wait-after-start-delay", testhelpers.Pwd()),
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--changed-only",
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
 				ValidImagesForPull: utils.StringSet{
 					"abc/xyz": {},
 				},
 			}),
 		},
 		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
+Stopping container g1-c1
+Removing container g1-c1
 Created network net1
 Creating container g1-c1
-Starting container g1-c1
-Waiting for 1s after container startup of g1-c1`,
+Starting container g1-c1`,
 	},
 	{
-		name: "Homelab Command - Groups Stop - All Groups",
+		name: "Homelab Command - Containers Recreate - One Container",
 		args: []string{
-			"groups",
-			"stop",
-			"all",
+			"containers",
+			"recreate",
+			"g1/c1",
 			"--configs-dir",
 			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
 		},
@@ -556,28 +786,52 @@ Waiting for 1s after container startup of g1-c1`,
 					{
 						Name:  "g1-c1",
 						Image: "abc/xyz",
-						State: docker.ContainerStateCreated,
+						State: docker.ContainerStateRunning,
 					},
+				},
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
+Stopping container g1-c1
+Removing container g1-c1
+Created network net1
+Creating container g1-c1
+Starting container g1-c1`,
+	},
+	{
+		name: "Homelab Command - Containers Restart - One Container",
+		args: []string{
+			"containers",
+			"restart",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
 					{
-						Name:  "g2-c3",
-						Image: "abc/xyz3",
-						State: docker.ContainerStateRemoving,
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
 					},
 				},
 			}),
 		},
-		want: `Container g1-c1 cannot be stopped since it is in state Created
-Container g1-c2 cannot be stopped since it was not found
-Container g2-c3 cannot be stopped since it is in state Removing`,
+		want: `Restarting container g1-c1`,
 	},
 	{
-		name: "Homelab Command - Groups Stop - All Groups - One Container With Ignore Image Pull Failures",
+		name: "Homelab Command - Groups Restart - All Groups",
 		args: []string{
 			"groups",
-			"stop",
+			"restart",
 			"all",
 			"--configs-dir",
-			fmt.Sprintf("%s/testdata/start-stop-cmds-with-ignore-image-pull-failures", testhelpers.Pwd()),
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
@@ -590,32 +844,24 @@ Container g2-c3 cannot be stopped since it is in state Removing`,
 					{
 						Name:  "g2-c3",
 						Image: "abc/xyz3",
-						State: docker.ContainerStateRunning,
+						State: docker.ContainerStateExited,
 					},
 				},
-				ValidImagesForPull: utils.StringSet{
-					"abc/xyz":  {},
-					"abc/xyz3": {},
-				},
-				FailImagePull: utils.StringSet{
-					"abc/xyz": {},
-				},
 			}),
 		},
-		want: `Pulling image: abc/xyz
-Ignoring - Image pull for container g1-c1 failed, reason: failed while pulling the image abc/xyz, reason: failed to pull image abc/xyz on the fake docker host
-Stopping container g1-c1
-Container g1-c2 cannot be stopped since it was not found
-Stopping container g2-c3`,
+		want: `Restarting container g1-c1
+Container g1-c2 cannot be restarted since it was not found
+Restarting container g2-c3`,
 	},
 	{
-		name: "Homelab Command - Groups Stop - One Group",
+		name: "Homelab Command - Groups Restart - All Groups - Dry Run",
 		args: []string{
 			"groups",
-			"stop",
-			"g1",
+			"restart",
+			"all",
 			"--configs-dir",
 			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--dry-run",
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
@@ -628,33 +874,45 @@ Stopping container g2-c3`,
 				},
 			}),
 		},
-		want: `Stopping container g1-c1
-Container g1-c2 cannot be stopped since it was not found`,
+		want: `Running in dry run mode, no changes will be made to the docker host
+Restarting container g1-c1
+Dry run plan for container g1-c1:
+  1 - Restart container g1-c1
+Container g1-c2 cannot be restarted since it was not found
+Dry run plan for container g1-c2:
+  No actions
+Container g2-c3 cannot be restarted since it was not found
+Dry run plan for container g2-c3:
+  No actions`,
 	},
 	{
-		name: "Homelab Command - Containers Stop - One Container - Not Found",
+		name: "Homelab Command - Containers Pause - One Container",
 		args: []string{
 			"containers",
-			"stop",
+			"pause",
 			"g1/c1",
 			"--configs-dir",
 			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
-				ValidImagesForPull: utils.StringSet{
-					"abc/xyz": {},
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
 				},
 			}),
 		},
-		want: `Container g1-c1 cannot be stopped since it was not found`,
+		want: `Pausing container g1-c1`,
 	},
 	{
-		name: "Homelab Command - Groups Purge - All Groups",
+		name: "Homelab Command - Containers Pause - One Container - Already Paused",
 		args: []string{
-			"groups",
-			"purge",
-			"all",
+			"containers",
+			"pause",
+			"g1/c1",
 			"--configs-dir",
 			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
 		},
@@ -664,27 +922,19 @@ Container g1-c2 cannot be stopped since it was not found`,
 					{
 						Name:  "g1-c1",
 						Image: "abc/xyz",
-						State: docker.ContainerStateCreated,
-					},
-					{
-						Name:  "g1-c2",
-						Image: "abc/xyz2",
 						State: docker.ContainerStatePaused,
 					},
 				},
 			}),
 		},
-		want: `Removing container g1-c1
-Stopping container g1-c2
-Removing container g1-c2
-Container g2-c3 cannot be purged since it was not found`,
+		want: `Container g1-c1 is already paused`,
 	},
 	{
-		name: "Homelab Command - Groups Purge - One Group",
+		name: "Homelab Command - Containers Unpause - One Container",
 		args: []string{
-			"groups",
-			"purge",
-			"g1",
+			"containers",
+			"unpause",
+			"g1/c1",
 			"--configs-dir",
 			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
 		},
@@ -694,120 +944,1039 @@ Container g2-c3 cannot be purged since it was not found`,
 					{
 						Name:  "g1-c1",
 						Image: "abc/xyz",
-						State: docker.ContainerStateRunning,
+						State: docker.ContainerStatePaused,
 					},
 				},
 			}),
 		},
-		want: `Stopping container g1-c1
-Removing container g1-c1
-Container g1-c2 cannot be purged since it was not found`,
+		want: `Unpausing container g1-c1`,
 	},
 	{
-		name: "Homelab Command - Containers Purge - One Container - Not Found",
+		name: "Homelab Command - Groups Pause - All Groups",
 		args: []string{
-			"containers",
-			"purge",
-			"g1/c1",
+			"groups",
+			"pause",
+			"all",
 			"--configs-dir",
 			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
-				ValidImagesForPull: utils.StringSet{
-					"abc/xyz": {},
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+					{
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateExited,
+					},
 				},
 			}),
 		},
-		want: `Container g1-c1 cannot be purged since it was not found`,
+		want: `Pausing container g1-c1
+Container g1-c2 cannot be paused since it was not found
+Container g2-c3 cannot be paused since it is in state Exited`,
 	},
 	{
-		name: "Homelab Command - Networks Create - One Network",
+		name: "Homelab Command - Groups Unpause - All Groups",
 		args: []string{
-			"networks",
-			"create",
-			"net1",
+			"groups",
+			"unpause",
+			"all",
 			"--configs-dir",
-			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
 		},
 		ctxInfo: &testutils.TestContextInfo{
-			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStatePaused,
+					},
+					{
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
 		},
-		want: `Created network net1`,
+		want: `Unpausing container g1-c1
+Container g1-c2 cannot be unpaused since it was not found
+Container g2-c3 cannot be unpaused since it is in state Running`,
 	},
 	{
-		name: "Homelab Command - Networks Create - One Network - Warning",
+		name: "Homelab Command - Groups Pause - All Groups - Dry Run",
 		args: []string{
-			"networks",
-			"create",
-			"net1",
+			"groups",
+			"pause",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--dry-run",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		want: `Running in dry run mode, no changes will be made to the docker host
+Pausing container g1-c1
+Dry run plan for container g1-c1:
+  1 - Pause container g1-c1
+Container g1-c2 cannot be paused since it was not found
+Dry run plan for container g1-c2:
+  No actions
+Container g2-c3 cannot be paused since it was not found
+Dry run plan for container g2-c3:
+  No actions`,
+	},
+	{
+		name: "Homelab Command - Groups Start - All Groups With Dependencies",
+		args: []string{
+			"groups",
+			"start",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/start-cmd-with-dependencies", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz":  {},
+					"abc/xyz3": {},
+				},
+			}),
+		},
+		want: `Container g1-c2 not allowed to run on host FakeHost
+Pulling image: abc/xyz3
+Pulled image abc/xyz3 on the fake docker host
+Created network net2
+Creating container g2-c3
+Starting container g2-c3
+Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
+Created network net1
+Creating container g1-c1
+Starting container g1-c1`,
+	},
+	{
+		name: "Homelab Command - Groups Start - All Groups With Dependencies - Parallelism",
+		args: []string{
+			"groups",
+			"start",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/start-cmd-with-dependencies", testhelpers.Pwd()),
+			"--parallelism",
+			"4",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz":  {},
+					"abc/xyz3": {},
+				},
+			}),
+		},
+		want: `\[g1-c2\] Container g1-c2 not allowed to run on host FakeHost
+\[g2-c3\] Pulling image: abc/xyz3
+\[g2-c3\] Created network net2
+\[g2-c3\] Creating container g2-c3
+\[g2-c3\] Starting container g2-c3
+\[g1-c1\] Pulling image: abc/xyz
+\[g1-c1\] Created network net1
+\[g1-c1\] Creating container g1-c1
+\[g1-c1\] Starting container g1-c1`,
+	},
+	{
+		name: "Homelab Command - Containers Start - One Container Without Dependencies",
+		args: []string{
+			"containers",
+			"start",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/start-cmd-with-dependencies", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
+Created network net1
+Creating container g1-c1
+Starting container g1-c1`,
+	},
+	{
+		name: "Homelab Command - Containers Start - One Container With Dependencies",
+		args: []string{
+			"containers",
+			"start",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/start-cmd-with-dependencies", testhelpers.Pwd()),
+			"--with-deps",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz":  {},
+					"abc/xyz3": {},
+				},
+			}),
+		},
+		want: `Pulling image: abc/xyz3
+Pulled image abc/xyz3 on the fake docker host
+Created network net2
+Creating container g2-c3
+Starting container g2-c3
+Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
+Created network net1
+Creating container g1-c1
+Starting container g1-c1`,
+	},
+	{
+		name: "Homelab Command - Containers Start - One Container With Wait After Start Delay",
+		args: []string{
+			"containers",
+			"start",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/start-cmd-with-wait-after-start-delay", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
+Created network net1
+Creating container g1-c1
+Starting container g1-c1
+Waiting for 1s after container startup of g1-c1`,
+	},
+	{
+		name: "Homelab Command - Groups Stop - All Groups",
+		args: []string{
+			"groups",
+			"stop",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateCreated,
+					},
+					{
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateRemoving,
+					},
+				},
+			}),
+		},
+		want: `Container g1-c1 cannot be stopped since it is in state Created
+Container g1-c2 cannot be stopped since it was not found
+Container g2-c3 cannot be stopped since it is in state Removing`,
+	},
+	{
+		name: "Homelab Command - Groups Stop - All Groups - One Container With Ignore Image Pull Failures",
+		args: []string{
+			"groups",
+			"stop",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/start-stop-cmds-with-ignore-image-pull-failures", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+					{
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz":  {},
+					"abc/xyz3": {},
+				},
+				FailImagePull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Pulling image: abc/xyz
+Ignoring - Image pull for container g1-c1 failed, reason: failed while pulling the image abc/xyz, reason: failed to pull image abc/xyz on the fake docker host
+Stopping container g1-c1
+Container g1-c2 cannot be stopped since it was not found
+Stopping container g2-c3`,
+	},
+	{
+		name: "Homelab Command - Groups Stop - All Groups With Dependencies",
+		args: []string{
+			"groups",
+			"stop",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/start-cmd-with-dependencies", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+					{
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		want: `Container g1-c2 cannot be stopped since it was not found
+Stopping container g1-c1
+Stopping container g2-c3`,
+	},
+	{
+		name: "Homelab Command - Groups Stop - One Group",
+		args: []string{
+			"groups",
+			"stop",
+			"g1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		want: `Stopping container g1-c1
+Container g1-c2 cannot be stopped since it was not found`,
+	},
+	{
+		name: "Homelab Command - Containers Stop - One Container - Not Found",
+		args: []string{
+			"containers",
+			"stop",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Container g1-c1 cannot be stopped since it was not found`,
+	},
+	{
+		name: "Homelab Command - Containers Stop - Custom Signal And Timeout",
+		args: []string{
+			"containers",
+			"stop",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--signal",
+			"SIGINT",
+			"--timeout",
+			"5",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		want: `Stopping container g1-c1`,
+	},
+	{
+		name: "Homelab Command - Groups Purge - All Groups",
+		args: []string{
+			"groups",
+			"purge",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateCreated,
+					},
+					{
+						Name:  "g1-c2",
+						Image: "abc/xyz2",
+						State: docker.ContainerStatePaused,
+					},
+				},
+			}),
+		},
+		want: `Removing container g1-c1
+Stopping container g1-c2
+Removing container g1-c2
+Container g2-c3 cannot be purged since it was not found`,
+	},
+	{
+		name: "Homelab Command - Groups Purge - One Group",
+		args: []string{
+			"groups",
+			"purge",
+			"g1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		want: `Stopping container g1-c1
+Removing container g1-c1
+Container g1-c2 cannot be purged since it was not found`,
+	},
+	{
+		name: "Homelab Command - Containers Purge - One Container - Not Found",
+		args: []string{
+			"containers",
+			"purge",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Container g1-c1 cannot be purged since it was not found`,
+	},
+	{
+		name: "Homelab Command - Networks Create - One Network",
+		args: []string{
+			"networks",
+			"create",
+			"net1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Created network net1`,
+	},
+	{
+		name: "Homelab Command - Networks Create - One Network - Warning",
+		args: []string{
+			"networks",
+			"create",
+			"net1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				WarnNetworkCreate: utils.StringSet{
+					"net1": {},
+				},
+			}),
+		},
+		want: `Warning encountered while creating the network net1
+warning generated during network create for network net1 on the fake docker host
+Created network net1`,
+	},
+	{
+		name: "Homelab Command - Networks Create - One Network - Exists Already",
+		args: []string{
+			"networks",
+			"create",
+			"net1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Networks: []*fakedocker.FakeNetworkInitInfo{
+					{
+						Name: "net1",
+					},
+				},
+			}),
+		},
+		want: `Network net1 not created since it already exists`,
+	},
+	{
+		name: "Homelab Command - Networks Delete - One Network - Network Doesn't Exist",
+		args: []string{
+			"networks",
+			"delete",
+			"net1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Network net1 not deleted since it doesn't exist already`,
+	},
+	{
+		name: "Homelab Command - Networks Delete - One Network - Network Exists",
+		args: []string{
+			"networks",
+			"delete",
+			"net1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Networks: []*fakedocker.FakeNetworkInitInfo{
+					{
+						Name: "net1",
+					},
+				},
+			}),
+		},
+		want: `Deleted network net1`,
+	},
+	{
+		name: "Homelab Command - Groups Status - All Groups",
+		args: []string{
+			"groups",
+			"status",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+					{
+						Name:  "g1-c4",
+						Image: "abc/xyz4",
+						State: docker.ContainerStateExited,
+					},
+					{
+						Name:  "foo-bar",
+						Image: "abc/foo",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		want: `Container g1-c1 \(out of sync, allowed on host\)
+  State: Running \(configured: Running\)
+  Image: abc/xyz \(configured: abc/xyz\)
+  Network net1: - \(configured: 172\.18\.100\.11, fd99:172:18:100::11\)
+Container g1-c2 \(in sync, not allowed on host\)
+  State: NotFound \(configured: NotFound\)
+  Image: - \(configured: abc/xyz2\)
+  Network net1: - \(configured: 172\.18\.100\.12\)
+Container g2-c3 \(out of sync, allowed on host\)
+  State: NotFound \(configured: Running\)
+  Image: - \(configured: abc/xyz3\)
+  Network net2: - \(configured: 172\.18\.101\.21\)
+Container g1-c4 looks like a homelab managed container, but is missing in the config`,
+	},
+	{
+		name: "Homelab Command - Containers Status - Paused Container",
+		args: []string{
+			"containers",
+			"status",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStatePaused,
+					},
+				},
+			}),
+		},
+		want: `Container g1-c1 \(out of sync, allowed on host\)
+  State: Paused \(configured: Running\)
+  Image: abc/xyz \(configured: abc/xyz\)
+  Network net1: - \(configured: 172\.18\.100\.11, fd99:172:18:100::11\)`,
+	},
+	{
+		name: "Homelab Command - Containers Logs",
+		args: []string{
+			"containers",
+			"logs",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+						Logs: []string{
+							"Starting server",
+							"Listening on port 8080",
+						},
+					},
+				},
+			}),
+		},
+		want: `Starting server
+Listening on port 8080`,
+	},
+	{
+		name: "Homelab Command - Containers Logs - Tail",
+		args: []string{
+			"containers",
+			"logs",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--tail",
+			"1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+						Tty:   true,
+						Logs: []string{
+							"Starting server",
+							"Listening on port 8080",
+						},
+					},
+				},
+			}),
+		},
+		want: `Listening on port 8080`,
+	},
+	{
+		name: "Homelab Command - Containers Logs - Container Not Found",
+		args: []string{
+			"containers",
+			"logs",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Container g1-c1 has no logs since it was not found`,
+	},
+	{
+		name: "Homelab Command - Groups Logs - All Groups",
+		args: []string{
+			"groups",
+			"logs",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+						Logs: []string{
+							"Hello from c1",
+						},
+					},
+					{
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateRunning,
+						Logs: []string{
+							"Hello from c3",
+						},
+					},
+				},
+			}),
+		},
+		want: `(g1/c1 \| Hello from c1
+g2/c3 \| Hello from c3|g2/c3 \| Hello from c3
+g1/c1 \| Hello from c1)
+Container g1-c2 has no logs since it was not found`,
+	},
+	{
+		name: "Homelab Command - Containers Exec",
+		args: []string{
+			"containers",
+			"exec",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--",
+			"ls",
+			"-la",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		want: `Executed "ls -la" as user root in container g1-c1 \(tty: false\)`,
+	},
+	{
+		name: "Homelab Command - Containers Exec - Configured User",
+		args: []string{
+			"containers",
+			"exec",
+			"g2/c3",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--",
+			"id",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		want: `Executed "id" as user appuser:appgroup in container g2-c3 \(tty: false\)`,
+	},
+	{
+		name: "Homelab Command - Containers Exec - User And TTY Override",
+		args: []string{
+			"containers",
+			"exec",
+			"g2/c3",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--user",
+			"nobody",
+			"--tty",
+			"--",
+			"id",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		want: `Executed "id" as user nobody in container g2-c3 \(tty: true\)`,
+	},
+	{
+		name: "Homelab Command - Containers Exec - Dry Run",
+		args: []string{
+			"containers",
+			"exec",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--dry-run",
+			"--",
+			"ls",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		want: `Running in dry run mode, no changes will be made to the docker host
+Dry run plan for container g1-c1:
+  1 - Execute command "ls" in container g1-c1`,
+	},
+	{
+		name: "Homelab Command - Containers Shell",
+		args: []string{
+			"containers",
+			"shell",
+			"g1/c1",
 			"--configs-dir",
-			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
-				WarnNetworkCreate: utils.StringSet{
-					"net1": {},
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
 				},
 			}),
 		},
-		want: `Warning encountered while creating the network net1
-warning generated during network create for network net1 on the fake docker host
-Created network net1`,
+		want: `Executed "/bin/sh" as user root in container g1-c1 \(tty: false\)`,
 	},
 	{
-		name: "Homelab Command - Networks Create - One Network - Exists Already",
+		name: "Homelab Command - Containers Shell - Custom Shell",
 		args: []string{
-			"networks",
-			"create",
-			"net1",
+			"containers",
+			"shell",
+			"g2/c3",
 			"--configs-dir",
-			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--shell",
+			"/bin/bash",
+			"-t",
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
-				Networks: []*fakedocker.FakeNetworkInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
 					{
-						Name: "net1",
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateRunning,
 					},
 				},
 			}),
 		},
-		want: `Network net1 not created since it already exists`,
+		want: `Executed "/bin/bash" as user appuser:appgroup in container g2-c3 \(tty: true\)`,
 	},
 	{
-		name: "Homelab Command - Networks Delete - One Network - Network Doesn't Exist",
+		name: "Homelab Command - Groups Start - All Groups - Dry Run",
 		args: []string{
-			"networks",
-			"delete",
-			"net1",
+			"groups",
+			"start",
+			"all",
 			"--configs-dir",
-			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--dry-run",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		want: `Running in dry run mode, no changes will be made to the docker host
+Pulling image: abc/xyz
+Stopping container g1-c1
+Removing container g1-c1
+Created network net1
+Creating container g1-c1
+Starting container g1-c1
+Dry run plan for container g1-c1:
+  1 - Pull image abc/xyz
+  2 - Stop container g1-c1
+  3 - Remove container g1-c1
+  4 - Create network net1
+  5 - Create container g1-c1 with image abc/xyz attached to network net1 \(IPv4 172\.18\.100\.11, IPv6 fd99:172:18:100::11\)
+  6 - Start container g1-c1
+Container g1-c2 not allowed to run on host FakeHost
+Dry run plan for container g1-c2:
+  No actions
+Pulling image: abc/xyz3
+Created network net2
+Creating container g2-c3
+Starting container g2-c3
+Dry run plan for container g2-c3:
+  1 - Pull image abc/xyz3
+  2 - Create network net2
+  3 - Create container g2-c3 with image abc/xyz3 attached to network net2 \(IPv4 172\.18\.101\.21\)
+  4 - Start container g2-c3`,
+	},
+	{
+		name: "Homelab Command - Containers Start - One Container With Wait After Start Delay - Dry Run",
+		args: []string{
+			"containers",
+			"start",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/start-cmd-with-wait-after-start-delay", testhelpers.Pwd()),
+			"--dry-run",
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
 		},
-		want: `Network net1 not deleted since it doesn't exist already`,
+		want: `Running in dry run mode, no changes will be made to the docker host
+Pulling image: abc/xyz
+Created network net1
+Creating container g1-c1
+Starting container g1-c1
+Dry run plan for container g1-c1:
+  1 - Pull image abc/xyz
+  2 - Create network net1
+  3 - Create container g1-c1 with image abc/xyz attached to network net1 \(IPv4 172\.18\.100\.11, IPv6 fd99:172:18:100::11\)
+  4 - Start container g1-c1
+  5 - Wait for 1s after starting container g1-c1`,
 	},
 	{
-		name: "Homelab Command - Networks Delete - One Network - Network Exists",
+		name: "Homelab Command - Containers Start - One Container With Start Pre-Hook - Dry Run",
 		args: []string{
-			"networks",
-			"delete",
-			"net1",
+			"containers",
+			"start",
+			"g2/c3",
 			"--configs-dir",
-			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+			fmt.Sprintf("%s/testdata/start-cmd-with-start-pre-hook", testhelpers.Pwd()),
+			"--dry-run",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			Executor:   fakecmdexec.NewEmptyFakeExecutor(),
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Running in dry run mode, no changes will be made to the docker host
+Output from start pre-hook for container g2-c3 >>>
+Pulling image: abc/xyz3
+Created network net2
+Creating container g2-c3
+Starting container g2-c3
+Dry run plan for container g2-c3:
+  1 - Run command: custom-start-prehook arg1 arg2
+  2 - Pull image abc/xyz3
+  3 - Create network net2
+  4 - Create container g2-c3 with image abc/xyz3 attached to network net2 \(IPv4 172\.18\.101\.21\)
+  5 - Start container g2-c3`,
+	},
+	{
+		name: "Homelab Command - Groups Purge - All Groups - Dry Run",
+		args: []string{
+			"groups",
+			"purge",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--dry-run",
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
-				Networks: []*fakedocker.FakeNetworkInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
 					{
-						Name: "net1",
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateCreated,
+					},
+					{
+						Name:  "g1-c2",
+						Image: "abc/xyz2",
+						State: docker.ContainerStatePaused,
 					},
 				},
 			}),
 		},
-		want: `Deleted network net1`,
+		want: `Running in dry run mode, no changes will be made to the docker host
+Removing container g1-c1
+Dry run plan for container g1-c1:
+  1 - Remove container g1-c1
+Stopping container g1-c2
+Removing container g1-c2
+Dry run plan for container g1-c2:
+  1 - Stop container g1-c2
+  2 - Remove container g1-c2
+Container g2-c3 cannot be purged since it was not found
+Dry run plan for container g2-c3:
+  No actions`,
+	},
+	{
+		name: "Homelab Command - Networks Create - One Network - Dry Run",
+		args: []string{
+			"networks",
+			"create",
+			"net1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+			"--dry-run",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Running in dry run mode, no changes will be made to the docker host
+Created network net1
+Dry run plan for network net1:
+  1 - Create network net1`,
 	},
 }
 
@@ -1085,87 +2254,229 @@ var executeHomelabCmdLogLevelTests = []struct {
 		},
 	},
 	{
-		name: "Homelab Command - Networks Create",
+		name: "Homelab Command - Networks Create",
+		args: []string{
+			"networks",
+			"create",
+			"net1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: func() *testutils.TestContextInfo {
+			return &testutils.TestContextInfo{
+				DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+			}
+		},
+	},
+	{
+		name: "Homelab Command - Networks Delete",
+		args: []string{
+			"networks",
+			"delete",
+			"net1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: func() *testutils.TestContextInfo {
+			return &testutils.TestContextInfo{
+				DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+					Networks: []*fakedocker.FakeNetworkInitInfo{
+						{
+							Name: "net1",
+						},
+					},
+				}),
+			}
+		},
+	},
+}
+
+func TestExecHomelabCmdLogLevel(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range executeHomelabCmdLogLevelTests {
+		tc := test
+		for _, l := range executeHomelabCmdLogLevels {
+			lvl := l
+			tcName := fmt.Sprintf("%s - %v Log Level", tc.name, lvl)
+			t.Run(tcName, func(t *testing.T) {
+				t.Parallel()
+
+				out, gotErr := execHomelabCmdTest(tc.ctxInfo(), &lvl, tc.args...)
+				if gotErr != nil {
+					testhelpers.LogErrorNotNilWithOutput(t, "Exec()", tcName, out, gotErr)
+					return
+				}
+			})
+		}
+	}
+}
+
+var executeHomelabCmdErrorTests = []struct {
+	name    string
+	args    []string
+	ctxInfo *testutils.TestContextInfo
+	want    string
+}{
+	{
+		name: "Homelab Base Command - Missing Subcommand",
+		args: nil,
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `homelab sub-command is required`,
+	},
+	{
+		name: "Homelab Config Command - Missing Subcommand",
+		args: []string{
+			"config",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `homelab config sub-command is required`,
+	},
+	{
+		name: "Homelab Command - Config Schema - JSON Output",
+		args: []string{
+			"config",
+			"schema",
+			"--output",
+			"json",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `config schema does not support the json output format`,
+	},
+	{
+		name: "Homelab Config Export Command - Missing Subcommand",
+		args: []string{
+			"config",
+			"export",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `homelab config export sub-command is required`,
+	},
+	{
+		name: "Homelab Command - Config Export Compose - JSON Output",
+		args: []string{
+			"config",
+			"export",
+			"compose",
+			"--output",
+			"json",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `config export compose does not support the json output format`,
+	},
+	{
+		name: "Homelab Command - Config Export Compose - Group Not Found",
+		args: []string{
+			"config",
+			"export",
+			"compose",
+			"--group",
+			"g4",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/export-compose-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `config export compose failed while querying containers, reason: group g4 not found`,
+	},
+	{
+		name: "Homelab Config Import Command - Missing Subcommand",
+		args: []string{
+			"config",
+			"import",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `homelab config import sub-command is required`,
+	},
+	{
+		name: "Homelab Command - Config Import Compose - Missing File",
+		args: []string{
+			"config",
+			"import",
+			"compose",
+			"--group",
+			"g1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Expected exactly one compose file argument to be specified, but found 0 instead`,
+	},
+	{
+		name: "Homelab Command - Config Import Compose - Missing Group",
 		args: []string{
-			"networks",
-			"create",
-			"net1",
-			"--configs-dir",
-			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+			"config",
+			"import",
+			"compose",
+			fmt.Sprintf("%s/testdata/import-compose-cmd/docker-compose.yml", testhelpers.Pwd()),
 		},
-		ctxInfo: func() *testutils.TestContextInfo {
-			return &testutils.TestContextInfo{
-				DockerHost: fakedocker.NewEmptyFakeDockerHost(),
-			}
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
 		},
+		want: `config import compose failed, reason: the group must be specified using the --group flag`,
 	},
 	{
-		name: "Homelab Command - Networks Delete",
+		name: "Homelab Command - Config Import Compose - Non Existing File",
 		args: []string{
-			"networks",
-			"delete",
-			"net1",
-			"--configs-dir",
-			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+			"config",
+			"import",
+			"compose",
+			"foo-bar.yml",
+			"--group",
+			"g1",
 		},
-		ctxInfo: func() *testutils.TestContextInfo {
-			return &testutils.TestContextInfo{
-				DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
-					Networks: []*fakedocker.FakeNetworkInitInfo{
-						{
-							Name: "net1",
-						},
-					},
-				}),
-			}
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
 		},
+		want: `config import compose failed while reading the compose file, reason: open foo-bar\.yml: no such file or directory`,
 	},
-}
-
-func TestExecHomelabCmdLogLevel(t *testing.T) {
-	t.Parallel()
-
-	for _, test := range executeHomelabCmdLogLevelTests {
-		tc := test
-		for _, l := range executeHomelabCmdLogLevels {
-			lvl := l
-			tcName := fmt.Sprintf("%s - %v Log Level", tc.name, lvl)
-			t.Run(tcName, func(t *testing.T) {
-				t.Parallel()
-
-				out, gotErr := execHomelabCmdTest(tc.ctxInfo(), &lvl, tc.args...)
-				if gotErr != nil {
-					testhelpers.LogErrorNotNilWithOutput(t, "Exec()", tcName, out, gotErr)
-					return
-				}
-			})
-		}
-	}
-}
-
-var executeHomelabCmdErrorTests = []struct {
-	name    string
-	args    []string
-	ctxInfo *testutils.TestContextInfo
-	want    string
-}{
 	{
-		name: "Homelab Base Command - Missing Subcommand",
-		args: nil,
+		name: "Homelab Command - Config Import Compose - YAML Output",
+		args: []string{
+			"config",
+			"import",
+			"compose",
+			fmt.Sprintf("%s/testdata/import-compose-cmd/docker-compose.yml", testhelpers.Pwd()),
+			"--group",
+			"g1",
+			"-o",
+			"yaml",
+		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
 		},
-		want: `homelab sub-command is required`,
+		want: `config import compose does not support the yaml output format`,
 	},
 	{
-		name: "Homelab Config Command - Missing Subcommand",
+		name: "Homelab Command - Validate Config - Multiple Errors",
 		args: []string{
 			"config",
+			"validate",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/validate-config-cmd-invalid", testhelpers.Pwd()),
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
 		},
-		want: `homelab config sub-command is required`,
+		want: `config validate failed with 5 errors in the configs, reason\(s\):
+1 - common/global\.yaml:1: container stop timeout -1 cannot be negative in global container config
+2 - common/ipam\.yaml:15: network net2 cannot have a non-positive priority 0
+3 - common/groups\.yaml:4: group g2 cannot have a non-positive order 0
+4 - g1/c1\.yaml:2: image cannot be empty in container {Group: g1 Container:c1} config
+5 - g2/c3\.yaml:2: dependency {Group:g2 Container:c9} not found in container {Group: g2 Container:c3} config`,
 	},
 	{
 		name: "Homelab Groups Command - Missing Subcommand",
@@ -1337,61 +2648,358 @@ var executeHomelabCmdErrorTests = []struct {
 			"g2/c2",
 		},
 		ctxInfo: &testutils.TestContextInfo{
-			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Expected exactly one container name argument to be specified, but found 2 instead`,
+	},
+	{
+		name: "Homelab Command - Containers Start - Invalid Container Name",
+		args: []string{
+			"containers",
+			"start",
+			"foobar",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `container name must be specified in the form 'group/container'`,
+	},
+	{
+		name: "Homelab Command - Containers Stop - Invalid Container Name",
+		args: []string{
+			"containers",
+			"stop",
+			"foobar",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `container name must be specified in the form 'group/container'`,
+	},
+	{
+		name: "Homelab Command - Containers Purge - Invalid Container Name",
+		args: []string{
+			"containers",
+			"purge",
+			"foobar",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `container name must be specified in the form 'group/container'`,
+	},
+	{
+		name: "Homelab Command - Groups Start - Failure",
+		args: []string{
+			"groups",
+			"start",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `groups start failed for 2 containers, reason\(s\):
+1 - Failed to start container g1-c1, reason:failed to pull the image abc/xyz, reason: image abc/xyz not found or invalid and cannot be pulled by the fake docker host
+2 - Failed to start container g2-c3, reason:failed to pull the image abc/xyz3, reason: image abc/xyz3 not found or invalid and cannot be pulled by the fake docker host`,
+	},
+	{
+		name: "Homelab Command - Groups Start - Invalid Parallelism",
+		args: []string{
+			"groups",
+			"start",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--parallelism",
+			"0",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `groups start failed, reason: parallelism 0 must be at least 1`,
+	},
+	{
+		name: "Homelab Command - Groups Start - Failure - Parallelism",
+		args: []string{
+			"groups",
+			"start",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--parallelism",
+			"2",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `groups start failed for 2 containers, reason\(s\):
+1 - Failed to start container g1-c1, reason:failed to pull the image abc/xyz, reason: image abc/xyz not found or invalid and cannot be pulled by the fake docker host
+2 - Failed to start container g2-c3, reason:failed to pull the image abc/xyz3, reason: image abc/xyz3 not found or invalid and cannot be pulled by the fake docker host`,
+	},
+	{
+		name: "Homelab Command - Containers Exec - Missing Command",
+		args: []string{
+			"containers",
+			"exec",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Expected a container name argument followed by the command to execute, but found 1 arguments instead`,
+	},
+	{
+		name: "Homelab Command - Containers Exec - Container Not Running",
+		args: []string{
+			"containers",
+			"exec",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--",
+			"ls",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `containers exec failed, reason: Failed to execute command in container g1-c1, reason:container is not running \(state: NotFound\)`,
+	},
+	{
+		name: "Homelab Command - Containers Exec - Non Zero Exit Code",
+		args: []string{
+			"containers",
+			"exec",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--",
+			"false",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:         "g1-c1",
+						Image:        "abc/xyz",
+						State:        docker.ContainerStateRunning,
+						ExecExitCode: 2,
+					},
+				},
+			}),
+		},
+		want: `containers exec failed, reason: command "false" exited with exit code 2`,
+	},
+	{
+		name: "Homelab Command - Containers Shell - Failure",
+		args: []string{
+			"containers",
+			"shell",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				FailContainerExec: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
+		},
+		want: `containers shell failed, reason: Failed to execute command in container g1-c1, reason:failed to create the exec instance, reason: failed to create exec instance in container g1-c1 on the fake docker host`,
+	},
+	{
+		name: "Homelab Command - Containers Logs - Invalid Tail",
+		args: []string{
+			"containers",
+			"logs",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--tail",
+			"-5",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `containers logs failed, reason: invalid tail -5, must be either all or a non-negative number of lines`,
+	},
+	{
+		name: "Homelab Command - Containers Stop - Invalid Timeout",
+		args: []string{
+			"containers",
+			"stop",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--timeout",
+			"-5",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `containers stop failed, reason: stop timeout -5 must be either -1 or a non-negative number of seconds`,
+	},
+	{
+		name: "Homelab Command - Groups Purge - Invalid Timeout",
+		args: []string{
+			"groups",
+			"purge",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--timeout",
+			"-2",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `groups purge failed, reason: stop timeout -2 must be either -1 or a non-negative number of seconds`,
+	},
+	{
+		name: "Homelab Command - Groups Logs - Failure",
+		args: []string{
+			"groups",
+			"logs",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+					{
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				FailContainerLogs: utils.StringSet{
+					"g2-c3": {},
+				},
+			}),
 		},
-		want: `Expected exactly one container name argument to be specified, but found 2 instead`,
+		want: `groups logs failed for 1 containers, reason\(s\):
+1 - Failed to retrieve logs of container g2-c3, reason:failed to retrieve the container logs, reason: failed to retrieve logs of container g2-c3 on the fake docker host`,
 	},
 	{
-		name: "Homelab Command - Containers Start - Invalid Container Name",
+		name: "Homelab Command - Groups Restart - Failure",
 		args: []string{
-			"containers",
-			"start",
-			"foobar",
+			"groups",
+			"restart",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
 		},
 		ctxInfo: &testutils.TestContextInfo{
-			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+					{
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				FailContainerRestart: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
 		},
-		want: `container name must be specified in the form 'group/container'`,
+		want: `groups restart failed for 1 containers, reason\(s\):
+1 - Failed to restart container g1-c1, reason:failed to restart the container, reason: failed to restart container g1-c1 on the fake docker host`,
 	},
 	{
-		name: "Homelab Command - Containers Stop - Invalid Container Name",
+		name: "Homelab Command - Groups Pause - Failure",
 		args: []string{
-			"containers",
-			"stop",
-			"foobar",
+			"groups",
+			"pause",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
 		},
 		ctxInfo: &testutils.TestContextInfo{
-			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+					{
+						Name:  "g2-c3",
+						Image: "abc/xyz3",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				FailContainerPause: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
 		},
-		want: `container name must be specified in the form 'group/container'`,
+		want: `groups pause failed for 1 containers, reason\(s\):
+1 - Failed to pause container g1-c1, reason:failed to pause the container, reason: failed to pause container g1-c1 on the fake docker host`,
 	},
 	{
-		name: "Homelab Command - Containers Purge - Invalid Container Name",
+		name: "Homelab Command - Containers Unpause - Failure",
 		args: []string{
 			"containers",
-			"purge",
-			"foobar",
+			"unpause",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
 		},
 		ctxInfo: &testutils.TestContextInfo{
-			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStatePaused,
+					},
+				},
+				FailContainerUnpause: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
 		},
-		want: `container name must be specified in the form 'group/container'`,
+		want: `containers unpause failed for 1 containers, reason\(s\):
+1 - Failed to unpause container g1-c1, reason:failed to unpause the container, reason: failed to unpause container g1-c1 on the fake docker host`,
 	},
 	{
-		name: "Homelab Command - Groups Start - Failure",
+		name: "Homelab Command - Groups Status - Invalid Output Format",
 		args: []string{
 			"groups",
-			"start",
+			"status",
 			"all",
 			"--configs-dir",
 			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--output",
+			"xml",
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
 		},
-		want: `groups start failed for 2 containers, reason\(s\):
-1 - Failed to start container g1-c1, reason:failed to pull the image abc/xyz, reason: image abc/xyz not found or invalid and cannot be pulled by the fake docker host
-2 - Failed to start container g2-c3, reason:failed to pull the image abc/xyz3, reason: image abc/xyz3 not found or invalid and cannot be pulled by the fake docker host`,
+		want: `invalid output format "xml", must be one of text, json or yaml`,
 	},
 	{
 		name: "Homelab Command - Groups Stop - Failure",
@@ -1456,7 +3064,7 @@ var executeHomelabCmdErrorTests = []struct {
 		},
 		want: `groups purge failed for 2 containers, reason\(s\):
 1 - Failed to purge container g1-c1, reason:failed to stop the container, reason: failed to stop container g1-c1 on the fake docker host
-2 - Failed to purge container g2-c3, reason:failed to purge container g2-c3 after 6 attempts`,
+2 - Failed to purge container g2-c3, reason:failed to purge container g2-c3 within the grace period of 100ms`,
 	},
 	{
 		name: "Homelab Command - Networks Create - Zero Network Name Args",
@@ -1542,73 +3150,466 @@ var executeHomelabCmdErrorTests = []struct {
 		want: `Expected exactly one network name argument to be specified, but found 0 instead`,
 	},
 	{
-		name: "Homelab Command - Networks Delete - Multiple Network Name Args",
+		name: "Homelab Command - Networks Delete - Multiple Network Name Args",
+		args: []string{
+			"networks",
+			"delete",
+			"net1",
+			"net2",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Expected exactly one network name argument to be specified, but found 2 instead`,
+	},
+	{
+		name: "Homelab Command - Networks Delete - Invalid Network Name",
+		args: []string{
+			"networks",
+			"delete",
+			"net11",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `networks delete failed while querying networks, reason: network net11 not found`,
+	},
+	{
+		name: "Homelab Command - Networks Delete - Container Mode Network",
+		args: []string{
+			"networks",
+			"delete",
+			"net3",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `networks delete failed for 1 networks, reason\(s\):
+1 - container mode network net3 cannot be deleted`,
+	},
+	{
+		name: "Homelab Command - Networks Delete - Failure",
+		args: []string{
+			"networks",
+			"delete",
+			"net1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Networks: []*fakedocker.FakeNetworkInitInfo{
+					{
+						Name: "net1",
+					},
+				},
+				FailNetworkRemove: utils.StringSet{
+					"net1": {},
+				},
+			}),
+		},
+		want: `networks delete failed for 1 networks, reason\(s\):
+1 - failed to remove the network, reason: failed to remove network net1 on the fake docker host`,
+	},
+}
+
+var executeHomelabCmdOutputTests = []struct {
+	name     string
+	args     []string
+	ctxInfo  *testutils.TestContextInfo
+	wantOut  string
+	wantLogs string
+	wantErr  string
+}{
+	{
+		name: "Homelab Command - Containers Status - JSON Output",
+		args: []string{
+			"containers",
+			"status",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--output",
+			"json",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		wantOut: `{
+  "command": "containers status",
+  "success": true,
+  "containers": [
+    {
+      "container": "g1-c1",
+      "action": "status",
+      "outcome": "succeeded",
+      "status": {
+        "inSync": false,
+        "allowedOnHost": true,
+        "wantState": "Running",
+        "state": "Running",
+        "wantImage": "abc/xyz",
+        "image": "abc/xyz",
+        "endpoints": [
+          {
+            "network": "net1",
+            "wantIPv4": "172.18.100.11",
+            "wantIPv6": "fd99:172:18:100::11"
+          }
+        ]
+      }
+    }
+  ]
+}
+`,
+		wantLogs: `Container g1-c1 \(out of sync, allowed on host\)
+  State: Running \(configured: Running\)
+  Image: abc/xyz \(configured: abc/xyz\)
+  Network net1: - \(configured: 172\.18\.100\.11, fd99:172:18:100::11\)`,
+	},
+	{
+		name: "Homelab Command - Containers Start - JSON Output - Image Pull",
+		args: []string{
+			"containers",
+			"start",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--output",
+			"json",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		wantOut: `{
+  "command": "containers start",
+  "success": true,
+  "containers": [
+    {
+      "container": "g1-c1",
+      "action": "start",
+      "outcome": "succeeded"
+    }
+  ]
+}
+`,
+		wantLogs: `Pulling image: abc/xyz
+Pulled image abc/xyz on the fake docker host
+Created network net1
+Creating container g1-c1
+Starting container g1-c1`,
+	},
+	{
+		name: "Homelab Command - Groups Stop - YAML Output",
+		args: []string{
+			"groups",
+			"stop",
+			"all",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"-o",
+			"yaml",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		wantOut: `command: groups stop
+success: true
+containers:
+  - container: g1-c1
+    action: stop
+    outcome: succeeded
+  - container: g1-c2
+    action: stop
+    outcome: skipped
+    reason: not found
+  - container: g2-c3
+    action: stop
+    outcome: skipped
+    reason: not found
+`,
+		wantLogs: `Stopping container g1-c1
+Container g1-c2 cannot be stopped since it was not found
+Container g2-c3 cannot be stopped since it was not found`,
+	},
+	{
+		name: "Homelab Command - Groups Stop - JSON Output - Failure",
+		args: []string{
+			"groups",
+			"stop",
+			"g1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"-o",
+			"json",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				FailContainerStop: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
+		},
+		wantOut: `{
+  "command": "groups stop",
+  "success": false,
+  "error": "groups stop failed for 1 containers, reason(s):\n1 - Failed to stop container g1-c1, reason:failed to stop the container, reason: failed to stop container g1-c1 on the fake docker host",
+  "containers": [
+    {
+      "container": "g1-c1",
+      "action": "stop",
+      "outcome": "failed",
+      "error": "Failed to stop container g1-c1, reason:failed to stop the container, reason: failed to stop container g1-c1 on the fake docker host"
+    },
+    {
+      "container": "g1-c2",
+      "action": "stop",
+      "outcome": "skipped",
+      "reason": "not found"
+    }
+  ]
+}
+`,
+		wantLogs: `Stopping container g1-c1
+Failed to stop container g1-c1, reason:failed to stop the container, reason: failed to stop container g1-c1 on the fake docker host
+Container g1-c2 cannot be stopped since it was not found`,
+		wantErr: `groups stop failed for 1 containers, reason\(s\):
+1 - Failed to stop container g1-c1, reason:failed to stop the container, reason: failed to stop container g1-c1 on the fake docker host`,
+	},
+	{
+		name: "Homelab Command - Networks Create - JSON Output - Dry Run",
 		args: []string{
 			"networks",
-			"delete",
+			"create",
 			"net1",
-			"net2",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--output",
+			"json",
+			"--dry-run",
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
 		},
-		want: `Expected exactly one network name argument to be specified, but found 2 instead`,
+		wantOut: `{
+  "command": "networks create",
+  "success": true,
+  "dryRun": true,
+  "networks": [
+    {
+      "network": "net1",
+      "action": "create",
+      "outcome": "succeeded",
+      "dryRunPlan": [
+        "Create network net1"
+      ]
+    }
+  ]
+}
+`,
+		wantLogs: `Running in dry run mode, no changes will be made to the docker host
+Created network net1
+Dry run plan for network net1:
+  1 - Create network net1`,
 	},
 	{
-		name: "Homelab Command - Networks Delete - Invalid Network Name",
+		name: "Homelab Command - Containers Logs - JSON Output",
 		args: []string{
-			"networks",
-			"delete",
-			"net11",
+			"containers",
+			"logs",
+			"g1/c1",
 			"--configs-dir",
-			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--output=json",
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
 		},
-		want: `networks delete failed while querying networks, reason: network net11 not found`,
+		wantOut: `{
+  "command": "containers logs",
+  "success": false,
+  "error": "containers logs does not support the json output format"
+}
+`,
+		wantErr: `containers logs does not support the json output format`,
 	},
+}
+
+func TestExecHomelabCmdOutput(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range executeHomelabCmdOutputTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := new(bytes.Buffer)
+			logs := new(bytes.Buffer)
+			tc.ctxInfo.Logger = testutils.NewCapturingVanillaTestLogger(tuxlog.LvlInfo, logs)
+			ctx := testutils.NewTestContext(tc.ctxInfo)
+
+			gotErr := Exec(ctx, strings.NewReader(""), out, logs, tc.args...)
+			if len(tc.wantErr) == 0 && gotErr != nil {
+				testhelpers.LogErrorNotNilWithOutput(t, "Exec()", tc.name, logs, gotErr)
+				return
+			}
+			if len(tc.wantErr) > 0 {
+				if gotErr == nil {
+					testhelpers.LogErrorNilWithOutput(t, "Exec()", tc.name, logs, tc.wantErr)
+					return
+				}
+				if !testhelpers.RegexMatchJoinNewLines(t, "Exec()", tc.name, "gotErr error string", tc.wantErr, gotErr.Error()) {
+					return
+				}
+			}
+
+			if !testhelpers.CmpDiff(t, "Exec()", tc.name, "result document", tc.wantOut, out.String()) {
+				return
+			}
+			// Ensure nothing else (e.g. the image pull progress) ended up in
+			// the result document.
+			var doc map[string]any
+			if err := yaml.Unmarshal(out.Bytes(), &doc); err != nil {
+				testhelpers.LogErrorNotNilWithOutput(t, "Exec()", tc.name, logs, err)
+				return
+			}
+			if !testhelpers.RegexMatchJoinNewLines(t, "Exec()", tc.name, "logs", tc.wantLogs, logs.String()) {
+				return
+			}
+		})
+	}
+}
+
+var logWriterTests = []struct {
+	name       string
+	args       []string
+	wantStderr bool
+}{
 	{
-		name: "Homelab Command - Networks Delete - Container Mode Network",
-		args: []string{
-			"networks",
-			"delete",
-			"net3",
-			"--configs-dir",
-			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
-		},
-		ctxInfo: &testutils.TestContextInfo{
-			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
-		},
-		want: `networks delete failed for 1 networks, reason\(s\):
-1 - container mode network net3 cannot be deleted`,
+		name: "Log Writer - No Output Flag",
+		args: []string{"groups", "start", "all"},
 	},
 	{
-		name: "Homelab Command - Networks Delete - Failure",
-		args: []string{
-			"networks",
-			"delete",
-			"net1",
-			"--configs-dir",
-			fmt.Sprintf("%s/testdata/networks-cmd", testhelpers.Pwd()),
-		},
-		ctxInfo: &testutils.TestContextInfo{
-			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
-				Networks: []*fakedocker.FakeNetworkInitInfo{
-					{
-						Name: "net1",
-					},
-				},
-				FailNetworkRemove: utils.StringSet{
-					"net1": {},
-				},
-			}),
-		},
-		want: `networks delete failed for 1 networks, reason\(s\):
-1 - failed to remove the network, reason: failed to remove network net1 on the fake docker host`,
+		name: "Log Writer - Text Output",
+		args: []string{"groups", "start", "all", "--output", "text"},
+	},
+	{
+		name:       "Log Writer - JSON Output",
+		args:       []string{"groups", "start", "all", "--output", "json"},
+		wantStderr: true,
+	},
+	{
+		name:       "Log Writer - YAML Output With Equals",
+		args:       []string{"groups", "start", "all", "--output=yaml"},
+		wantStderr: true,
+	},
+	{
+		name:       "Log Writer - JSON Output With Short Flag",
+		args:       []string{"-o", "json", "groups", "start", "all"},
+		wantStderr: true,
+	},
+	{
+		name:       "Log Writer - JSON Output With Combined Short Flag",
+		args:       []string{"groups", "start", "all", "-ojson"},
+		wantStderr: true,
+	},
+	{
+		name: "Log Writer - Last Output Flag Wins",
+		args: []string{"groups", "start", "all", "-o", "json", "--output", "text"},
+	},
+	{
+		name: "Log Writer - Output Flag After Terminator",
+		args: []string{"containers", "exec", "g1/c1", "--", "ls", "-o", "json"},
+	},
+	{
+		name: "Log Writer - Invalid Output",
+		args: []string{"groups", "start", "all", "-o", "xml"},
+	},
+	{
+		name:       "Log Writer - Config Export",
+		args:       []string{"config", "export", "compose", "--group", "g1"},
+		wantStderr: true,
+	},
+	{
+		name:       "Log Writer - Config Export With Interleaved Flags",
+		args:       []string{"--configs-dir", "/tmp/configs", "config", "--group=g1", "export", "compose"},
+		wantStderr: true,
+	},
+	{
+		name: "Log Writer - Config Show",
+		args: []string{"config", "show"},
+	},
+	{
+		name: "Log Writer - Export After Terminator",
+		args: []string{"containers", "exec", "g1/c1", "--", "config", "export"},
+	},
+	{
+		name: "Log Writer - Output Flag Like Flag Value",
+		args: []string{"--configs-dir", "-ojson", "config", "show"},
+	},
+	{
+		name: "Log Writer - Output Flag Like Short Flag Cluster",
+		args: []string{"containers", "exec", "g1/c1", "-t", "--user", "-ojson", "--", "ls"},
+	},
+	{
+		name: "Log Writer - Export Like Flag Value And Argument",
+		args: []string{"--configs-dir", "config", "groups", "start", "export"},
+	},
+	{
+		name: "Log Writer - Unknown Flag",
+		args: []string{"groups", "start", "all", "--foo", "-o", "json"},
 	},
 }
 
+func TestLogWriter(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range logWriterTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			outW := new(bytes.Buffer)
+			errW := new(bytes.Buffer)
+			got := LogWriter(testutils.NewVanillaTestContext(), outW, errW, tc.args...)
+			if gotStderr := got == errW; gotStderr != tc.wantStderr {
+				testhelpers.LogCustom(t, "LogWriter()", tc.name, fmt.Sprintf("gotStderr (%t) != wantStderr (%t)", gotStderr, tc.wantStderr))
+			}
+		})
+	}
+}
+
 func TestExecHomelabCmdErrors(t *testing.T) {
 	t.Parallel()
 
@@ -1732,6 +3733,14 @@ var executeHomelabConfigCmds = []struct {
 		cmdNameInError: "config show",
 		cmdDesc:        "Show Config",
 	},
+	{
+		cmdArgs: []string{
+			"config",
+			"validate",
+		},
+		cmdNameInError: "config validate",
+		cmdDesc:        "Validate Config",
+	},
 	{
 		cmdArgs: []string{
 			"groups",
@@ -1750,6 +3759,42 @@ var executeHomelabConfigCmds = []struct {
 		cmdNameInError: "groups stop",
 		cmdDesc:        "Groups Stop",
 	},
+	{
+		cmdArgs: []string{
+			"groups",
+			"restart",
+			"all",
+		},
+		cmdNameInError: "groups restart",
+		cmdDesc:        "Groups Restart",
+	},
+	{
+		cmdArgs: []string{
+			"groups",
+			"recreate",
+			"all",
+		},
+		cmdNameInError: "groups recreate",
+		cmdDesc:        "Groups Recreate",
+	},
+	{
+		cmdArgs: []string{
+			"groups",
+			"pause",
+			"all",
+		},
+		cmdNameInError: "groups pause",
+		cmdDesc:        "Groups Pause",
+	},
+	{
+		cmdArgs: []string{
+			"groups",
+			"unpause",
+			"all",
+		},
+		cmdNameInError: "groups unpause",
+		cmdDesc:        "Groups Unpause",
+	},
 	{
 		cmdArgs: []string{
 			"groups",
@@ -1777,6 +3822,42 @@ var executeHomelabConfigCmds = []struct {
 		cmdNameInError: "containers stop",
 		cmdDesc:        "Containers Stop",
 	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"restart",
+			"g1/c1",
+		},
+		cmdNameInError: "containers restart",
+		cmdDesc:        "Containers Restart",
+	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"recreate",
+			"g1/c1",
+		},
+		cmdNameInError: "containers recreate",
+		cmdDesc:        "Containers Recreate",
+	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"pause",
+			"g1/c1",
+		},
+		cmdNameInError: "containers pause",
+		cmdDesc:        "Containers Pause",
+	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"unpause",
+			"g1/c1",
+		},
+		cmdNameInError: "containers unpause",
+		cmdDesc:        "Containers Unpause",
+	},
 	{
 		cmdArgs: []string{
 			"containers",
@@ -1804,6 +3885,24 @@ var executeHomelabConfigCmds = []struct {
 		cmdNameInError: "networks delete",
 		cmdDesc:        "Networks Delete",
 	},
+	{
+		cmdArgs: []string{
+			"groups",
+			"status",
+			"all",
+		},
+		cmdNameInError: "groups status",
+		cmdDesc:        "Groups Status",
+	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"status",
+			"g1/c1",
+		},
+		cmdNameInError: "containers status",
+		cmdDesc:        "Containers Status",
+	},
 }
 
 var executeHomelabConfigCmdErrorTests = []struct {
@@ -1942,6 +4041,38 @@ var executeHomelabGroupsCmds = []struct {
 		cmdNameInError: "groups stop",
 		cmdDesc:        "Groups Stop",
 	},
+	{
+		cmdArgs: []string{
+			"groups",
+			"restart",
+		},
+		cmdNameInError: "groups restart",
+		cmdDesc:        "Groups Restart",
+	},
+	{
+		cmdArgs: []string{
+			"groups",
+			"recreate",
+		},
+		cmdNameInError: "groups recreate",
+		cmdDesc:        "Groups Recreate",
+	},
+	{
+		cmdArgs: []string{
+			"groups",
+			"pause",
+		},
+		cmdNameInError: "groups pause",
+		cmdDesc:        "Groups Pause",
+	},
+	{
+		cmdArgs: []string{
+			"groups",
+			"unpause",
+		},
+		cmdNameInError: "groups unpause",
+		cmdDesc:        "Groups Unpause",
+	},
 	{
 		cmdArgs: []string{
 			"groups",
@@ -1950,6 +4081,22 @@ var executeHomelabGroupsCmds = []struct {
 		cmdNameInError: "groups purge",
 		cmdDesc:        "Groups Purge",
 	},
+	{
+		cmdArgs: []string{
+			"groups",
+			"status",
+		},
+		cmdNameInError: "groups status",
+		cmdDesc:        "Groups Status",
+	},
+	{
+		cmdArgs: []string{
+			"groups",
+			"logs",
+		},
+		cmdNameInError: "groups logs",
+		cmdDesc:        "Groups Logs",
+	},
 }
 
 var executeHomelabGroupsCmdTests = []struct {
@@ -2073,6 +4220,38 @@ var executeHomelabContainerCmds = []struct {
 		cmdNameInError: "containers stop",
 		cmdDesc:        "Containers Stop",
 	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"restart",
+		},
+		cmdNameInError: "containers restart",
+		cmdDesc:        "Containers Restart",
+	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"recreate",
+		},
+		cmdNameInError: "containers recreate",
+		cmdDesc:        "Containers Recreate",
+	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"pause",
+		},
+		cmdNameInError: "containers pause",
+		cmdDesc:        "Containers Pause",
+	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"unpause",
+		},
+		cmdNameInError: "containers unpause",
+		cmdDesc:        "Containers Unpause",
+	},
 	{
 		cmdArgs: []string{
 			"containers",
@@ -2081,6 +4260,30 @@ var executeHomelabContainerCmds = []struct {
 		cmdNameInError: "containers purge",
 		cmdDesc:        "Containers Purge",
 	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"status",
+		},
+		cmdNameInError: "containers status",
+		cmdDesc:        "Containers Status",
+	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"logs",
+		},
+		cmdNameInError: "containers logs",
+		cmdDesc:        "Containers Logs",
+	},
+	{
+		cmdArgs: []string{
+			"containers",
+			"shell",
+		},
+		cmdNameInError: "containers shell",
+		cmdDesc:        "Containers Shell",
+	},
 }
 
 var executeHomelabContainerCmdErrorTests = []struct {
@@ -2267,7 +4470,7 @@ Completion ended with directive: ShellCompDirectiveError`,
 		name: "Homelab Command - %s - Completion - Invalid Homelab Config - Merge Fail",
 		preCmdArgs: []string{
 			"--configs-dir",
-			fmt.Sprintf("%s/testdata/parse-configs-invalid-deepmerge-fail", testhelpers.Pwd()),
+			fmt.Sprintf("%s/testdata/parse-configs-invalid-merge-conflict", testhelpers.Pwd()),
 			"__complete",
 		},
 		postCmdArgs: []string{
@@ -2398,7 +4601,7 @@ Completion ended with directive: ShellCompDirectiveError`,
 		name: "Homelab Command - %s - Completion - Invalid Homelab Config",
 		preCmdArgs: []string{
 			"--configs-dir",
-			fmt.Sprintf("%s/testdata/parse-configs-invalid-deepmerge-fail", testhelpers.Pwd()),
+			fmt.Sprintf("%s/testdata/parse-configs-invalid-merge-conflict", testhelpers.Pwd()),
 			"__complete",
 		},
 		postCmdArgs: []string{
@@ -2555,7 +4758,7 @@ Completion ended with directive: ShellCompDirectiveError`,
 		name: "Homelab Command - %s - Completion - Invalid Homelab Config - Merge Fail",
 		preCmdArgs: []string{
 			"--configs-dir",
-			fmt.Sprintf("%s/testdata/parse-configs-invalid-deepmerge-fail", testhelpers.Pwd()),
+			fmt.Sprintf("%s/testdata/parse-configs-invalid-merge-conflict", testhelpers.Pwd()),
 			"__complete",
 		},
 		postCmdArgs: []string{
@@ -2628,12 +4831,12 @@ func execHomelabCmdTestWithBuf(ctxInfo *testutils.TestContextInfo, logLevel *tux
 		lvl = *logLevel
 	}
 	ctxInfo.Logger = testutils.NewCapturingVanillaTestLogger(lvl, buf)
-	if ctxInfo.ContainerPurgeKillAttempts == 0 {
-		// Reduce the number of attempts to keep the tests executing quickly.
-		ctxInfo.ContainerPurgeKillAttempts = 5
+	if ctxInfo.ContainerPurgeGracePeriod == 0 {
+		// Reduce the grace period to keep the tests executing quickly.
+		ctxInfo.ContainerPurgeGracePeriod = 100 * time.Millisecond
 	}
 
 	ctx := testutils.NewTestContext(ctxInfo)
-	err := Exec(ctx, buf, buf, args...)
+	err := Exec(ctx, strings.NewReader(""), buf, buf, args...)
 	return buf, err
 }
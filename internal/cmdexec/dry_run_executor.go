@@ -0,0 +1,22 @@
+package cmdexec
+
+import (
+	"strings"
+
+	"github.com/tuxgal/homelab/internal/dryrun"
+)
+
+type dryRunExecutor struct {
+	rec *dryrun.Recorder
+}
+
+// NewDryRunExecutor returns an executor that only records the commands
+// in the specified recorder instead of running them.
+func NewDryRunExecutor(rec *dryrun.Recorder) Executor {
+	return &dryRunExecutor{rec: rec}
+}
+
+func (e *dryRunExecutor) Run(bin string, args ...string) (string, error) {
+	e.rec.Record("Run command: %s", strings.Join(append([]string{bin}, args...), " "))
+	return "", nil
+}
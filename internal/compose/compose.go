@@ -0,0 +1,172 @@
+// Package compose represents the subset of the docker compose file
+// format (as defined by the compose specification) that the homelab
+// deployments can be translated to.
+package compose
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Project represents a compose document.
+type Project struct {
+	Services map[string]*Service `yaml:"services"`
+	Networks map[string]*Network `yaml:"networks,omitempty"`
+	// Variables are the names of the variables interpolated within the
+	// parsed compose document, which were translated to the config env
+	// variable references.
+	Variables []string `yaml:"-"`
+	// UnsetOnlyInterpolations are the interpolation expressions within
+	// the parsed compose document which apply only when the variable is
+	// unset (e.g. ${VAR-default}), but were translated to the config env
+	// variable references which also apply when the variable is empty.
+	UnsetOnlyInterpolations []string `yaml:"-"`
+	// UnsupportedInterpolations are the interpolation expressions within
+	// the parsed compose document which could not be translated to the
+	// config env variable references and were left as is.
+	UnsupportedInterpolations []string `yaml:"-"`
+}
+
+// Service represents a service within the compose document.
+type Service struct {
+	ContainerName   string                     `yaml:"container_name,omitempty"`
+	Image           string                     `yaml:"image,omitempty"`
+	Hostname        string                     `yaml:"hostname,omitempty"`
+	DomainName      string                     `yaml:"domainname,omitempty"`
+	User            string                     `yaml:"user,omitempty"`
+	GroupAdd        []string                   `yaml:"group_add,omitempty"`
+	Tty             bool                       `yaml:"tty,omitempty"`
+	Init            *bool                      `yaml:"init,omitempty"`
+	Entrypoint      []string                   `yaml:"entrypoint,omitempty"`
+	Command         []string                   `yaml:"command,omitempty"`
+	Environment     []string                   `yaml:"environment,omitempty"`
+	Labels          map[string]string          `yaml:"labels,omitempty"`
+	DependsOn       []string                   `yaml:"depends_on,omitempty"`
+	Restart         string                     `yaml:"restart,omitempty"`
+	StopSignal      string                     `yaml:"stop_signal,omitempty"`
+	StopGracePeriod string                     `yaml:"stop_grace_period,omitempty"`
+	HealthCheck     *HealthCheck               `yaml:"healthcheck,omitempty"`
+	NetworkMode     string                     `yaml:"network_mode,omitempty"`
+	Networks        map[string]*ServiceNetwork `yaml:"networks,omitempty"`
+	Ports           []string                   `yaml:"ports,omitempty"`
+	DNS             []string                   `yaml:"dns,omitempty"`
+	DNSOpt          []string                   `yaml:"dns_opt,omitempty"`
+	DNSSearch       []string                   `yaml:"dns_search,omitempty"`
+	ExtraHosts      []string                   `yaml:"extra_hosts,omitempty"`
+	Volumes         []*Volume                  `yaml:"volumes,omitempty"`
+	Devices         []string                   `yaml:"devices,omitempty"`
+	ReadOnly        bool                       `yaml:"read_only,omitempty"`
+	Privileged      bool                       `yaml:"privileged,omitempty"`
+	CapAdd          []string                   `yaml:"cap_add,omitempty"`
+	CapDrop         []string                   `yaml:"cap_drop,omitempty"`
+	Sysctls         map[string]string          `yaml:"sysctls,omitempty"`
+	Pid             string                     `yaml:"pid,omitempty"`
+	Ipc             string                     `yaml:"ipc,omitempty"`
+	ShmSize         int64                      `yaml:"shm_size,omitempty"`
+	MemLimit        int64                      `yaml:"mem_limit,omitempty"`
+	MemReservation  int64                      `yaml:"mem_reservation,omitempty"`
+	MemSwapLimit    int64                      `yaml:"memswap_limit,omitempty"`
+	CPUs            float64                    `yaml:"cpus,omitempty"`
+	CPUShares       int64                      `yaml:"cpu_shares,omitempty"`
+	CPUSet          string                     `yaml:"cpuset,omitempty"`
+	PidsLimit       int64                      `yaml:"pids_limit,omitempty"`
+	BlkioConfig     *BlkioConfig               `yaml:"blkio_config,omitempty"`
+	OomKillDisable  bool                       `yaml:"oom_kill_disable,omitempty"`
+	OomScoreAdj     int                        `yaml:"oom_score_adj,omitempty"`
+	Ulimits         map[string]*Ulimit         `yaml:"ulimits,omitempty"`
+	Logging         *Logging                   `yaml:"logging,omitempty"`
+}
+
+// HealthCheck represents the health check of a service.
+type HealthCheck struct {
+	Test          []string `yaml:"test,omitempty"`
+	Interval      string   `yaml:"interval,omitempty"`
+	Timeout       string   `yaml:"timeout,omitempty"`
+	StartPeriod   string   `yaml:"start_period,omitempty"`
+	StartInterval string   `yaml:"start_interval,omitempty"`
+	Retries       int      `yaml:"retries,omitempty"`
+}
+
+// ServiceNetwork represents the attachment of a service to a network.
+type ServiceNetwork struct {
+	IPv4Address string `yaml:"ipv4_address,omitempty"`
+	IPv6Address string `yaml:"ipv6_address,omitempty"`
+	// Priority determines the order in which the service is connected
+	// to its networks, with the highest priority network connected first.
+	Priority int `yaml:"priority,omitempty"`
+}
+
+// Volume represents a mount within a service using the long syntax.
+type Volume struct {
+	Type     string       `yaml:"type"`
+	Source   string       `yaml:"source,omitempty"`
+	Target   string       `yaml:"target"`
+	ReadOnly bool         `yaml:"read_only,omitempty"`
+	Tmpfs    *TmpfsVolume `yaml:"tmpfs,omitempty"`
+}
+
+// TmpfsVolume represents the options of a tmpfs mount.
+type TmpfsVolume struct {
+	Size int64 `yaml:"size,omitempty"`
+}
+
+// Ulimit represents a resource limit of a service.
+type Ulimit struct {
+	Soft int64 `yaml:"soft"`
+	Hard int64 `yaml:"hard"`
+}
+
+// BlkioConfig represents the block IO configuration of a service.
+type BlkioConfig struct {
+	Weight uint16 `yaml:"weight,omitempty"`
+}
+
+// Logging represents the logging configuration of a service.
+type Logging struct {
+	Driver  string            `yaml:"driver,omitempty"`
+	Options map[string]string `yaml:"options,omitempty"`
+}
+
+// Network represents a network within the compose document.
+type Network struct {
+	Name       string            `yaml:"name,omitempty"`
+	Driver     string            `yaml:"driver,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	EnableIPv6 bool              `yaml:"enable_ipv6,omitempty"`
+	IPAM       *IPAM             `yaml:"ipam,omitempty"`
+}
+
+// IPAM represents the IP address management configuration of a network.
+type IPAM struct {
+	Driver string        `yaml:"driver,omitempty"`
+	Config []*IPAMConfig `yaml:"config,omitempty"`
+}
+
+// IPAMConfig represents a subnet of a network.
+type IPAMConfig struct {
+	Subnet  string `yaml:"subnet,omitempty"`
+	Gateway string `yaml:"gateway,omitempty"`
+}
+
+// NewProject returns an empty compose project.
+func NewProject() *Project {
+	return &Project{
+		Services: make(map[string]*Service),
+	}
+}
+
+// YAML returns the compose document serialized as yaml.
+func (p *Project) YAML() ([]byte, error) {
+	res := bytes.Buffer{}
+	enc := yaml.NewEncoder(&res)
+	enc.SetIndent(2)
+	if err := enc.Encode(p); err != nil {
+		return nil, fmt.Errorf("failed to serialize the compose document, reason: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to serialize the compose document, reason: %w", err)
+	}
+	return res.Bytes(), nil
+}
@@ -0,0 +1,469 @@
+package compose
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tuxgal/homelab/internal/config"
+)
+
+const (
+	// Driver option holding the name of the bridge interface on the host.
+	bridgeNameDriverOpt = "com.docker.network.bridge.name"
+	// Maximum length of a network interface name on linux.
+	maxInterfaceNameLen = 15
+)
+
+type converter struct {
+	project  *Project
+	group    string
+	warnings []string
+	// networks maps the names of the networks within the compose project
+	// to their bridge mode networks in the homelab config.
+	networks map[string]*config.BridgeModeNetwork
+}
+
+// HomelabConfig translates the services and the networks of the compose
+// project into the homelab config of a single container group, along
+// with the warnings about the settings which could not be translated.
+func (p *Project) HomelabConfig(group string) (*config.Homelab, []string) {
+	c := &converter{
+		project:  p,
+		group:    group,
+		networks: make(map[string]*config.BridgeModeNetwork),
+	}
+	conf := &config.Homelab{
+		Groups: []config.ContainerGroup{
+			{
+				Name:  group,
+				Order: 1,
+			},
+		},
+	}
+
+	for _, v := range p.Variables {
+		c.warnf("variable %s interpolated within the compose document was translated to the config env variable $$%s$$, which must be defined in the config env unless a default value is specified", v, v)
+	}
+	for _, expr := range p.UnsetOnlyInterpolations {
+		c.warnf("interpolation %s applies only when the variable is unset, but was translated to a config env variable reference which also applies when the variable is empty", expr)
+	}
+	for _, expr := range p.UnsupportedInterpolations {
+		c.warnf("interpolation %s is not supported and was left as is", expr)
+	}
+
+	for i, name := range sortedKeys(p.Networks) {
+		if n := c.bridgeModeNetwork(name, p.Networks[name], i+1); n != nil {
+			c.networks[name] = n
+		}
+	}
+
+	cmNetworks := make(map[string]*config.ContainerModeNetwork)
+	for _, name := range sortedKeys(p.Services) {
+		ct, cmn := c.container(name, p.Services[name])
+		conf.Containers = append(conf.Containers, *ct)
+		if cmn != nil {
+			if n, found := cmNetworks[cmn.Name]; found {
+				n.AttachingContainers = append(n.AttachingContainers, cmn.AttachingContainers...)
+			} else {
+				cmNetworks[cmn.Name] = cmn
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(c.networks) {
+		conf.IPAM.Networks.BridgeModeNetworks = append(conf.IPAM.Networks.BridgeModeNetworks, *c.networks[name])
+	}
+	for _, name := range sortedKeys(cmNetworks) {
+		conf.IPAM.Networks.ContainerModeNetworks = append(conf.IPAM.Networks.ContainerModeNetworks, *cmNetworks[name])
+	}
+	return conf, c.warnings
+}
+
+func (c *converter) warnf(format string, args ...any) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+func (c *converter) bridgeModeNetwork(name string, n *Network, priority int) *config.BridgeModeNetwork {
+	if len(n.Driver) > 0 && n.Driver != "bridge" {
+		c.warnf("network %s uses the %s driver, only the bridge driver is supported", name, n.Driver)
+		return nil
+	}
+
+	res := &config.BridgeModeNetwork{
+		Name:              name,
+		HostInterfaceName: n.DriverOpts[bridgeNameDriverOpt],
+		Priority:          priority,
+	}
+	if len(n.Name) > 0 {
+		res.Name = n.Name
+	}
+	if len(res.HostInterfaceName) == 0 {
+		res.HostInterfaceName = fmt.Sprintf("docker-%s", res.Name)
+		if len(res.HostInterfaceName) > maxInterfaceNameLen {
+			res.HostInterfaceName = res.HostInterfaceName[:maxInterfaceNameLen]
+		}
+		c.warnf("network %s does not specify the bridge interface name, using %s", name, res.HostInterfaceName)
+	}
+
+	if n.IPAM != nil {
+		for _, ipam := range n.IPAM.Config {
+			prefix, err := netip.ParsePrefix(ipam.Subnet)
+			if err != nil {
+				c.warnf("network %s has an invalid subnet %s which was skipped", name, ipam.Subnet)
+				continue
+			}
+			if prefix.Addr().Is4() {
+				res.CIDR.V4 = ipam.Subnet
+			} else {
+				res.CIDR.V6 = ipam.Subnet
+			}
+			// The first address within the subnet is always used as the
+			// gateway by the homelab networks.
+			if len(ipam.Gateway) > 0 && ipam.Gateway != prefix.Masked().Addr().Next().String() {
+				c.warnf("network %s uses the gateway %s which is not the first address within the subnet %s", name, ipam.Gateway, ipam.Subnet)
+			}
+		}
+	}
+	if len(res.CIDR.V4) == 0 {
+		c.warnf("network %s does not specify an IPv4 subnet and was skipped", name)
+		return nil
+	}
+	return res
+}
+
+func (c *converter) container(name string, svc *Service) (*config.Container, *config.ContainerModeNetwork) {
+	ref := config.ContainerReference{Group: c.group, Container: name}
+	ct := &config.Container{
+		Info: ref,
+		Image: config.ContainerImage{
+			Image: svc.Image,
+		},
+		Lifecycle: config.ContainerLifecycle{
+			Order:      1,
+			StopSignal: svc.StopSignal,
+		},
+		User: config.ContainerUser{
+			AdditionalGroups: svc.GroupAdd,
+		},
+		Filesystem: config.ContainerFilesystem{
+			ReadOnlyRootfs: svc.ReadOnly,
+		},
+		Network: config.ContainerNetwork{
+			HostName:   svc.Hostname,
+			DomainName: svc.DomainName,
+			DNSServers: svc.DNS,
+			DNSOptions: svc.DNSOpt,
+			DNSSearch:  svc.DNSSearch,
+			ExtraHosts: svc.ExtraHosts,
+		},
+		Security: config.ContainerSecurity{
+			Privileged: svc.Privileged,
+			CapAdd:     svc.CapAdd,
+			CapDrop:    svc.CapDrop,
+			PidMode:    svc.Pid,
+			IpcMode:    svc.Ipc,
+		},
+		Runtime: config.ContainerRuntime{
+			AttachToTty:    svc.Tty,
+			Entrypoint:     svc.Entrypoint,
+			Args:           svc.Command,
+			Init:           svc.Init,
+			OOMScoreAdj:    svc.OomScoreAdj,
+			OOMKillDisable: svc.OomKillDisable,
+		},
+		Resources: config.ContainerResources{
+			Memory:            sizeString(svc.MemLimit),
+			MemoryReservation: sizeString(svc.MemReservation),
+			MemorySwap:        sizeString(svc.MemSwapLimit),
+			CPUs:              svc.CPUs,
+			CPUShares:         svc.CPUShares,
+			CPUSet:            svc.CPUSet,
+			PidsLimit:         svc.PidsLimit,
+		},
+	}
+
+	if len(svc.ContainerName) > 0 && svc.ContainerName != fmt.Sprintf("%s-%s", c.group, name) {
+		c.warnf("service %s specifies the container name %s, which is replaced by the homelab container name %s-%s", name, svc.ContainerName, c.group, name)
+	}
+	ct.User.User, ct.User.PrimaryGroup, _ = strings.Cut(svc.User, ":")
+	if svc.ShmSize > 0 {
+		ct.Runtime.ShmSize = sizeString(svc.ShmSize)
+	}
+	if svc.BlkioConfig != nil {
+		ct.Resources.BlkioWeight = svc.BlkioConfig.Weight
+	}
+	if svc.Logging != nil {
+		ct.Logging.Driver = svc.Logging.Driver
+		for _, k := range sortedKeys(svc.Logging.Options) {
+			ct.Logging.Options = append(ct.Logging.Options, config.LoggingOption{Name: k, Value: svc.Logging.Options[k]})
+		}
+	}
+	for _, k := range sortedKeys(svc.Sysctls) {
+		ct.Security.Sysctls = append(ct.Security.Sysctls, config.Sysctl{Key: k, Value: svc.Sysctls[k]})
+	}
+	for _, k := range sortedKeys(svc.Ulimits) {
+		ct.Runtime.Ulimits = append(ct.Runtime.Ulimits, config.Ulimit{Name: k, Soft: svc.Ulimits[k].Soft, Hard: svc.Ulimits[k].Hard})
+	}
+
+	c.restartPolicy(name, svc, ct)
+	c.stopTimeout(name, svc, ct)
+	c.environment(name, svc, ct)
+	c.labels(name, svc, ct)
+	c.dependencies(name, svc, ct)
+	c.healthCheck(name, svc, ct)
+	c.ports(name, svc, ct)
+	c.mounts(name, svc, ct)
+	c.devices(name, svc, ct)
+	return ct, c.containerNetworks(name, svc, ref)
+}
+
+func (c *converter) restartPolicy(name string, svc *Service, ct *config.Container) {
+	if len(svc.Restart) == 0 {
+		return
+	}
+	mode, retries, found := strings.Cut(svc.Restart, ":")
+	ct.Lifecycle.RestartPolicy.Mode = mode
+	if found {
+		n, err := strconv.Atoi(retries)
+		if err != nil {
+			c.warnf("service %s has an invalid restart policy maximum retry count %s which was skipped", name, retries)
+			return
+		}
+		ct.Lifecycle.RestartPolicy.MaxRetryCount = n
+	}
+}
+
+func (c *converter) stopTimeout(name string, svc *Service, ct *config.Container) {
+	if len(svc.StopGracePeriod) == 0 {
+		return
+	}
+	d, err := time.ParseDuration(svc.StopGracePeriod)
+	if err != nil {
+		c.warnf("service %s has an invalid stop grace period %s which was skipped", name, svc.StopGracePeriod)
+		return
+	}
+	ct.Lifecycle.StopTimeout = int(d.Seconds())
+}
+
+func (c *converter) environment(name string, svc *Service, ct *config.Container) {
+	for _, e := range svc.Environment {
+		k, v, found := strings.Cut(e, "=")
+		if !found {
+			c.warnf("service %s passes through the environment variable %s without a value, which was skipped", name, k)
+			continue
+		}
+		ct.Runtime.Env = append(ct.Runtime.Env, config.ContainerEnv{Var: k, Value: v})
+	}
+}
+
+func (c *converter) labels(name string, svc *Service, ct *config.Container) {
+	for _, k := range sortedKeys(svc.Labels) {
+		if len(svc.Labels[k]) == 0 {
+			c.warnf("service %s has the label %s without a value, which was skipped", name, k)
+			continue
+		}
+		ct.Metadata.Labels = append(ct.Metadata.Labels, config.Label{Name: k, Value: svc.Labels[k]})
+	}
+}
+
+func (c *converter) dependencies(name string, svc *Service, ct *config.Container) {
+	for _, dep := range svc.DependsOn {
+		if _, found := c.project.Services[dep]; !found {
+			c.warnf("service %s depends on the service %s which is not defined, the dependency was skipped", name, dep)
+			continue
+		}
+		ct.Lifecycle.DependsOn = append(ct.Lifecycle.DependsOn, config.ContainerReference{Group: c.group, Container: dep})
+	}
+}
+
+func (c *converter) healthCheck(name string, svc *Service, ct *config.Container) {
+	h := svc.HealthCheck
+	if h == nil {
+		return
+	}
+	ct.Health = config.ContainerHealth{
+		Retries:       h.Retries,
+		Interval:      h.Interval,
+		Timeout:       h.Timeout,
+		StartPeriod:   h.StartPeriod,
+		StartInterval: h.StartInterval,
+	}
+	if len(h.Test) == 0 {
+		return
+	}
+	switch h.Test[0] {
+	case "CMD":
+		ct.Health.Cmd = h.Test[1:]
+	case "CMD-SHELL":
+		ct.Health.Cmd = append([]string{"/bin/sh", "-c"}, strings.Join(h.Test[1:], " "))
+	case "NONE":
+		c.warnf("service %s disables the health check of the image, which is not supported", name)
+	default:
+		c.warnf("service %s has a health check test %q without the CMD or CMD-SHELL prefix, which was skipped", name, h.Test)
+	}
+}
+
+func (c *converter) ports(name string, svc *Service, ct *config.Container) {
+	for _, p := range svc.Ports {
+		port, proto, found := strings.Cut(p, "/")
+		if !found {
+			proto = "tcp"
+		}
+
+		hostIP := "0.0.0.0"
+		if strings.HasPrefix(port, "[") {
+			ip, rest, found := strings.Cut(port[1:], "]:")
+			if !found {
+				c.warnf("service %s has an invalid published port %s which was skipped", name, p)
+				continue
+			}
+			hostIP = ip
+			port = fmt.Sprintf(":%s", rest)
+		}
+		parts := strings.Split(port, ":")
+		if len(parts) == 3 {
+			if len(parts[0]) > 0 {
+				hostIP = parts[0]
+			}
+			parts = parts[1:]
+		}
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			c.warnf("service %s publishes the port %s on a random host port, which is not supported", name, p)
+			continue
+		}
+		if strings.Contains(port, "-") {
+			c.warnf("service %s publishes the port range %s, which is not supported", name, p)
+			continue
+		}
+		ct.Network.PublishedPorts = append(ct.Network.PublishedPorts, config.PublishedPort{
+			ContainerPort: parts[1],
+			Protocol:      proto,
+			HostIP:        hostIP,
+			HostPort:      parts[0],
+		})
+	}
+}
+
+func (c *converter) mounts(name string, svc *Service, ct *config.Container) {
+	for _, v := range svc.Volumes {
+		switch v.Type {
+		case "bind":
+			ct.Filesystem.Mounts = append(ct.Filesystem.Mounts, config.Mount{
+				Name:     mountName(v.Target),
+				Type:     "bind",
+				Src:      v.Source,
+				Dst:      v.Target,
+				ReadOnly: v.ReadOnly,
+			})
+		case "tmpfs":
+			m := config.Mount{
+				Name: mountName(v.Target),
+				Type: "tmpfs",
+				Dst:  v.Target,
+			}
+			if v.Tmpfs != nil {
+				m.TmpfsSize = v.Tmpfs.Size
+			}
+			ct.Filesystem.Mounts = append(ct.Filesystem.Mounts, m)
+		default:
+			c.warnf("service %s mounts a %s at %s, only bind and tmpfs mounts are supported", name, v.Type, v.Target)
+		}
+	}
+}
+
+func (c *converter) devices(name string, svc *Service, ct *config.Container) {
+	for _, d := range svc.Devices {
+		parts := strings.Split(d, ":")
+		if len(parts) > 3 {
+			c.warnf("service %s has an invalid device %s which was skipped", name, d)
+			continue
+		}
+		dev := config.Device{Src: parts[0]}
+		if len(parts) > 1 && parts[1] != parts[0] {
+			dev.Dst = parts[1]
+		}
+		if len(parts) > 2 {
+			dev.DisallowRead = !strings.Contains(parts[2], "r")
+			dev.DisallowWrite = !strings.Contains(parts[2], "w")
+			dev.DisallowMknod = !strings.Contains(parts[2], "m")
+		}
+		ct.Filesystem.Devices.Static = append(ct.Filesystem.Devices.Static, dev)
+	}
+}
+
+func (c *converter) containerNetworks(name string, svc *Service, ref config.ContainerReference) *config.ContainerModeNetwork {
+	switch {
+	case strings.HasPrefix(svc.NetworkMode, "service:"):
+		target := strings.TrimPrefix(svc.NetworkMode, "service:")
+		if _, found := c.project.Services[target]; !found {
+			c.warnf("service %s uses the network stack of the service %s which is not defined", name, target)
+			return nil
+		}
+		return &config.ContainerModeNetwork{
+			Name: fmt.Sprintf("%s-%s", c.group, target),
+			Container: config.ContainerReference{
+				Group:     c.group,
+				Container: target,
+			},
+			AttachingContainers: []config.ContainerReference{ref},
+		}
+	case svc.NetworkMode == "none":
+		return nil
+	case len(svc.NetworkMode) > 0:
+		c.warnf("service %s uses the network mode %s, which is not supported", name, svc.NetworkMode)
+		return nil
+	case len(svc.Networks) == 0:
+		c.warnf("service %s is attached to the default network, which is not supported", name)
+		return nil
+	}
+
+	for _, n := range sortedKeys(svc.Networks) {
+		sn := svc.Networks[n]
+		bmn, found := c.networks[n]
+		if !found {
+			c.warnf("service %s is attached to the network %s which is not supported", name, n)
+			continue
+		}
+		if len(sn.IPv4Address) == 0 {
+			c.warnf("service %s is attached to the network %s without a static IPv4 address, which is not supported", name, n)
+			continue
+		}
+		bmn.Containers = append(bmn.Containers, config.ContainerIPInfo{
+			IP: config.ContainerIP{
+				IPv4: sn.IPv4Address,
+				IPv6: sn.IPv6Address,
+			},
+			Container: ref,
+		})
+	}
+	return nil
+}
+
+// mountName returns the name of the mount derived from its target.
+func mountName(target string) string {
+	name := strings.ReplaceAll(strings.Trim(target, "/"), "/", "-")
+	if len(name) == 0 {
+		return "root"
+	}
+	return name
+}
+
+func sizeString(size int64) string {
+	if size == 0 {
+		return ""
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
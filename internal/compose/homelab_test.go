@@ -0,0 +1,478 @@
+package compose
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tuxgal/homelab/internal/config"
+	"github.com/tuxgal/homelab/internal/testhelpers"
+)
+
+var homelabConfigTests = []struct {
+	name         string
+	project      *Project
+	want         *config.Homelab
+	wantWarnings []string
+}{
+	{
+		name: "HomelabConfig() - Services And Networks",
+		project: &Project{
+			Services: map[string]*Service{
+				"web": {
+					Image:       "nginx",
+					User:        "101:102",
+					Command:     []string{"nginx"},
+					Environment: []string{"TZ=UTC"},
+					Restart:     "on-failure:3",
+					DependsOn:   []string{"db"},
+					HealthCheck: &HealthCheck{
+						Test:     []string{"CMD-SHELL", "curl -f http://localhost"},
+						Interval: "10s",
+					},
+					Ports: []string{"127.0.0.1:8080:80", "53:53/udp", "[::1]:8443:443"},
+					Volumes: []*Volume{
+						{
+							Type:     "bind",
+							Source:   "/srv/html",
+							Target:   "/usr/share/html",
+							ReadOnly: true,
+						},
+					},
+					Devices: []string{"/dev/dri:/dev/dri:rw"},
+					Networks: map[string]*ServiceNetwork{
+						"net1": {
+							IPv4Address: "172.20.0.10",
+						},
+					},
+					MemLimit: 1024,
+				},
+				"db": {
+					Image:       "postgres",
+					NetworkMode: "service:web",
+				},
+			},
+			Networks: map[string]*Network{
+				"net1": {
+					DriverOpts: map[string]string{
+						bridgeNameDriverOpt: "br-net1",
+					},
+					IPAM: &IPAM{
+						Config: []*IPAMConfig{
+							{
+								Subnet:  "172.20.0.0/24",
+								Gateway: "172.20.0.1",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: &config.Homelab{
+			IPAM: config.IPAM{
+				Networks: config.Networks{
+					BridgeModeNetworks: []config.BridgeModeNetwork{
+						{
+							Name:              "net1",
+							HostInterfaceName: "br-net1",
+							CIDR: config.NetworkCIDR{
+								V4: "172.20.0.0/24",
+							},
+							Priority: 1,
+							Containers: []config.ContainerIPInfo{
+								{
+									IP: config.ContainerIP{
+										IPv4: "172.20.0.10",
+									},
+									Container: config.ContainerReference{
+										Group:     "g1",
+										Container: "web",
+									},
+								},
+							},
+						},
+					},
+					ContainerModeNetworks: []config.ContainerModeNetwork{
+						{
+							Name: "g1-web",
+							Container: config.ContainerReference{
+								Group:     "g1",
+								Container: "web",
+							},
+							AttachingContainers: []config.ContainerReference{
+								{
+									Group:     "g1",
+									Container: "db",
+								},
+							},
+						},
+					},
+				},
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "db",
+					},
+					Image: config.ContainerImage{
+						Image: "postgres",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "web",
+					},
+					Image: config.ContainerImage{
+						Image: "nginx",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+						RestartPolicy: config.ContainerRestartPolicy{
+							Mode:          "on-failure",
+							MaxRetryCount: 3,
+						},
+						DependsOn: []config.ContainerReference{
+							{
+								Group:     "g1",
+								Container: "db",
+							},
+						},
+					},
+					User: config.ContainerUser{
+						User:         "101",
+						PrimaryGroup: "102",
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name:     "usr-share-html",
+								Type:     "bind",
+								Src:      "/srv/html",
+								Dst:      "/usr/share/html",
+								ReadOnly: true,
+							},
+						},
+						Devices: config.ContainerDevice{
+							Static: []config.Device{
+								{
+									Src:           "/dev/dri",
+									DisallowMknod: true,
+								},
+							},
+						},
+					},
+					Network: config.ContainerNetwork{
+						PublishedPorts: []config.PublishedPort{
+							{
+								ContainerPort: "80",
+								Protocol:      "tcp",
+								HostIP:        "127.0.0.1",
+								HostPort:      "8080",
+							},
+							{
+								ContainerPort: "53",
+								Protocol:      "udp",
+								HostIP:        "0.0.0.0",
+								HostPort:      "53",
+							},
+							{
+								ContainerPort: "443",
+								Protocol:      "tcp",
+								HostIP:        "::1",
+								HostPort:      "8443",
+							},
+						},
+					},
+					Health: config.ContainerHealth{
+						Cmd:      []string{"/bin/sh", "-c", "curl -f http://localhost"},
+						Interval: "10s",
+					},
+					Runtime: config.ContainerRuntime{
+						Env: []config.ContainerEnv{
+							{
+								Var:   "TZ",
+								Value: "UTC",
+							},
+						},
+						Args: []string{"nginx"},
+					},
+					Resources: config.ContainerResources{
+						Memory: "1024",
+					},
+				},
+			},
+		},
+	},
+	{
+		name: "HomelabConfig() - Unsupported Settings",
+		project: &Project{
+			Services: map[string]*Service{
+				"web": {
+					Image:         "nginx",
+					ContainerName: "nginx",
+					Environment:   []string{"PASS_THROUGH"},
+					DependsOn:     []string{"foo"},
+					Ports:         []string{"80", "8000-8001:8000-8001"},
+					Volumes: []*Volume{
+						{
+							Type:   "volume",
+							Source: "data",
+							Target: "/data",
+						},
+					},
+					Networks: map[string]*ServiceNetwork{
+						"net1": {},
+						"net2": {},
+					},
+				},
+				"host": {
+					Image:       "busybox",
+					NetworkMode: "host",
+				},
+			},
+			Networks: map[string]*Network{
+				"net1": {
+					IPAM: &IPAM{
+						Config: []*IPAMConfig{
+							{
+								Subnet: "172.20.0.0/24",
+							},
+						},
+					},
+				},
+				"net2": {
+					Driver: "overlay",
+				},
+			},
+		},
+		want: &config.Homelab{
+			IPAM: config.IPAM{
+				Networks: config.Networks{
+					BridgeModeNetworks: []config.BridgeModeNetwork{
+						{
+							Name:              "net1",
+							HostInterfaceName: "docker-net1",
+							CIDR: config.NetworkCIDR{
+								V4: "172.20.0.0/24",
+							},
+							Priority: 1,
+						},
+					},
+				},
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "host",
+					},
+					Image: config.ContainerImage{
+						Image: "busybox",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "web",
+					},
+					Image: config.ContainerImage{
+						Image: "nginx",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+			},
+		},
+		wantWarnings: []string{
+			"network net1 does not specify the bridge interface name, using docker-net1",
+			"network net2 uses the overlay driver, only the bridge driver is supported",
+			"service host uses the network mode host, which is not supported",
+			"service web specifies the container name nginx, which is replaced by the homelab container name g1-web",
+			"service web passes through the environment variable PASS_THROUGH without a value, which was skipped",
+			"service web depends on the service foo which is not defined, the dependency was skipped",
+			"service web publishes the port 80 on a random host port, which is not supported",
+			"service web publishes the port range 8000-8001:8000-8001, which is not supported",
+			"service web mounts a volume at /data, only bind and tmpfs mounts are supported",
+			"service web is attached to the network net1 without a static IPv4 address, which is not supported",
+			"service web is attached to the network net2 which is not supported",
+		},
+	},
+}
+
+func TestHomelabConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range homelabConfigTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotWarnings := tc.project.HomelabConfig("g1")
+			testhelpers.CmpDiff(t, "HomelabConfig()", tc.name, "config", tc.want, got)
+			testhelpers.CmpDiff(t, "HomelabConfig()", tc.name, "warnings", tc.wantWarnings, gotWarnings)
+		})
+	}
+}
+
+var homelabConfigFromDocumentTests = []struct {
+	name         string
+	doc          string
+	want         []config.Container
+	wantWarnings []string
+}{
+	{
+		name: "HomelabConfig() - Parsed Document - Interpolation",
+		doc: `
+services:
+  web:
+    image: nginx:${TAG:-latest}
+    network_mode: none
+    environment:
+      - PASSWORD=${DB_PASS}
+      - HOST=${DB_HOST-db}
+      - USER=$DB_USER
+      - PRICE=$$5
+      - ALT=${FOO:+bar}
+`,
+		want: []config.Container{
+			{
+				Info: config.ContainerReference{
+					Group:     "g1",
+					Container: "web",
+				},
+				Image: config.ContainerImage{
+					Image: "nginx:$$TAG:-latest$$",
+				},
+				Lifecycle: config.ContainerLifecycle{
+					Order: 1,
+				},
+				Runtime: config.ContainerRuntime{
+					Env: []config.ContainerEnv{
+						{
+							Var:   "PASSWORD",
+							Value: "$$DB_PASS$$",
+						},
+						{
+							Var:   "HOST",
+							Value: "$$DB_HOST:-db$$",
+						},
+						{
+							Var:   "USER",
+							Value: "$$DB_USER$$",
+						},
+						{
+							Var:   "PRICE",
+							Value: "$5",
+						},
+						{
+							Var:   "ALT",
+							Value: "${FOO:+bar}",
+						},
+					},
+				},
+			},
+		},
+		wantWarnings: []string{
+			"variable DB_HOST interpolated within the compose document was translated to the config env variable $$DB_HOST$$, which must be defined in the config env unless a default value is specified",
+			"variable DB_PASS interpolated within the compose document was translated to the config env variable $$DB_PASS$$, which must be defined in the config env unless a default value is specified",
+			"variable DB_USER interpolated within the compose document was translated to the config env variable $$DB_USER$$, which must be defined in the config env unless a default value is specified",
+			"variable TAG interpolated within the compose document was translated to the config env variable $$TAG$$, which must be defined in the config env unless a default value is specified",
+			"interpolation ${DB_HOST-db} applies only when the variable is unset, but was translated to a config env variable reference which also applies when the variable is empty",
+			"interpolation ${FOO:+bar} is not supported and was left as is",
+		},
+	},
+	{
+		name: "HomelabConfig() - Parsed Document - Relative Bind Mounts",
+		doc: `
+services:
+  web:
+    image: nginx
+    network_mode: none
+    volumes:
+      - ./data:/data
+      - type: bind
+        source: ../shared
+        target: /shared
+        read_only: true
+      - /srv/html:/html
+`,
+		want: []config.Container{
+			{
+				Info: config.ContainerReference{
+					Group:     "g1",
+					Container: "web",
+				},
+				Image: config.ContainerImage{
+					Image: "nginx",
+				},
+				Lifecycle: config.ContainerLifecycle{
+					Order: 1,
+				},
+				Filesystem: config.ContainerFilesystem{
+					Mounts: []config.Mount{
+						{
+							Name: "data",
+							Type: "bind",
+							Src:  "/srv/compose/data",
+							Dst:  "/data",
+						},
+						{
+							Name:     "shared",
+							Type:     "bind",
+							Src:      "/srv/shared",
+							Dst:      "/shared",
+							ReadOnly: true,
+						},
+						{
+							Name: "html",
+							Type: "bind",
+							Src:  "/srv/html",
+							Dst:  "/html",
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestHomelabConfigFromDocument(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range homelabConfigFromDocumentTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, _, err := Parse(strings.NewReader(tc.doc), "/srv/compose")
+			if err != nil {
+				testhelpers.LogErrorNotNil(t, "Parse()", tc.name, err)
+				return
+			}
+
+			got, gotWarnings := project.HomelabConfig("g1")
+			testhelpers.CmpDiff(t, "HomelabConfig()", tc.name, "containers", tc.want, got.Containers)
+			testhelpers.CmpDiff(t, "HomelabConfig()", tc.name, "warnings", tc.wantWarnings, gotWarnings)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package compose
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// interpolator translates the variable interpolation within the values
+// of a compose document into the config env variable references of the
+// homelab config:
+//
+//	$VAR, ${VAR}                        $$VAR$$
+//	${VAR:-default}, ${VAR-default}     $$VAR:-default$$
+//	${VAR:?message}, ${VAR?message}     $$VAR:?message$$
+//	$$                                  $
+//
+// The config env variable references have no equivalent of ${VAR-default}
+// and ${VAR?message}, which apply only when VAR is unset rather than when
+// it is unset or empty. These are still translated to the closest
+// references, but are recorded so that the difference can be reported.
+//
+// The remaining forms of interpolation (e.g. ${VAR:+replacement} or the
+// nested interpolation within the default value) are left as is.
+type interpolator struct {
+	variables   map[string]struct{}
+	unsetOnly   []string
+	unsupported []string
+}
+
+func newInterpolator() *interpolator {
+	return &interpolator{variables: make(map[string]struct{})}
+}
+
+// node translates the interpolation within all the scalar values of the
+// node and its descendants. The keys of the mappings are left untouched
+// since compose does not interpolate them either.
+func (in *interpolator) node(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range node.Content {
+			in.node(n)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			in.node(node.Content[i])
+		}
+	case yaml.ScalarNode:
+		if node.ShortTag() == "!!str" && strings.Contains(node.Value, "$") {
+			node.Value = in.value(node.Value)
+		}
+	}
+}
+
+func (in *interpolator) value(val string) string {
+	var res, literal strings.Builder
+	// A literal $$ within the homelab config must be escaped to prevent
+	// it from being treated as the beginning of a config env reference.
+	flush := func() {
+		res.WriteString(strings.ReplaceAll(literal.String(), "$$", `\$$`))
+		literal.Reset()
+	}
+
+	for i := 0; i < len(val); {
+		if val[i] != '$' {
+			literal.WriteByte(val[i])
+			i++
+			continue
+		}
+
+		rest := val[i+1:]
+		switch {
+		case strings.HasPrefix(rest, "$"):
+			literal.WriteByte('$')
+			i += 2
+		case strings.HasPrefix(rest, "{"):
+			end := strings.IndexByte(rest, '}')
+			if end < 0 {
+				in.unsupported = append(in.unsupported, val[i:])
+				literal.WriteString(val[i:])
+				i = len(val)
+				continue
+			}
+			expr := val[i : i+end+2]
+			if ref, ok := in.reference(rest[1:end]); ok {
+				flush()
+				res.WriteString(ref)
+			} else {
+				in.unsupported = append(in.unsupported, expr)
+				literal.WriteString(expr)
+			}
+			i += len(expr)
+		default:
+			n := variableNameLen(rest)
+			if n == 0 {
+				literal.WriteByte('$')
+				i++
+				continue
+			}
+			ref, _ := in.reference(rest[:n])
+			flush()
+			res.WriteString(ref)
+			i += n + 1
+		}
+	}
+	flush()
+	return res.String()
+}
+
+// reference returns the config env variable reference equivalent to the
+// specified interpolation expression, i.e. the contents of ${...}.
+func (in *interpolator) reference(expr string) (string, bool) {
+	n := variableNameLen(expr)
+	if n == 0 {
+		return "", false
+	}
+	name, rest := expr[:n], expr[n:]
+
+	op, unsetOnly := "", false
+	switch {
+	case len(rest) == 0:
+	case strings.HasPrefix(rest, ":-"), strings.HasPrefix(rest, ":?"):
+		op, rest = rest[:2], rest[2:]
+	case strings.HasPrefix(rest, "-"):
+		op, rest = ":-", rest[1:]
+		unsetOnly = true
+	case strings.HasPrefix(rest, "?"):
+		op, rest = ":?", rest[1:]
+		unsetOnly = true
+	default:
+		return "", false
+	}
+	if strings.Contains(rest, "$") {
+		return "", false
+	}
+
+	if unsetOnly {
+		in.unsetOnly = append(in.unsetOnly, fmt.Sprintf("${%s}", expr))
+	}
+	in.variables[name] = struct{}{}
+	return fmt.Sprintf("$$%s%s%s$$", name, op, rest), true
+}
+
+func variableNameLen(s string) int {
+	n := 0
+	for n < len(s) && isVariableNameChar(s[n], n == 0) {
+		n++
+	}
+	return n
+}
+
+func isVariableNameChar(c byte, first bool) bool {
+	if c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
+		return true
+	}
+	return !first && c >= '0' && c <= '9'
+}
@@ -0,0 +1,697 @@
+package compose
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+	"gopkg.in/yaml.v3"
+)
+
+// serviceKeyParser parses the value of a key within a service into the
+// service.
+type serviceKeyParser func(svc *Service, node *yaml.Node) error
+
+// serviceKeyParsers maps the supported keys of a service to their
+// parsers. The keys not present here are reported as unsupported.
+var serviceKeyParsers = map[string]serviceKeyParser{
+	"container_name":    decodeInto(func(s *Service) any { return &s.ContainerName }),
+	"image":             decodeInto(func(s *Service) any { return &s.Image }),
+	"hostname":          decodeInto(func(s *Service) any { return &s.Hostname }),
+	"domainname":        decodeInto(func(s *Service) any { return &s.DomainName }),
+	"user":              decodeInto(func(s *Service) any { return &s.User }),
+	"group_add":         decodeInto(func(s *Service) any { return &s.GroupAdd }),
+	"tty":               decodeInto(func(s *Service) any { return &s.Tty }),
+	"init":              decodeInto(func(s *Service) any { return &s.Init }),
+	"entrypoint":        parseEntrypoint,
+	"command":           parseCommand,
+	"environment":       parseEnvironment,
+	"labels":            parseLabels,
+	"depends_on":        parseDependsOn,
+	"restart":           decodeInto(func(s *Service) any { return &s.Restart }),
+	"stop_signal":       decodeInto(func(s *Service) any { return &s.StopSignal }),
+	"stop_grace_period": decodeInto(func(s *Service) any { return &s.StopGracePeriod }),
+	"healthcheck":       parseHealthCheck,
+	"network_mode":      decodeInto(func(s *Service) any { return &s.NetworkMode }),
+	"ports":             parsePorts,
+	"dns":               decodeStringsInto(func(s *Service) *[]string { return &s.DNS }),
+	"dns_opt":           decodeInto(func(s *Service) any { return &s.DNSOpt }),
+	"dns_search":        decodeStringsInto(func(s *Service) *[]string { return &s.DNSSearch }),
+	"extra_hosts":       parseExtraHosts,
+	"volumes":           parseVolumes,
+	"tmpfs":             parseTmpfs,
+	"devices":           parseDevices,
+	"read_only":         decodeInto(func(s *Service) any { return &s.ReadOnly }),
+	"privileged":        decodeInto(func(s *Service) any { return &s.Privileged }),
+	"cap_add":           decodeInto(func(s *Service) any { return &s.CapAdd }),
+	"cap_drop":          decodeInto(func(s *Service) any { return &s.CapDrop }),
+	"sysctls":           parseSysctls,
+	"pid":               decodeInto(func(s *Service) any { return &s.Pid }),
+	"ipc":               decodeInto(func(s *Service) any { return &s.Ipc }),
+	"shm_size":          decodeSizeInto(func(s *Service) *int64 { return &s.ShmSize }),
+	"mem_limit":         decodeSizeInto(func(s *Service) *int64 { return &s.MemLimit }),
+	"mem_reservation":   decodeSizeInto(func(s *Service) *int64 { return &s.MemReservation }),
+	"memswap_limit":     decodeSizeInto(func(s *Service) *int64 { return &s.MemSwapLimit }),
+	"cpus":              parseCPUs,
+	"cpu_shares":        decodeInto(func(s *Service) any { return &s.CPUShares }),
+	"cpuset":            decodeInto(func(s *Service) any { return &s.CPUSet }),
+	"pids_limit":        decodeInto(func(s *Service) any { return &s.PidsLimit }),
+	"blkio_config":      decodeInto(func(s *Service) any { return &s.BlkioConfig }),
+	"oom_kill_disable":  decodeInto(func(s *Service) any { return &s.OomKillDisable }),
+	"oom_score_adj":     decodeInto(func(s *Service) any { return &s.OomScoreAdj }),
+	"ulimits":           parseUlimits,
+	"logging":           decodeInto(func(s *Service) any { return &s.Logging }),
+}
+
+type parser struct {
+	project     *Project
+	unsupported []string
+}
+
+// Parse parses the compose document, returning the project along with
+// the paths of the keys within the document which are not supported
+// and were ignored. The variable interpolation within the document is
+// translated to the config env variable references, and the relative
+// sources of the bind mounts are resolved against dir, which is
+// expected to be the directory containing the compose document.
+func Parse(r io.Reader, dir string) (*Project, []string, error) {
+	doc := yaml.Node{}
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("compose document is empty")
+		}
+		return nil, nil, fmt.Errorf("failed to parse the compose document, reason: %w", err)
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("line %d: compose document must contain a mapping at the top level", root.Line)
+	}
+
+	in := newInterpolator()
+	in.node(root)
+
+	p := &parser{project: NewProject()}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, val := root.Content[i].Value, root.Content[i+1]
+		var err error
+		switch key {
+		case "version", "name":
+			// The version is obsolete as per the compose specification and
+			// the project name has no equivalent in the homelab config.
+		case "services":
+			err = p.parseServices(val)
+		case "networks":
+			err = p.parseNetworks(val)
+		default:
+			p.unsupported = append(p.unsupported, key)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	p.resolveBindSources(dir)
+	if len(in.variables) > 0 {
+		p.project.Variables = sortedKeys(in.variables)
+	}
+	p.project.UnsetOnlyInterpolations = in.unsetOnly
+	p.project.UnsupportedInterpolations = in.unsupported
+	return p.project, p.unsupported, nil
+}
+
+// resolveBindSources resolves the relative sources of the bind mounts
+// against the specified directory the way compose does.
+func (p *parser) resolveBindSources(dir string) {
+	for _, svc := range p.project.Services {
+		for _, v := range svc.Volumes {
+			if v.Type != "bind" || filepath.IsAbs(v.Source) || strings.HasPrefix(v.Source, "~") || strings.HasPrefix(v.Source, "$$") {
+				continue
+			}
+			v.Source = filepath.Join(dir, v.Source)
+		}
+	}
+}
+
+func (p *parser) parseServices(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: services must be a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, val := node.Content[i].Value, node.Content[i+1]
+		svc, err := p.parseService(name, val)
+		if err != nil {
+			return err
+		}
+		p.project.Services[name] = svc
+	}
+	return nil
+}
+
+func (p *parser) parseService(name string, node *yaml.Node) (*Service, error) {
+	svc := &Service{}
+	if isNull(node) {
+		return svc, nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: service %s must be a mapping", node.Line, name)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, val := node.Content[i].Value, node.Content[i+1]
+		path := fmt.Sprintf("services.%s.%s", name, key)
+		if key == "networks" {
+			if err := p.parseServiceNetworks(svc, path, val); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		parse, found := serviceKeyParsers[key]
+		if !found {
+			p.unsupported = append(p.unsupported, path)
+			continue
+		}
+		if isNull(val) {
+			continue
+		}
+		if err := parse(svc, val); err != nil {
+			return nil, fmt.Errorf("invalid value for %s, reason: %w", path, err)
+		}
+	}
+	return svc, nil
+}
+
+func (p *parser) parseServiceNetworks(svc *Service, path string, node *yaml.Node) error {
+	svc.Networks = make(map[string]*ServiceNetwork)
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var names []string
+		if err := node.Decode(&names); err != nil {
+			return fmt.Errorf("invalid value for %s, reason: %w", path, err)
+		}
+		for _, n := range names {
+			svc.Networks[n] = &ServiceNetwork{}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, val := node.Content[i].Value, node.Content[i+1]
+			sn := &ServiceNetwork{}
+			svc.Networks[name] = sn
+			if isNull(val) {
+				continue
+			}
+			if val.Kind != yaml.MappingNode {
+				return fmt.Errorf("line %d: invalid value for %s.%s, must be a mapping", val.Line, path, name)
+			}
+			for j := 0; j+1 < len(val.Content); j += 2 {
+				key, v := val.Content[j].Value, val.Content[j+1]
+				var err error
+				switch key {
+				case "ipv4_address":
+					err = v.Decode(&sn.IPv4Address)
+				case "ipv6_address":
+					err = v.Decode(&sn.IPv6Address)
+				case "priority":
+					err = v.Decode(&sn.Priority)
+				default:
+					p.unsupported = append(p.unsupported, fmt.Sprintf("%s.%s.%s", path, name, key))
+				}
+				if err != nil {
+					return fmt.Errorf("invalid value for %s.%s.%s, reason: %w", path, name, key, err)
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("line %d: invalid value for %s, must be a sequence or a mapping", node.Line, path)
+	}
+	return nil
+}
+
+func (p *parser) parseNetworks(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: networks must be a mapping", node.Line)
+	}
+	p.project.Networks = make(map[string]*Network)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, val := node.Content[i].Value, node.Content[i+1]
+		n := &Network{}
+		p.project.Networks[name] = n
+		if isNull(val) {
+			continue
+		}
+		if val.Kind != yaml.MappingNode {
+			return fmt.Errorf("line %d: network %s must be a mapping", val.Line, name)
+		}
+		for j := 0; j+1 < len(val.Content); j += 2 {
+			key, v := val.Content[j].Value, val.Content[j+1]
+			path := fmt.Sprintf("networks.%s.%s", name, key)
+			var err error
+			switch key {
+			case "name":
+				err = v.Decode(&n.Name)
+			case "driver":
+				err = v.Decode(&n.Driver)
+			case "driver_opts":
+				err = v.Decode(&n.DriverOpts)
+			case "enable_ipv6":
+				err = v.Decode(&n.EnableIPv6)
+			case "ipam":
+				err = v.Decode(&n.IPAM)
+			default:
+				p.unsupported = append(p.unsupported, path)
+			}
+			if err != nil {
+				return fmt.Errorf("invalid value for %s, reason: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+func decodeInto(field func(*Service) any) serviceKeyParser {
+	return func(svc *Service, node *yaml.Node) error {
+		return node.Decode(field(svc))
+	}
+}
+
+func decodeStringsInto(field func(*Service) *[]string) serviceKeyParser {
+	return func(svc *Service, node *yaml.Node) error {
+		vals, err := decodeStrings(node)
+		if err != nil {
+			return err
+		}
+		*field(svc) = vals
+		return nil
+	}
+}
+
+func decodeSizeInto(field func(*Service) *int64) serviceKeyParser {
+	return func(svc *Service, node *yaml.Node) error {
+		size, err := decodeSize(node)
+		if err != nil {
+			return err
+		}
+		*field(svc) = size
+		return nil
+	}
+}
+
+func parseEntrypoint(svc *Service, node *yaml.Node) error {
+	cmd, err := decodeCommand(node)
+	svc.Entrypoint = cmd
+	return err
+}
+
+func parseCommand(svc *Service, node *yaml.Node) error {
+	cmd, err := decodeCommand(node)
+	svc.Command = cmd
+	return err
+}
+
+func parseEnvironment(svc *Service, node *yaml.Node) error {
+	env, err := decodeListOrMap(node, "=")
+	if err != nil {
+		return err
+	}
+	svc.Environment = env
+	return nil
+}
+
+func parseLabels(svc *Service, node *yaml.Node) error {
+	labels, err := decodeListOrMap(node, "=")
+	if err != nil {
+		return err
+	}
+	svc.Labels = listToMap(labels, "=")
+	return nil
+}
+
+func parseSysctls(svc *Service, node *yaml.Node) error {
+	sysctls, err := decodeListOrMap(node, "=")
+	if err != nil {
+		return err
+	}
+	svc.Sysctls = listToMap(sysctls, "=")
+	return nil
+}
+
+func parseExtraHosts(svc *Service, node *yaml.Node) error {
+	hosts, err := decodeListOrMap(node, ":")
+	svc.ExtraHosts = hosts
+	return err
+}
+
+func parseDependsOn(svc *Service, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return node.Decode(&svc.DependsOn)
+	case yaml.MappingNode:
+		// The conditions of the dependencies are ignored.
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			svc.DependsOn = append(svc.DependsOn, node.Content[i].Value)
+		}
+		return nil
+	}
+	return fmt.Errorf("line %d: must be a sequence or a mapping", node.Line)
+}
+
+func parseHealthCheck(svc *Service, node *yaml.Node) error {
+	raw := struct {
+		Test          yaml.Node `yaml:"test"`
+		Interval      string    `yaml:"interval"`
+		Timeout       string    `yaml:"timeout"`
+		StartPeriod   string    `yaml:"start_period"`
+		StartInterval string    `yaml:"start_interval"`
+		Retries       int       `yaml:"retries"`
+		Disable       bool      `yaml:"disable"`
+	}{}
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+
+	h := &HealthCheck{
+		Interval:      raw.Interval,
+		Timeout:       raw.Timeout,
+		StartPeriod:   raw.StartPeriod,
+		StartInterval: raw.StartInterval,
+		Retries:       raw.Retries,
+	}
+	switch {
+	case raw.Disable:
+		h.Test = []string{"NONE"}
+	case raw.Test.Kind == yaml.ScalarNode:
+		h.Test = []string{"CMD-SHELL", raw.Test.Value}
+	case raw.Test.Kind == yaml.SequenceNode:
+		var test []string
+		if err := raw.Test.Decode(&test); err != nil {
+			return err
+		}
+		h.Test = test
+	}
+	svc.HealthCheck = h
+	return nil
+}
+
+func parsePorts(svc *Service, node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: must be a sequence", node.Line)
+	}
+	for _, item := range node.Content {
+		if item.Kind == yaml.ScalarNode {
+			svc.Ports = append(svc.Ports, item.Value)
+			continue
+		}
+
+		// Translate the long syntax to the short syntax.
+		raw := struct {
+			Target    string `yaml:"target"`
+			Published string `yaml:"published"`
+			HostIP    string `yaml:"host_ip"`
+			Protocol  string `yaml:"protocol"`
+		}{}
+		if err := item.Decode(&raw); err != nil {
+			return err
+		}
+		port := raw.Target
+		if len(raw.Protocol) > 0 {
+			port = fmt.Sprintf("%s/%s", port, raw.Protocol)
+		}
+		if len(raw.Published) > 0 {
+			port = fmt.Sprintf("%s:%s", raw.Published, port)
+		}
+		if len(raw.HostIP) > 0 {
+			hostIP := raw.HostIP
+			if strings.Contains(hostIP, ":") {
+				hostIP = fmt.Sprintf("[%s]", hostIP)
+			}
+			if len(raw.Published) == 0 {
+				port = fmt.Sprintf(":%s", port)
+			}
+			port = fmt.Sprintf("%s:%s", hostIP, port)
+		}
+		svc.Ports = append(svc.Ports, port)
+	}
+	return nil
+}
+
+func parseVolumes(svc *Service, node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: must be a sequence", node.Line)
+	}
+	for _, item := range node.Content {
+		if item.Kind == yaml.ScalarNode {
+			svc.Volumes = append(svc.Volumes, shortSyntaxVolume(item.Value))
+			continue
+		}
+
+		raw := struct {
+			Type     string `yaml:"type"`
+			Source   string `yaml:"source"`
+			Target   string `yaml:"target"`
+			ReadOnly bool   `yaml:"read_only"`
+			Tmpfs    struct {
+				Size yaml.Node `yaml:"size"`
+			} `yaml:"tmpfs"`
+		}{}
+		if err := item.Decode(&raw); err != nil {
+			return err
+		}
+		v := &Volume{
+			Type:     raw.Type,
+			Source:   raw.Source,
+			Target:   raw.Target,
+			ReadOnly: raw.ReadOnly,
+		}
+		if raw.Tmpfs.Size.Kind == yaml.ScalarNode {
+			size, err := decodeSize(&raw.Tmpfs.Size)
+			if err != nil {
+				return err
+			}
+			v.Tmpfs = &TmpfsVolume{Size: size}
+		}
+		svc.Volumes = append(svc.Volumes, v)
+	}
+	return nil
+}
+
+func parseTmpfs(svc *Service, node *yaml.Node) error {
+	targets, err := decodeStrings(node)
+	if err != nil {
+		return err
+	}
+	for _, t := range targets {
+		// The mount options are ignored.
+		target, _, _ := strings.Cut(t, ":")
+		svc.Volumes = append(svc.Volumes, &Volume{Type: "tmpfs", Target: target})
+	}
+	return nil
+}
+
+func parseDevices(svc *Service, node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: must be a sequence", node.Line)
+	}
+	for _, item := range node.Content {
+		if item.Kind == yaml.ScalarNode {
+			svc.Devices = append(svc.Devices, item.Value)
+			continue
+		}
+
+		raw := struct {
+			Source      string `yaml:"source"`
+			Target      string `yaml:"target"`
+			Permissions string `yaml:"permissions"`
+		}{}
+		if err := item.Decode(&raw); err != nil {
+			return err
+		}
+		svc.Devices = append(svc.Devices, strings.TrimRight(fmt.Sprintf("%s:%s:%s", raw.Source, raw.Target, raw.Permissions), ":"))
+	}
+	return nil
+}
+
+func parseCPUs(svc *Service, node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: must be a number", node.Line)
+	}
+	cpus, err := strconv.ParseFloat(node.Value, 64)
+	if err != nil {
+		return err
+	}
+	svc.CPUs = cpus
+	return nil
+}
+
+func parseUlimits(svc *Service, node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: must be a mapping", node.Line)
+	}
+	svc.Ulimits = make(map[string]*Ulimit)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, val := node.Content[i].Value, node.Content[i+1]
+		u := &Ulimit{}
+		if val.Kind == yaml.ScalarNode {
+			if err := val.Decode(&u.Soft); err != nil {
+				return err
+			}
+			u.Hard = u.Soft
+		} else if err := val.Decode(u); err != nil {
+			return err
+		}
+		svc.Ulimits[name] = u
+	}
+	return nil
+}
+
+// shortSyntaxVolume parses the volume specified in the short syntax,
+// i.e. [src:]dst[:mode].
+func shortSyntaxVolume(spec string) *Volume {
+	parts := strings.Split(spec, ":")
+	if len(parts) == 1 {
+		return &Volume{Type: "volume", Target: parts[0]}
+	}
+
+	v := &Volume{Source: parts[0], Target: parts[1]}
+	if len(parts) > 2 {
+		for _, opt := range strings.Split(parts[2], ",") {
+			if opt == "ro" {
+				v.ReadOnly = true
+			}
+		}
+	}
+	// The sources beginning with a config env variable reference were
+	// interpolated paths within the compose document.
+	if strings.HasPrefix(v.Source, "/") || strings.HasPrefix(v.Source, ".") || strings.HasPrefix(v.Source, "~") || strings.HasPrefix(v.Source, "$$") {
+		v.Type = "bind"
+	} else {
+		v.Type = "volume"
+	}
+	return v
+}
+
+func decodeStrings(node *yaml.Node) ([]string, error) {
+	if node.Kind == yaml.ScalarNode {
+		return []string{node.Value}, nil
+	}
+	var res []string
+	if err := node.Decode(&res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// decodeCommand decodes the command specified either as a list or as a
+// string which is split into the arguments the way a shell would.
+func decodeCommand(node *yaml.Node) ([]string, error) {
+	if node.Kind == yaml.ScalarNode {
+		args, err := splitCommand(node.Value)
+		if err != nil {
+			return nil, err
+		}
+		return args, nil
+	}
+	var res []string
+	if err := node.Decode(&res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// decodeListOrMap decodes the key value pairs specified either as a list
+// of strings or as a mapping, into a list of strings where the keys and
+// the values are separated by the specified separator. Keys without a
+// value are retained as is.
+func decodeListOrMap(node *yaml.Node, sep string) ([]string, error) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var res []string
+		if err := node.Decode(&res); err != nil {
+			return nil, err
+		}
+		return res, nil
+	case yaml.MappingNode:
+		var res []string
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, val := node.Content[i].Value, node.Content[i+1]
+			if isNull(val) {
+				res = append(res, key)
+				continue
+			}
+			if val.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: value of %s must be a scalar", val.Line, key)
+			}
+			res = append(res, fmt.Sprintf("%s%s%s", key, sep, val.Value))
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("line %d: must be a sequence or a mapping", node.Line)
+}
+
+func decodeSize(node *yaml.Node) (int64, error) {
+	if node.Kind != yaml.ScalarNode {
+		return 0, fmt.Errorf("line %d: must be a size", node.Line)
+	}
+	if node.Value == "-1" {
+		return -1, nil
+	}
+	return units.RAMInBytes(node.Value)
+}
+
+func listToMap(list []string, sep string) map[string]string {
+	res := make(map[string]string, len(list))
+	for _, item := range list {
+		k, v, _ := strings.Cut(item, sep)
+		res[k] = v
+	}
+	return res
+}
+
+// splitCommand splits the command into its arguments, honoring the
+// single and double quotes along with the backslash escapes.
+func splitCommand(cmd string) ([]string, error) {
+	var res []string
+	var cur strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+	for _, r := range cmd {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				res = append(res, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in command %q", cmd)
+	}
+	if inArg {
+		res = append(res, cur.String())
+	}
+	return res, nil
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
@@ -0,0 +1,352 @@
+package compose
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tuxgal/homelab/internal/testhelpers"
+)
+
+var parseTests = []struct {
+	name            string
+	doc             string
+	want            *Project
+	wantUnsupported []string
+}{
+	{
+		name: "Parse() - Short Syntax",
+		doc: `
+version: "3.8"
+services:
+  web:
+    image: nginx
+    command: nginx -g "daemon off;"
+    environment:
+      - TZ=UTC
+      - SECRET=pa$$word
+    labels:
+      - app=web
+    dns: 1.1.1.1
+    depends_on:
+      - db
+    ports:
+      - 127.0.0.1:8080:80
+      - 53:53/udp
+    volumes:
+      - /srv/html:/html:ro
+      - data:/data
+    tmpfs: /cache
+    devices:
+      - /dev/dri
+    networks:
+      - net1
+    mem_limit: 256m
+    cpus: "1.5"
+    ulimits:
+      nproc: 512
+`,
+		want: &Project{
+			Services: map[string]*Service{
+				"web": {
+					Image:       "nginx",
+					Command:     []string{"nginx", "-g", "daemon off;"},
+					Environment: []string{"TZ=UTC", "SECRET=pa$word"},
+					Labels: map[string]string{
+						"app": "web",
+					},
+					DNS:       []string{"1.1.1.1"},
+					DependsOn: []string{"db"},
+					Ports:     []string{"127.0.0.1:8080:80", "53:53/udp"},
+					Volumes: []*Volume{
+						{
+							Type:     "bind",
+							Source:   "/srv/html",
+							Target:   "/html",
+							ReadOnly: true,
+						},
+						{
+							Type:   "volume",
+							Source: "data",
+							Target: "/data",
+						},
+						{
+							Type:   "tmpfs",
+							Target: "/cache",
+						},
+					},
+					Devices: []string{"/dev/dri"},
+					Networks: map[string]*ServiceNetwork{
+						"net1": {},
+					},
+					MemLimit: 268435456,
+					CPUs:     1.5,
+					Ulimits: map[string]*Ulimit{
+						"nproc": {
+							Soft: 512,
+							Hard: 512,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name: "Parse() - Long Syntax",
+		doc: `
+services:
+  web:
+    image: nginx
+    entrypoint:
+      - /entrypoint.sh
+    environment:
+      TZ: UTC
+      EMPTY:
+    labels:
+      app: web
+    depends_on:
+      db:
+        condition: service_healthy
+    healthcheck:
+      test:
+        - CMD
+        - curl
+        - http://localhost
+      interval: 10s
+      retries: 2
+    ports:
+      - target: 80
+        published: 8080
+        host_ip: 127.0.0.1
+        protocol: tcp
+    volumes:
+      - type: tmpfs
+        target: /cache
+        tmpfs:
+          size: 1k
+    networks:
+      net1:
+        ipv4_address: 172.20.0.10
+        aliases:
+          - www
+    sysctls:
+      net.core.somaxconn: 1024
+    logging:
+      driver: json-file
+      options:
+        max-size: 10m
+    secrets:
+      - foo
+networks:
+  net1:
+    driver_opts:
+      com.docker.network.bridge.name: br-net1
+    ipam:
+      config:
+        - subnet: 172.20.0.0/24
+    external: true
+x-custom: foo
+`,
+		want: &Project{
+			Services: map[string]*Service{
+				"web": {
+					Image:       "nginx",
+					Entrypoint:  []string{"/entrypoint.sh"},
+					Environment: []string{"TZ=UTC", "EMPTY"},
+					Labels: map[string]string{
+						"app": "web",
+					},
+					DependsOn: []string{"db"},
+					HealthCheck: &HealthCheck{
+						Test:     []string{"CMD", "curl", "http://localhost"},
+						Interval: "10s",
+						Retries:  2,
+					},
+					Ports: []string{"127.0.0.1:8080:80/tcp"},
+					Volumes: []*Volume{
+						{
+							Type:   "tmpfs",
+							Target: "/cache",
+							Tmpfs: &TmpfsVolume{
+								Size: 1024,
+							},
+						},
+					},
+					Networks: map[string]*ServiceNetwork{
+						"net1": {
+							IPv4Address: "172.20.0.10",
+						},
+					},
+					Sysctls: map[string]string{
+						"net.core.somaxconn": "1024",
+					},
+					Logging: &Logging{
+						Driver: "json-file",
+						Options: map[string]string{
+							"max-size": "10m",
+						},
+					},
+				},
+			},
+			Networks: map[string]*Network{
+				"net1": {
+					DriverOpts: map[string]string{
+						"com.docker.network.bridge.name": "br-net1",
+					},
+					IPAM: &IPAM{
+						Config: []*IPAMConfig{
+							{
+								Subnet: "172.20.0.0/24",
+							},
+						},
+					},
+				},
+			},
+		},
+		wantUnsupported: []string{
+			"services.web.networks.net1.aliases",
+			"services.web.secrets",
+			"networks.net1.external",
+			"x-custom",
+		},
+	},
+	{
+		name: "Parse() - Interpolation And Relative Binds",
+		doc: `
+services:
+  web:
+    image: nginx:${TAG:-latest}
+    command: echo ${GREETING?required} $$HOME
+    environment:
+      PASSWORD: ${DB_PASS}
+      USER: $DB_USER-admin
+      LITERAL: $$$$
+      ALT: ${FOO:+bar}
+    volumes:
+      - ./data:/data
+      - ${DATA_DIR}/cache:/cache
+      - logs:/logs
+`,
+		want: &Project{
+			Services: map[string]*Service{
+				"web": {
+					Image:   "nginx:$$TAG:-latest$$",
+					Command: []string{"echo", "$$GREETING:?required$$", "$HOME"},
+					Environment: []string{
+						"PASSWORD=$$DB_PASS$$",
+						"USER=$$DB_USER$$-admin",
+						`LITERAL=\$$`,
+						"ALT=${FOO:+bar}",
+					},
+					Volumes: []*Volume{
+						{
+							Type:   "bind",
+							Source: "/srv/compose/data",
+							Target: "/data",
+						},
+						{
+							Type:   "bind",
+							Source: "$$DATA_DIR$$/cache",
+							Target: "/cache",
+						},
+						{
+							Type:   "volume",
+							Source: "logs",
+							Target: "/logs",
+						},
+					},
+				},
+			},
+			Variables:                 []string{"DATA_DIR", "DB_PASS", "DB_USER", "GREETING", "TAG"},
+			UnsetOnlyInterpolations:   []string{"${GREETING?required}"},
+			UnsupportedInterpolations: []string{"${FOO:+bar}"},
+		},
+	},
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range parseTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotUnsupported, gotErr := Parse(strings.NewReader(tc.doc), "/srv/compose")
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "Parse()", tc.name, gotErr)
+				return
+			}
+
+			testhelpers.CmpDiff(t, "Parse()", tc.name, "project", tc.want, got)
+			testhelpers.CmpDiff(t, "Parse()", tc.name, "unsupported keys", tc.wantUnsupported, gotUnsupported)
+		})
+	}
+}
+
+var parseErrorTests = []struct {
+	name string
+	doc  string
+	want string
+}{
+	{
+		name: "Parse() - Empty Document",
+		doc:  ``,
+		want: `compose document is empty`,
+	},
+	{
+		name: "Parse() - Invalid YAML",
+		doc:  `services: [`,
+		want: `failed to parse the compose document, reason: yaml: line 1: did not find expected node content`,
+	},
+	{
+		name: "Parse() - Top Level Not A Mapping",
+		doc:  `- foo`,
+		want: `line 1: compose document must contain a mapping at the top level`,
+	},
+	{
+		name: "Parse() - Invalid Service Value",
+		doc: `
+services:
+  web:
+    ports: 80
+`,
+		want: `invalid value for services\.web\.ports, reason: line 4: must be a sequence`,
+	},
+	{
+		name: "Parse() - Unterminated Command Quote",
+		doc: `
+services:
+  web:
+    command: echo "foo
+`,
+		want: `invalid value for services\.web\.command, reason: unterminated quote or escape in command "echo \\"foo"`,
+	},
+	{
+		name: "Parse() - Invalid Size",
+		doc: `
+services:
+  web:
+    mem_limit: lots
+`,
+		want: `invalid value for services\.web\.mem_limit, reason: invalid size: 'lots'`,
+	},
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range parseErrorTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, gotErr := Parse(strings.NewReader(tc.doc), "/srv/compose")
+			if gotErr == nil {
+				testhelpers.LogErrorNil(t, "Parse()", tc.name, tc.want)
+				return
+			}
+
+			testhelpers.RegexMatch(t, "Parse()", tc.name, "gotErr error string", tc.want, gotErr.Error())
+		})
+	}
+}
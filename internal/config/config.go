@@ -20,6 +20,7 @@ type Homelab struct {
 	Hosts      []Host           `yaml:"hosts,omitempty" json:"hosts,omitempty"`
 	Groups     []ContainerGroup `yaml:"groups,omitempty" json:"groups,omitempty"`
 	Containers []Container      `yaml:"containers,omitempty" json:"containers,omitempty"`
+	Secrets    []Secret         `yaml:"secrets,omitempty" json:"secrets,omitempty"`
 	Ignore     []IgnoredConfig  `yaml:"ignore,omitempty" json:"ignore,omitempty"`
 }
 
@@ -67,34 +68,49 @@ type ContainerModeNetworkNameOnly struct {
 }
 
 // Global represents the configuration that will be applied
-// across the entire homelab deployment.
+// across the entire homelab deployment. The config env read from the
+// EnvFiles (dotenv files) overrides the config env in Env, and
+// ProcessEnvAllowlist lists the process environment variables that
+// can be read by the config env using valueFromEnv. Relative paths of
+// the EnvFiles are resolved against the configs dir.
 type Global struct {
-	BaseDir   string          `yaml:"baseDir,omitempty" json:"baseDir,omitempty"`
-	Env       []ConfigEnv     `yaml:"env,omitempty" json:"env,omitempty"`
-	MountDefs []Mount         `yaml:"mountDefs,omitempty" json:"mountDefs,omitempty"`
-	Container GlobalContainer `yaml:"container,omitempty" json:"container,omitempty"`
+	BaseDir             string          `yaml:"baseDir,omitempty" json:"baseDir,omitempty" configenv:"-"`
+	Env                 []ConfigEnv     `yaml:"env,omitempty" json:"env,omitempty" configenv:"-"`
+	EnvFiles            []string        `yaml:"envFiles,omitempty" json:"envFiles,omitempty" configenv:"-"`
+	ProcessEnvAllowlist []string        `yaml:"processEnvAllowlist,omitempty" json:"processEnvAllowlist,omitempty" configenv:"-"`
+	MountDefs           []Mount         `yaml:"mountDefs,omitempty" json:"mountDefs,omitempty"`
+	Container           GlobalContainer `yaml:"container,omitempty" json:"container,omitempty"`
 }
 
 // GlobalContainer represents container related configuration that
 // will be applied globally across all containers.
 type GlobalContainer struct {
-	StopSignal    string                 `yaml:"stopSignal,omitempty" json:"stopSignal,omitempty"`
-	StopTimeout   int                    `yaml:"stopTimeout,omitempty" json:"stopTimeout,omitempty"`
-	RestartPolicy ContainerRestartPolicy `yaml:"restartPolicy,omitempty" json:"restartPolicy,omitempty"`
-	DomainName    string                 `yaml:"domainName,omitempty" json:"domainName,omitempty"`
-	DNSSearch     []string               `yaml:"dnsSearch,omitempty" json:"dnsSearch,omitempty"`
-	Env           []ContainerEnv         `yaml:"env,omitempty" json:"env,omitempty"`
-	Mounts        []Mount                `yaml:"mounts,omitempty" json:"mounts,omitempty"`
-	Labels        []Label                `yaml:"labels,omitempty" json:"labels,omitempty"`
+	StopSignal       string                 `yaml:"stopSignal,omitempty" json:"stopSignal,omitempty"`
+	StopTimeout      int                    `yaml:"stopTimeout,omitempty" json:"stopTimeout,omitempty"`
+	PurgeGracePeriod int                    `yaml:"purgeGracePeriod,omitempty" json:"purgeGracePeriod,omitempty"`
+	RestartPolicy    ContainerRestartPolicy `yaml:"restartPolicy,omitempty" json:"restartPolicy,omitempty"`
+	DomainName       string                 `yaml:"domainName,omitempty" json:"domainName,omitempty"`
+	DNSSearch        []string               `yaml:"dnsSearch,omitempty" json:"dnsSearch,omitempty"`
+	Env              []ContainerEnv         `yaml:"env,omitempty" json:"env,omitempty"`
+	Mounts           []Mount                `yaml:"mounts,omitempty" json:"mounts,omitempty"`
+	Labels           []Label                `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Resources        ContainerResources     `yaml:"resources,omitempty" json:"resources,omitempty"`
+	Logging          ContainerLogging       `yaml:"logging,omitempty" json:"logging,omitempty"`
 }
 
 // ConfigEnv is a pair of environment variable name and value that will be
 // substituted in all string field values read from the homelab
-// configuration file.
+// configuration file, except the fields tagged with `configenv:"-"`.
+// The value is either specified inline, or read from the output of a
+// command, the (trimmed) contents of a file (whose relative path is
+// resolved against the configs dir) or an allowlisted process
+// environment variable.
 type ConfigEnv struct {
 	Var          string   `yaml:"var,omitempty" json:"var,omitempty"`
 	Value        string   `yaml:"value,omitempty" json:"value,omitempty"`
 	ValueCommand []string `yaml:"valueCommand,omitempty" json:"valueCommand,omitempty"`
+	ValueFile    string   `yaml:"valueFile,omitempty" json:"valueFile,omitempty"`
+	ValueFromEnv string   `yaml:"valueFromEnv,omitempty" json:"valueFromEnv,omitempty"`
 }
 
 // IPAM represents the IP Addressing and management information for
@@ -172,8 +188,8 @@ type ContainerGroupNameOnly struct {
 
 // Container represents a single docker container.
 type Container struct {
-	Info       ContainerReference     `yaml:"info,omitempty" json:"info,omitempty"`
-	Config     ContainerConfigOptions `yaml:"config,omitempty" json:"config,omitempty"`
+	Info       ContainerReference     `yaml:"info,omitempty" json:"info,omitempty" configenv:"-"`
+	Config     ContainerConfigOptions `yaml:"config,omitempty" json:"config,omitempty" configenv:"-"`
 	Image      ContainerImage         `yaml:"image,omitempty" json:"image,omitempty"`
 	Metadata   ContainerMetadata      `yaml:"metadata,omitempty" json:"metadata,omitempty"`
 	Lifecycle  ContainerLifecycle     `yaml:"lifecycle,omitempty" json:"lifecycle,omitempty"`
@@ -183,6 +199,9 @@ type Container struct {
 	Security   ContainerSecurity      `yaml:"security,omitempty" json:"security,omitempty"`
 	Health     ContainerHealth        `yaml:"health,omitempty" json:"health,omitempty"`
 	Runtime    ContainerRuntime       `yaml:"runtime,omitempty" json:"runtime,omitempty"`
+	Resources  ContainerResources     `yaml:"resources,omitempty" json:"resources,omitempty"`
+	Logging    ContainerLogging       `yaml:"logging,omitempty" json:"logging,omitempty"`
+	Secrets    []ContainerSecret      `yaml:"secrets,omitempty" json:"secrets,omitempty"`
 }
 
 // ContainerNameOnly represents a single docker container with just the
@@ -214,13 +233,17 @@ type ContainerMetadata struct {
 // ContainerLifecycle represents the lifecycle information for the
 // docker container.
 type ContainerLifecycle struct {
-	Order               int                    `yaml:"order,omitempty" json:"order,omitempty"`
-	StartPreHook        []string               `yaml:"startPreHook,omitempty" json:"startPreHook,omitempty"`
-	RestartPolicy       ContainerRestartPolicy `yaml:"restartPolicy,omitempty" json:"restartPolicy,omitempty"`
-	AutoRemove          bool                   `yaml:"autoRemove,omitempty" json:"autoRemove,omitempty"`
-	StopSignal          string                 `yaml:"stopSignal,omitempty" json:"stopSignal,omitempty"`
-	StopTimeout         int                    `yaml:"stopTimeout,omitempty" json:"stopTimeout,omitempty"`
-	WaitAfterStartDelay int                    `yaml:"waitAfterStartDelay,omitempty" json:"waitAfterStartDelay,omitempty"`
+	Order                 int                    `yaml:"order,omitempty" json:"order,omitempty"`
+	StartPreHook          []string               `yaml:"startPreHook,omitempty" json:"startPreHook,omitempty"`
+	RestartPolicy         ContainerRestartPolicy `yaml:"restartPolicy,omitempty" json:"restartPolicy,omitempty"`
+	AutoRemove            bool                   `yaml:"autoRemove,omitempty" json:"autoRemove,omitempty"`
+	StopSignal            string                 `yaml:"stopSignal,omitempty" json:"stopSignal,omitempty"`
+	StopTimeout           int                    `yaml:"stopTimeout,omitempty" json:"stopTimeout,omitempty"`
+	PurgeGracePeriod      int                    `yaml:"purgeGracePeriod,omitempty" json:"purgeGracePeriod,omitempty"`
+	WaitAfterStartDelay   int                    `yaml:"waitAfterStartDelay,omitempty" json:"waitAfterStartDelay,omitempty"`
+	WaitForHealthy        bool                   `yaml:"waitForHealthy,omitempty" json:"waitForHealthy,omitempty"`
+	WaitForHealthyTimeout int                    `yaml:"waitForHealthyTimeout,omitempty" json:"waitForHealthyTimeout,omitempty"`
+	DependsOn             []ContainerReference   `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty" configenv:"-"`
 }
 
 // ContainerUser represents the user and group information for the
@@ -258,6 +281,8 @@ type ContainerSecurity struct {
 	Sysctls    []Sysctl `yaml:"sysctls,omitempty" json:"sysctls,omitempty"`
 	CapAdd     []string `yaml:"capAdd,omitempty" json:"capAdd,omitempty"`
 	CapDrop    []string `yaml:"capDrop,omitempty" json:"capDrop,omitempty"`
+	PidMode    string   `yaml:"pidMode,omitempty" json:"pidMode,omitempty"`
+	IpcMode    string   `yaml:"ipcMode,omitempty" json:"ipcMode,omitempty"`
 }
 
 // ContainerHealth represents the health check options for the
@@ -274,11 +299,42 @@ type ContainerHealth struct {
 // ContainerRuntime represents the execution and runtime information
 // for the docker container.
 type ContainerRuntime struct {
-	AttachToTty bool           `yaml:"tty,omitempty" json:"tty,omitempty"`
-	ShmSize     string         `yaml:"shmSize,omitempty" json:"shmSize,omitempty"`
-	Env         []ContainerEnv `yaml:"env,omitempty" json:"env,omitempty"`
-	Entrypoint  []string       `yaml:"entrypoint,omitempty" json:"entrypoint,omitempty"`
-	Args        []string       `yaml:"args,omitempty" json:"args,omitempty"`
+	AttachToTty    bool           `yaml:"tty,omitempty" json:"tty,omitempty"`
+	ShmSize        string         `yaml:"shmSize,omitempty" json:"shmSize,omitempty"`
+	Env            []ContainerEnv `yaml:"env,omitempty" json:"env,omitempty"`
+	Entrypoint     []string       `yaml:"entrypoint,omitempty" json:"entrypoint,omitempty"`
+	Args           []string       `yaml:"args,omitempty" json:"args,omitempty"`
+	Init           *bool          `yaml:"init,omitempty" json:"init,omitempty"`
+	Ulimits        []Ulimit       `yaml:"ulimits,omitempty" json:"ulimits,omitempty"`
+	OOMScoreAdj    int            `yaml:"oomScoreAdj,omitempty" json:"oomScoreAdj,omitempty"`
+	OOMKillDisable bool           `yaml:"oomKillDisable,omitempty" json:"oomKillDisable,omitempty"`
+}
+
+// ContainerResources represents the resource limits for the docker
+// container.
+type ContainerResources struct {
+	Memory            string  `yaml:"memory,omitempty" json:"memory,omitempty"`
+	MemoryReservation string  `yaml:"memoryReservation,omitempty" json:"memoryReservation,omitempty"`
+	MemorySwap        string  `yaml:"memorySwap,omitempty" json:"memorySwap,omitempty"`
+	CPUs              float64 `yaml:"cpus,omitempty" json:"cpus,omitempty"`
+	CPUShares         int64   `yaml:"cpuShares,omitempty" json:"cpuShares,omitempty"`
+	CPUSet            string  `yaml:"cpuset,omitempty" json:"cpuset,omitempty"`
+	PidsLimit         int64   `yaml:"pidsLimit,omitempty" json:"pidsLimit,omitempty"`
+	BlkioWeight       uint16  `yaml:"blkioWeight,omitempty" json:"blkioWeight,omitempty"`
+}
+
+// ContainerLogging represents the logging driver and its options for the
+// docker container.
+type ContainerLogging struct {
+	Driver  string          `yaml:"driver,omitempty" json:"driver,omitempty"`
+	Options []LoggingOption `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+// LoggingOption represents an option passed to the logging driver of a
+// container.
+type LoggingOption struct {
+	Name  string `yaml:"name,omitempty" json:"name,omitempty"`
+	Value string `yaml:"value,omitempty" json:"value,omitempty"`
 }
 
 // Mount represents a filesystem mount.
@@ -295,7 +351,7 @@ type Mount struct {
 type ContainerDevice struct {
 	Static         []Device `yaml:"static,omitempty" json:"static,omitempty"`
 	DynamicCommand []string `yaml:"dynamic,omitempty" json:"dynamic,omitempty"`
-	Dynamic        []Device `yaml:"-" json:"-"`
+	Dynamic        []Device `yaml:"-" json:"-" configenv:"-"`
 }
 
 // Device represents a device node that will be exposed to a container.
@@ -307,6 +363,14 @@ type Device struct {
 	DisallowMknod bool   `yaml:"disallowMknod,omitempty" json:"disallowMknod,omitempty"`
 }
 
+// Ulimit represents a resource limit applied to the processes within a
+// container. The hard limit defaults to the soft limit when unset.
+type Ulimit struct {
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	Soft int64  `yaml:"soft,omitempty" json:"soft,omitempty"`
+	Hard int64  `yaml:"hard,omitempty" json:"hard,omitempty"`
+}
+
 // Sysctl represents a sysctl config to apply to a container.
 type Sysctl struct {
 	Key   string `yaml:"key,omitempty" json:"key,omitempty"`
@@ -334,6 +398,27 @@ type Label struct {
 	Value string `yaml:"value,omitempty" json:"value,omitempty"`
 }
 
+// Secret represents a secret whose value is read from exactly one of
+// a file, the output of a command or an allowlisted process environment
+// variable. The secret value is never part of the config, and instead
+// is written to a file that is bind mounted into the containers
+// referring to the secret. The global config env is substituted within
+// the secret, and a relative file path is resolved against the configs
+// dir.
+type Secret struct {
+	Name    string   `yaml:"name,omitempty" json:"name,omitempty"`
+	File    string   `yaml:"file,omitempty" json:"file,omitempty"`
+	Command []string `yaml:"command,omitempty" json:"command,omitempty"`
+	Env     string   `yaml:"env,omitempty" json:"env,omitempty"`
+}
+
+// ContainerSecret represents a secret mounted read-only into a
+// container at the target path, which defaults to /run/secrets/<name>.
+type ContainerSecret struct {
+	Name   string `yaml:"name,omitempty" json:"name,omitempty"`
+	Target string `yaml:"target,omitempty" json:"target,omitempty"`
+}
+
 // ContainerRestartPolicy represents the restart policy for the container.
 type ContainerRestartPolicy struct {
 	Mode          string `yaml:"mode,omitempty" json:"mode,omitempty"`
@@ -418,72 +503,16 @@ func (h *HomelabNetworksOnly) ListNetworks() []string {
 	return networks
 }
 
-func (g *Global) ApplyConfigEnv(env *env.ConfigEnvManager) {
-	for i, m := range g.MountDefs {
-		g.MountDefs[i].Src = env.Apply(m.Src)
-		g.MountDefs[i].Dst = env.Apply(m.Dst)
-	}
-	g.Container.DomainName = env.Apply(g.Container.DomainName)
-	for i, d := range g.Container.DNSSearch {
-		g.Container.DNSSearch[i] = env.Apply(d)
-	}
-	for i, e := range g.Container.Env {
-		g.Container.Env[i].Var = env.Apply(e.Var)
-		g.Container.Env[i].Value = env.Apply(e.Value)
-	}
-	for i, m := range g.Container.Mounts {
-		g.Container.Mounts[i].Src = env.Apply(m.Src)
-		g.Container.Mounts[i].Dst = env.Apply(m.Dst)
-	}
+func (g *Global) ApplyConfigEnv(env *env.ConfigEnvManager) error {
+	return applyConfigEnv(env, g)
 }
 
-func (c *Container) ApplyConfigEnv(env *env.ConfigEnvManager) {
-	for i, cmdArg := range c.Lifecycle.StartPreHook {
-		c.Lifecycle.StartPreHook[i] = env.Apply(cmdArg)
-	}
-	c.User.User = env.Apply(c.User.User)
-	c.User.PrimaryGroup = env.Apply(c.User.PrimaryGroup)
-	for i, g := range c.User.AdditionalGroups {
-		c.User.AdditionalGroups[i] = env.Apply(g)
-	}
-	for i, m := range c.Filesystem.Mounts {
-		c.Filesystem.Mounts[i].Src = env.Apply(m.Src)
-		c.Filesystem.Mounts[i].Dst = env.Apply(m.Dst)
-	}
-	for i, d := range c.Filesystem.Devices.Static {
-		c.Filesystem.Devices.Static[i].Src = env.Apply(d.Src)
-		c.Filesystem.Devices.Static[i].Dst = env.Apply(d.Dst)
-	}
-	for i, cmdArg := range c.Filesystem.Devices.DynamicCommand {
-		c.Filesystem.Devices.DynamicCommand[i] = env.Apply(cmdArg)
-	}
-	c.Network.HostName = env.Apply(c.Network.HostName)
-	c.Network.DomainName = env.Apply(c.Network.DomainName)
-	for i, d := range c.Network.DNSServers {
-		c.Network.DNSServers[i] = env.Apply(d)
-	}
-	for i, d := range c.Network.DNSOptions {
-		c.Network.DNSOptions[i] = env.Apply(d)
-	}
-	for i, d := range c.Network.DNSSearch {
-		c.Network.DNSSearch[i] = env.Apply(d)
-	}
-	for i, e := range c.Network.ExtraHosts {
-		c.Network.ExtraHosts[i] = env.Apply(e)
-	}
-	for i, p := range c.Network.PublishedPorts {
-		c.Network.PublishedPorts[i].ContainerPort = env.Apply(p.ContainerPort)
-		c.Network.PublishedPorts[i].Protocol = env.Apply(p.Protocol)
-		c.Network.PublishedPorts[i].HostIP = env.Apply(p.HostIP)
-		c.Network.PublishedPorts[i].HostPort = env.Apply(p.HostPort)
-	}
-	for i, e := range c.Runtime.Env {
-		c.Runtime.Env[i].Var = env.Apply(e.Var)
-		c.Runtime.Env[i].Value = env.Apply(e.Value)
-	}
-	for i, a := range c.Runtime.Args {
-		c.Runtime.Args[i] = env.Apply(a)
-	}
+func (c *Container) ApplyConfigEnv(env *env.ConfigEnvManager) error {
+	return applyConfigEnv(env, c)
+}
+
+func (s *Secret) ApplyConfigEnv(env *env.ConfigEnvManager) error {
+	return applyConfigEnv(env, s)
 }
 
 func (c *Container) ApplyCmdExecutor(exec cmdexec.Executor) error {
@@ -301,6 +301,147 @@ var applyConfigEnvToContainerTests = []struct {
 			},
 		},
 	},
+	{
+		name: "Container Config - ApplyConfigEnv - All String Fields",
+		container: Container{
+			Info: ContainerReference{
+				Group:     "g1",
+				Container: "c1-$$NOT_SUBSTITUTED$$",
+			},
+			Config: ContainerConfigOptions{
+				Env: []ConfigEnv{
+					{
+						Var:   "IMAGE_TAG",
+						Value: "$$NOT_SUBSTITUTED$$",
+					},
+				},
+			},
+			Image: ContainerImage{
+				Image: "foo/bar:$$IMAGE_TAG$$",
+			},
+			Metadata: ContainerMetadata{
+				Labels: []Label{
+					{
+						Name:  "traefik.http.routers.c1.rule",
+						Value: "Host(`c1.$$HOST_NAME$$`)",
+					},
+				},
+			},
+			Lifecycle: ContainerLifecycle{
+				Order:      1,
+				StopSignal: "$$STOP_SIGNAL:-SIGTERM$$",
+				DependsOn: []ContainerReference{
+					{
+						Group:     "g1",
+						Container: "$$NOT_SUBSTITUTED$$",
+					},
+				},
+			},
+			Security: ContainerSecurity{
+				Sysctls: []Sysctl{
+					{
+						Key:   "net.ipv4.conf.$$IFACE$$.forwarding",
+						Value: "1",
+					},
+				},
+			},
+			Health: ContainerHealth{
+				Cmd: []string{
+					"curl",
+					"http://$$HOST_IPV4$$:8080",
+				},
+			},
+			Runtime: ContainerRuntime{
+				Entrypoint: []string{
+					"$$CONTAINER_SCRIPTS_DIR$$/entrypoint.sh",
+				},
+			},
+			Logging: ContainerLogging{
+				Driver: "json-file",
+				Options: []LoggingOption{
+					{
+						Name:  "tag",
+						Value: "$$HOST_NAME$$-c1",
+					},
+				},
+			},
+		},
+		globalEnvMap: env.EnvMap{
+			"IFACE": "eth0",
+		},
+		globalEnvOrder: env.EnvOrder{
+			"IFACE",
+		},
+		containerEnvMap: env.EnvMap{
+			"IMAGE_TAG": "1.2.3",
+		},
+		containerEnvOrder: env.EnvOrder{
+			"IMAGE_TAG",
+		},
+		want: Container{
+			Info: ContainerReference{
+				Group:     "g1",
+				Container: "c1-$$NOT_SUBSTITUTED$$",
+			},
+			Config: ContainerConfigOptions{
+				Env: []ConfigEnv{
+					{
+						Var:   "IMAGE_TAG",
+						Value: "$$NOT_SUBSTITUTED$$",
+					},
+				},
+			},
+			Image: ContainerImage{
+				Image: "foo/bar:1.2.3",
+			},
+			Metadata: ContainerMetadata{
+				Labels: []Label{
+					{
+						Name:  "traefik.http.routers.c1.rule",
+						Value: "Host(`c1.fakehost`)",
+					},
+				},
+			},
+			Lifecycle: ContainerLifecycle{
+				Order:      1,
+				StopSignal: "SIGTERM",
+				DependsOn: []ContainerReference{
+					{
+						Group:     "g1",
+						Container: "$$NOT_SUBSTITUTED$$",
+					},
+				},
+			},
+			Security: ContainerSecurity{
+				Sysctls: []Sysctl{
+					{
+						Key:   "net.ipv4.conf.eth0.forwarding",
+						Value: "1",
+					},
+				},
+			},
+			Health: ContainerHealth{
+				Cmd: []string{
+					"curl",
+					"http://10.76.77.78:8080",
+				},
+			},
+			Runtime: ContainerRuntime{
+				Entrypoint: []string{
+					"/tmp/base-dir/g1/c1/scripts/entrypoint.sh",
+				},
+			},
+			Logging: ContainerLogging{
+				Driver: "json-file",
+				Options: []LoggingOption{
+					{
+						Name:  "tag",
+						Value: "fakehost-c1",
+					},
+				},
+			},
+		},
+	},
 }
 
 func TestApplyConfigEnvToContainer(t *testing.T) {
@@ -320,7 +461,10 @@ func TestApplyConfigEnvToContainer(t *testing.T) {
 			e = e.NewContainerConfigEnvManager(ctx, "/tmp/base-dir/g1", "/tmp/base-dir/g1/c1", tc.containerEnvMap, tc.containerEnvOrder)
 
 			got := deepcopy.MustCopy(tc.container)
-			got.ApplyConfigEnv(e)
+			if gotErr := got.ApplyConfigEnv(e); gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "Container.ApplyConfigEnv()", tc.name, gotErr)
+				return
+			}
 			if !testhelpers.CmpDiff(t, "Container.ApplyConfigEnv()", tc.name, "apply result", tc.want, got) {
 				return
 			}
@@ -328,6 +472,72 @@ func TestApplyConfigEnvToContainer(t *testing.T) {
 	}
 }
 
+var applyConfigEnvToContainerErrorTests = []struct {
+	name      string
+	container Container
+	want      string
+}{
+	{
+		name: "Container Config - ApplyConfigEnv - Undefined Variable",
+		container: Container{
+			Filesystem: ContainerFilesystem{
+				Mounts: []Mount{
+					{
+						Name: "mount-1",
+						Type: "bind",
+						Src:  "$$CONTAINR_DATA_DIR$$/foo",
+						Dst:  "/foo",
+					},
+				},
+			},
+		},
+		want: `config env substitution failed for fs\.mounts\[0\]\.src, reason: config env variable CONTAINR_DATA_DIR is not defined in "\$\$CONTAINR_DATA_DIR\$\$/foo"`,
+	},
+	{
+		name: "Container Config - ApplyConfigEnv - Required Variable Not Set",
+		container: Container{
+			Runtime: ContainerRuntime{
+				Env: []ContainerEnv{
+					{
+						Var:   "DB_PASSWORD",
+						Value: "$$DB_PASSWORD:?set the database password in the container config env$$",
+					},
+				},
+			},
+		},
+		want: `config env substitution failed for runtime\.env\[0\]\.value, reason: required config env variable DB_PASSWORD is not set: set the database password in the container config env in "\$\$DB_PASSWORD:\?set the database password in the container config env\$\$"`,
+	},
+}
+
+func TestApplyConfigEnvToContainerErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range applyConfigEnvToContainerErrorTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := testutils.NewCapturingTestLogger(tuxlog.LvlInfo, new(bytes.Buffer))
+			ctx := testutils.NewTestContext(&testutils.TestContextInfo{})
+			ctx = logger.WithLogger(ctx, l)
+
+			e := env.NewSystemConfigEnvManager(ctx)
+			e = e.NewContainerConfigEnvManager(ctx, "/tmp/base-dir/g1", "/tmp/base-dir/g1/c1", nil, nil)
+
+			got := deepcopy.MustCopy(tc.container)
+			gotErr := got.ApplyConfigEnv(e)
+			if gotErr == nil {
+				testhelpers.LogErrorNil(t, "Container.ApplyConfigEnv()", tc.name, tc.want)
+				return
+			}
+
+			if !testhelpers.RegexMatch(t, "Container.ApplyConfigEnv()", tc.name, "gotErr error string", tc.want, gotErr.Error()) {
+				return
+			}
+		})
+	}
+}
+
 var applyCmdExecutorToContainerTests = []struct {
 	name      string
 	container Container
@@ -976,6 +1186,67 @@ var applyConfigEnvToGlobalTests = []struct {
 			},
 		},
 	},
+	{
+		name: "Global Config - ApplyConfigEnv - All String Fields",
+		global: Global{
+			BaseDir: "$$NOT_SUBSTITUTED$$",
+			Env: []ConfigEnv{
+				{
+					Var:   "MOUNT_NAME",
+					Value: "$$NOT_SUBSTITUTED$$",
+				},
+			},
+			MountDefs: []Mount{
+				{
+					Name: "$$MOUNT_NAME$$",
+					Type: "bind",
+					Src:  "/foo",
+					Dst:  "/foo",
+				},
+			},
+			Container: GlobalContainer{
+				StopSignal: "$$STOP_SIGNAL:-SIGTERM$$",
+				Labels: []Label{
+					{
+						Name:  "homelab.host",
+						Value: "$$HOST_NAME$$",
+					},
+				},
+			},
+		},
+		globalEnvMap: env.EnvMap{
+			"MOUNT_NAME": "mount-1",
+		},
+		globalEnvOrder: env.EnvOrder{
+			"MOUNT_NAME",
+		},
+		want: Global{
+			BaseDir: "$$NOT_SUBSTITUTED$$",
+			Env: []ConfigEnv{
+				{
+					Var:   "MOUNT_NAME",
+					Value: "$$NOT_SUBSTITUTED$$",
+				},
+			},
+			MountDefs: []Mount{
+				{
+					Name: "mount-1",
+					Type: "bind",
+					Src:  "/foo",
+					Dst:  "/foo",
+				},
+			},
+			Container: GlobalContainer{
+				StopSignal: "SIGTERM",
+				Labels: []Label{
+					{
+						Name:  "homelab.host",
+						Value: "fakehost",
+					},
+				},
+			},
+		},
+	},
 }
 
 func TestApplyConfigEnvToGlobal(t *testing.T) {
@@ -994,10 +1265,162 @@ func TestApplyConfigEnvToGlobal(t *testing.T) {
 			e = e.NewGlobalConfigEnvManager(ctx, "/tmp/base-dir", tc.globalEnvMap, tc.globalEnvOrder)
 
 			got := deepcopy.MustCopy(tc.global)
-			got.ApplyConfigEnv(e)
+			if gotErr := got.ApplyConfigEnv(e); gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "Global.ApplyConfigEnv()", tc.name, gotErr)
+				return
+			}
 			if !testhelpers.CmpDiff(t, "Global.ApplyConfigEnv()", tc.name, "apply result", tc.want, got) {
 				return
 			}
 		})
 	}
 }
+
+var redactedTests = []struct {
+	name    string
+	config  *Homelab
+	secrets []string
+	want    *Homelab
+}{
+	{
+		name: "Homelab Config - Redacted",
+		config: &Homelab{
+			Global: Global{
+				Env: []ConfigEnv{
+					{
+						Var:   "DB_PASSWORD",
+						Value: "s3cr3t",
+					},
+				},
+			},
+			Containers: []Container{
+				{
+					Runtime: ContainerRuntime{
+						Env: []ContainerEnv{
+							{
+								Var:   "DB_URL",
+								Value: "postgres://user:s3cr3t-long@db/app",
+							},
+							{
+								Var:   "DB_PASSWORD",
+								Value: "s3cr3t",
+							},
+							{
+								Var:   "TZ",
+								Value: "UTC",
+							},
+						},
+					},
+				},
+			},
+		},
+		secrets: []string{"s3cr3t", "s3cr3t-long\n", ""},
+		want: &Homelab{
+			Global: Global{
+				Env: []ConfigEnv{
+					{
+						Var:   "DB_PASSWORD",
+						Value: RedactedValue,
+					},
+				},
+			},
+			Containers: []Container{
+				{
+					Runtime: ContainerRuntime{
+						Env: []ContainerEnv{
+							{
+								Var:   "DB_URL",
+								Value: "postgres://user:<redacted>@db/app",
+							},
+							{
+								Var:   "DB_PASSWORD",
+								Value: RedactedValue,
+							},
+							{
+								Var:   "TZ",
+								Value: "UTC",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name: "Homelab Config - Redacted - Short Secrets",
+		config: &Homelab{
+			Containers: []Container{
+				{
+					Image: ContainerImage{
+						Image: "abc/xyz:1",
+					},
+					Runtime: ContainerRuntime{
+						Env: []ContainerEnv{
+							{
+								Var:   "API_KEY",
+								Value: "abc",
+							},
+							{
+								Var:   "DB_URL",
+								Value: "postgres://user:abc@db/app",
+							},
+						},
+					},
+				},
+			},
+		},
+		secrets: []string{"abc\n", "1"},
+		want: &Homelab{
+			Containers: []Container{
+				{
+					Image: ContainerImage{
+						Image: "abc/xyz:1",
+					},
+					Runtime: ContainerRuntime{
+						Env: []ContainerEnv{
+							{
+								Var:   "API_KEY",
+								Value: RedactedValue,
+							},
+							{
+								Var:   "DB_URL",
+								Value: "postgres://user:abc@db/app",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name: "Homelab Config - Redacted - No Secrets",
+		config: &Homelab{
+			Global: Global{
+				BaseDir: "/homelab",
+			},
+		},
+		want: &Homelab{
+			Global: Global{
+				BaseDir: "/homelab",
+			},
+		},
+	},
+}
+
+func TestRedacted(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range redactedTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			orig := deepcopy.MustCopy(tc.config)
+			got := Redacted(tc.config, tc.secrets)
+			if !testhelpers.CmpDiff(t, "Redacted()", tc.name, "redacted config", tc.want, got) {
+				return
+			}
+			testhelpers.CmpDiff(t, "Redacted()", tc.name, "original config", orig, tc.config)
+		})
+	}
+}
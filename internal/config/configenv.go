@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/tuxgal/homelab/internal/config/env"
+)
+
+const (
+	configEnvTag     = "configenv"
+	configEnvTagSkip = "-"
+)
+
+// applyConfigEnv substitutes the config env in every string reachable
+// from the struct pointed to by ptr, except within the fields tagged
+// with `configenv:"-"`.
+func applyConfigEnv(env *env.ConfigEnvManager, ptr any) error {
+	w := stringsWalker{
+		fn: func(path, s string) (string, error) {
+			res, err := env.Apply(s)
+			if err != nil {
+				return "", fmt.Errorf("config env substitution failed for %s, reason: %w", path, err)
+			}
+			return res, nil
+		},
+		skip: func(f reflect.StructField) bool {
+			return f.Tag.Get(configEnvTag) == configEnvTagSkip
+		},
+	}
+	return w.walk(reflect.ValueOf(ptr).Elem(), "")
+}
@@ -0,0 +1,83 @@
+package env
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadEnvFile reads the env from the specified dotenv file.
+func ReadEnvFile(path string) (EnvMap, EnvOrder, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return parseEnvFile(f)
+}
+
+// parseEnvFile parses the env in the dotenv format, i.e. one
+// VAR=value pair per line optionally prefixed by export, where the
+// value is optionally enclosed within single or double quotes. Blank
+// lines, lines starting with # and comments following an unquoted
+// value are ignored. A variable defined more than once takes the last
+// value.
+func parseEnvFile(r io.Reader) (EnvMap, EnvOrder, error) {
+	envs := EnvMap{}
+	envOrder := EnvOrder{}
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		k, v, found := strings.Cut(line, "=")
+		if !found {
+			return nil, nil, fmt.Errorf("line %d: expected VAR=value", lineNum)
+		}
+		k = strings.TrimSpace(k)
+		if !isConfigEnvName(k) {
+			return nil, nil, fmt.Errorf("line %d: invalid env var name %q", lineNum, k)
+		}
+		v, err := parseEnvFileValue(strings.TrimSpace(v))
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		if _, found := envs[k]; !found {
+			envOrder = append(envOrder, k)
+		}
+		envs[k] = v
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return envs, envOrder, nil
+}
+
+func parseEnvFileValue(v string) (string, error) {
+	if len(v) == 0 {
+		return v, nil
+	}
+	if q := v[0]; q == '"' || q == '\'' {
+		end := strings.IndexByte(v[1:], q)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value %s", v)
+		}
+		rest := strings.TrimSpace(v[end+2:])
+		if len(rest) > 0 && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected characters %q after the quoted value", rest)
+		}
+		return v[1 : end+1], nil
+	}
+	for i := 1; i < len(v); i++ {
+		if v[i] == '#' && (v[i-1] == ' ' || v[i-1] == '\t') {
+			return strings.TrimSpace(v[:i]), nil
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,117 @@
+package env
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tuxgal/homelab/internal/testhelpers"
+)
+
+var parseEnvFileTests = []struct {
+	name      string
+	input     string
+	wantEnv   EnvMap
+	wantOrder EnvOrder
+}{
+	{
+		name: "Parse Env File - Valid",
+		input: `
+# Comment line.
+FOO=foo
+export BAR = bar value
+QUOTED="quoted # value" # Trailing comment.
+SINGLE_QUOTED='$$NOT_EXPANDED$$'
+UNQUOTED=unquoted	# Trailing comment.
+EMPTY=
+FOO=foo-overridden
+`,
+		wantEnv: EnvMap{
+			"FOO":           "foo-overridden",
+			"BAR":           "bar value",
+			"QUOTED":        "quoted # value",
+			"SINGLE_QUOTED": "$$NOT_EXPANDED$$",
+			"UNQUOTED":      "unquoted",
+			"EMPTY":         "",
+		},
+		wantOrder: EnvOrder{
+			"FOO",
+			"BAR",
+			"QUOTED",
+			"SINGLE_QUOTED",
+			"UNQUOTED",
+			"EMPTY",
+		},
+	},
+}
+
+func TestParseEnvFile(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range parseEnvFileTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotEnv, gotOrder, gotErr := parseEnvFile(strings.NewReader(tc.input))
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "parseEnvFile()", tc.name, gotErr)
+				return
+			}
+
+			if !testhelpers.CmpDiff(t, "parseEnvFile()", tc.name, "env map", tc.wantEnv, gotEnv) {
+				return
+			}
+			if !testhelpers.CmpDiff(t, "parseEnvFile()", tc.name, "env order", tc.wantOrder, gotOrder) {
+				return
+			}
+		})
+	}
+}
+
+var parseEnvFileErrorTests = []struct {
+	name  string
+	input string
+	want  string
+}{
+	{
+		name:  "Parse Env File - Missing Separator",
+		input: "FOO=foo\nBAR\n",
+		want:  `line 2: expected VAR=value`,
+	},
+	{
+		name:  "Parse Env File - Invalid Var Name",
+		input: "1FOO=foo\n",
+		want:  `line 1: invalid env var name "1FOO"`,
+	},
+	{
+		name:  "Parse Env File - Unterminated Quote",
+		input: "FOO=\"foo\n",
+		want:  `line 1: unterminated quoted value "foo`,
+	},
+	{
+		name:  "Parse Env File - Characters After Quoted Value",
+		input: "FOO='foo' bar\n",
+		want:  `line 1: unexpected characters "bar" after the quoted value`,
+	},
+}
+
+func TestParseEnvFileErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range parseEnvFileErrorTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, gotErr := parseEnvFile(strings.NewReader(tc.input))
+			if gotErr == nil {
+				testhelpers.LogErrorNil(t, "parseEnvFile()", tc.name, tc.want)
+				return
+			}
+
+			if !testhelpers.RegexMatch(t, "parseEnvFile()", tc.name, "gotErr error string", tc.want, gotErr.Error()) {
+				return
+			}
+		})
+	}
+}
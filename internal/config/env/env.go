@@ -6,10 +6,17 @@ import (
 	"strings"
 )
 
+const (
+	configEnvDelim         = "$$"
+	configEnvEscapedDelim  = `\$$`
+	configEnvDefaultOp     = ":-"
+	configEnvRequiredOp    = ":?"
+	configEnvOperatorStart = ':'
+)
+
 type configEnv struct {
 	env         EnvMap
 	envKeyOrder EnvOrder
-	replacer    *strings.Replacer
 }
 
 func newConfigEnv(ctx context.Context, env EnvMap, order EnvOrder) *configEnv {
@@ -41,16 +48,157 @@ func (c *configEnv) override(ctx context.Context, override EnvMap, order EnvOrde
 		}
 		res.env[sk] = newVal
 	}
-	var repl []string
-	for _, k := range res.envKeyOrder {
-		repl = append(repl, k, res.env[k])
-	}
-	res.replacer = strings.NewReplacer(repl...)
 	return &res
 }
 
-func (c *configEnv) apply(input string) string {
-	return c.replacer.Replace(input)
+// apply expands all the config env variable references within the
+// input in a single pass, i.e. the substituted values are never
+// expanded further. The supported forms are:
+//
+//	$$VAR$$          value of VAR
+//	$$VAR:-default$$ value of VAR, or default if VAR is unset or empty
+//	$$VAR:?message$$ value of VAR, or an error with the message if
+//	                 VAR is unset or empty
+//	\$$              a literal $$
+//
+// Every literal $$ must be escaped individually, including the closing
+// one, e.g. \$$VAR\$$ for the literal $$VAR$$.
+//
+// A reference to a variable which is not defined results in an error,
+// and so does any unescaped $$ which is not part of a well formed
+// reference (e.g. $$HOST-NAME$$, $$VAR:x$$ or an unterminated $$VAR),
+// since it is most likely a typo which would otherwise be left
+// unresolved within the config.
+func (c *configEnv) apply(input string) (string, error) {
+	var res strings.Builder
+	for i := 0; i < len(input); {
+		if strings.HasPrefix(input[i:], configEnvEscapedDelim) {
+			res.WriteString(configEnvDelim)
+			i += len(configEnvEscapedDelim)
+			continue
+		}
+		if !strings.HasPrefix(input[i:], configEnvDelim) {
+			res.WriteByte(input[i])
+			i++
+			continue
+		}
+
+		ref, n := parseConfigEnvRef(input[i+len(configEnvDelim):])
+		if ref == nil && strings.HasPrefix(input[i+len(configEnvDelim):], "$") {
+			// A $ immediately preceding a reference, e.g. $$$VAR$$.
+			res.WriteByte('$')
+			i++
+			continue
+		}
+		if ref == nil {
+			return "", fmt.Errorf("malformed config env reference %s in %q", malformedConfigEnvRef(input[i:]), input)
+		}
+		val, err := c.resolve(ref)
+		if err != nil {
+			return "", fmt.Errorf("%w in %q", err, input)
+		}
+		res.WriteString(val)
+		i += len(configEnvDelim) + n
+	}
+	return res.String(), nil
+}
+
+func (c *configEnv) resolve(ref *configEnvRef) (string, error) {
+	val, found := c.env[configEnvSearchKey(ref.name)]
+	switch ref.op {
+	case configEnvDefaultOp:
+		if len(val) == 0 {
+			return ref.arg, nil
+		}
+	case configEnvRequiredOp:
+		if len(val) == 0 {
+			if len(ref.arg) == 0 {
+				return "", fmt.Errorf("required config env variable %s is not set", ref.name)
+			}
+			return "", fmt.Errorf("required config env variable %s is not set: %s", ref.name, ref.arg)
+		}
+	default:
+		if !found {
+			return "", fmt.Errorf("config env variable %s is not defined", ref.name)
+		}
+	}
+	return val, nil
+}
+
+type configEnvRef struct {
+	name string
+	op   string
+	arg  string
+}
+
+// parseConfigEnvRef parses the config env variable reference at the
+// beginning of the input following the opening $$, and returns the
+// reference along with the number of bytes consumed including the
+// closing $$. A nil reference is returned if the input does not begin
+// with a well formed reference.
+func parseConfigEnvRef(input string) (*configEnvRef, int) {
+	n := 0
+	for n < len(input) && isConfigEnvNameChar(input[n], n == 0) {
+		n++
+	}
+	if n == 0 {
+		return nil, 0
+	}
+	ref := configEnvRef{name: input[:n]}
+
+	rest := input[n:]
+	if len(rest) > 0 && rest[0] == configEnvOperatorStart {
+		switch {
+		case strings.HasPrefix(rest, configEnvDefaultOp):
+			ref.op = configEnvDefaultOp
+		case strings.HasPrefix(rest, configEnvRequiredOp):
+			ref.op = configEnvRequiredOp
+		default:
+			return nil, 0
+		}
+		rest = rest[len(ref.op):]
+		end := strings.Index(rest, configEnvDelim)
+		if end < 0 {
+			return nil, 0
+		}
+		ref.arg = rest[:end]
+		return &ref, n + len(ref.op) + end + len(configEnvDelim)
+	}
+
+	if !strings.HasPrefix(rest, configEnvDelim) {
+		return nil, 0
+	}
+	return &ref, n + len(configEnvDelim)
+}
+
+// malformedConfigEnvRef returns the malformed config env variable
+// reference at the beginning of the input, i.e. everything up to and
+// including the closing $$ (if any).
+func malformedConfigEnvRef(input string) string {
+	end := strings.Index(input[len(configEnvDelim):], configEnvDelim)
+	if end < 0 {
+		return input
+	}
+	return input[:end+2*len(configEnvDelim)]
+}
+
+func isConfigEnvName(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	for i := range len(name) {
+		if !isConfigEnvNameChar(name[i], i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func isConfigEnvNameChar(c byte, first bool) bool {
+	if c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
+		return true
+	}
+	return !first && c >= '0' && c <= '9'
 }
 
 func configEnvSearchKey(env string) string {
@@ -81,7 +81,7 @@ func (c *ConfigEnvManager) NewContainerConfigEnvManager(ctx context.Context, con
 	}
 }
 
-func (c *ConfigEnvManager) Apply(input string) string {
+func (c *ConfigEnvManager) Apply(input string) (string, error) {
 	return c.env.apply(input)
 }
 
@@ -71,7 +71,11 @@ func TestSystemConfigEnvManagerApply(t *testing.T) {
 			ctx = logger.WithLogger(ctx, l)
 
 			env := NewSystemConfigEnvManager(ctx)
-			got := env.Apply(tc.input)
+			got, gotErr := env.Apply(tc.input)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "SystemConfigEnvManager.Apply()", tc.name, gotErr)
+				return
+			}
 			if got != tc.want {
 				testhelpers.LogCustom(t, "SystemConfigEnvManager.Apply()", tc.name, fmt.Sprintf("got '%s' != want '%s'", got, tc.want))
 			}
@@ -208,7 +212,11 @@ func TestGlobalConfigEnvManagerApply(t *testing.T) {
 			ctx = logger.WithLogger(ctx, l)
 
 			env := NewSystemConfigEnvManager(ctx).NewGlobalConfigEnvManager(ctx, "/home/foobar/dummy-base-dir", tc.globalEnvMap, tc.globalEnvOrder)
-			got := env.Apply(tc.input)
+			got, gotErr := env.Apply(tc.input)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "GlobalConfigEnvManager.Apply()", tc.name, gotErr)
+				return
+			}
 			if got != tc.want {
 				testhelpers.LogCustom(t, "GlobalConfigEnvManager.Apply()", tc.name, fmt.Sprintf("got '%s' != want '%s'", got, tc.want))
 			}
@@ -518,7 +526,11 @@ func TestContainerConfigEnvManagerApply(t *testing.T) {
 			env := NewSystemConfigEnvManager(ctx)
 			env = env.NewGlobalConfigEnvManager(ctx, "/home/foobar/dummy-base-dir", tc.globalEnvMap, tc.globalEnvOrder)
 			env = env.NewContainerConfigEnvManager(ctx, "/home/foobar/dummy-base-dir/g1", "/home/foobar/dummy-base-dir/g1/c1", tc.containerEnvMap, tc.containerEnvOrder)
-			got := env.Apply(tc.input)
+			got, gotErr := env.Apply(tc.input)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "ContainerConfigEnvManager.Apply()", tc.name, gotErr)
+				return
+			}
 			if got != tc.want {
 				testhelpers.LogCustom(t, "ContainerConfigEnvManager.Apply()", tc.name, fmt.Sprintf("got '%s' != want '%s'", got, tc.want))
 			}
@@ -119,10 +119,14 @@ var configEnvTests = []struct {
 	{
 		name: "Config Env - apply - No Match",
 		test: func(t *testing.T, ctx context.Context, env *configEnv, tc string) {
-			input := "my_env1=$ENV1$$;my_env2=$$ENV2$;my_env3=ENV3"
-			want := "my_env1=$ENV1$$;my_env2=$$ENV2$;my_env3=ENV3"
+			input := "my_env1=$ENV1;my_env2=$ENV2$;my_env3=ENV3"
+			want := "my_env1=$ENV1;my_env2=$ENV2$;my_env3=ENV3"
 
-			got := env.apply(input)
+			got, gotErr := env.apply(input)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "configEnv.apply()", tc, gotErr)
+				return
+			}
 			if !testhelpers.CmpDiff(t, "configEnv.apply()", tc, "apply result", want, got) {
 				return
 			}
@@ -131,10 +135,14 @@ var configEnvTests = []struct {
 	{
 		name: "Config Env - apply - Single Match",
 		test: func(t *testing.T, ctx context.Context, env *configEnv, tc string) {
-			input := "my_env1=$ENV1$$;my_env2=$$ENV2$$;my_env3=ENV3"
-			want := "my_env1=$ENV1$$;my_env2=my-env-2;my_env3=ENV3"
+			input := "my_env1=$ENV1;my_env2=$$ENV2$$;my_env3=ENV3"
+			want := "my_env1=$ENV1;my_env2=my-env-2;my_env3=ENV3"
 
-			got := env.apply(input)
+			got, gotErr := env.apply(input)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "configEnv.apply()", tc, gotErr)
+				return
+			}
 			if !testhelpers.CmpDiff(t, "configEnv.apply()", tc, "apply result", want, got) {
 				return
 			}
@@ -146,7 +154,11 @@ var configEnvTests = []struct {
 			input := "my_env1=$$ENV1$$;my_env2=$$ENV2$$;my_env3=$$ENV3$$"
 			want := "my_env1=my-env-1;my_env2=my-env-2;my_env3=my-env-3"
 
-			got := env.apply(input)
+			got, gotErr := env.apply(input)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "configEnv.apply()", tc, gotErr)
+				return
+			}
 			if !testhelpers.CmpDiff(t, "configEnv.apply()", tc, "apply result", want, got) {
 				return
 			}
@@ -171,12 +183,156 @@ var configEnvTests = []struct {
 					"BAZ",
 				},
 			)
-			got := env.apply(input)
+			got, gotErr := env.apply(input)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "configEnv.apply()", tc, gotErr)
+				return
+			}
+			if !testhelpers.CmpDiff(t, "configEnv.apply()", tc, "apply result", want, got) {
+				return
+			}
+		},
+	},
+	{
+		name: "Config Env - apply - Default Value",
+		test: func(t *testing.T, ctx context.Context, env *configEnv, tc string) {
+			input := "env1=$$ENV1:-foo$$;empty=$$EMPTY:-bar$$;unset=$$UNSET:-baz$$;no_default=$$UNSET:-$$"
+			want := "env1=my-env-1;empty=bar;unset=baz;no_default="
+
+			env = env.override(ctx, EnvMap{"EMPTY": ""}, EnvOrder{"EMPTY"})
+			got, gotErr := env.apply(input)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "configEnv.apply()", tc, gotErr)
+				return
+			}
 			if !testhelpers.CmpDiff(t, "configEnv.apply()", tc, "apply result", want, got) {
 				return
 			}
 		},
 	},
+	{
+		name: "Config Env - apply - Required Value Set",
+		test: func(t *testing.T, ctx context.Context, env *configEnv, tc string) {
+			input := "env2=$$ENV2:?ENV2 must be set$$"
+			want := "env2=my-env-2"
+
+			got, gotErr := env.apply(input)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "configEnv.apply()", tc, gotErr)
+				return
+			}
+			if !testhelpers.CmpDiff(t, "configEnv.apply()", tc, "apply result", want, got) {
+				return
+			}
+		},
+	},
+	{
+		name: "Config Env - apply - Escaped",
+		test: func(t *testing.T, ctx context.Context, env *configEnv, tc string) {
+			input := `literal=\$$ENV1\$$;env1=$$ENV1$$;dollar=$$$ENV2$$;pass=pa\$$word;trailing=\$$`
+			want := "literal=$$ENV1$$;env1=my-env-1;dollar=$my-env-2;pass=pa$$word;trailing=$$"
+
+			got, gotErr := env.apply(input)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "configEnv.apply()", tc, gotErr)
+				return
+			}
+			if !testhelpers.CmpDiff(t, "configEnv.apply()", tc, "apply result", want, got) {
+				return
+			}
+		},
+	},
+	{
+		name: "Config Env - apply - Malformed References",
+		test: func(t *testing.T, ctx context.Context, env *configEnv, tc string) {
+			tests := []struct {
+				input string
+				want  string
+			}{
+				{
+					input: "host=$$HOST-NAME$$",
+					want:  `malformed config env reference \$\$HOST-NAME\$\$ in "host=\$\$HOST-NAME\$\$"`,
+				},
+				{
+					input: "env1=$$ENV1:x$$;env2=$$ENV2$$",
+					want:  `malformed config env reference \$\$ENV1:x\$\$ in "env1=\$\$ENV1:x\$\$;env2=\$\$ENV2\$\$"`,
+				},
+				{
+					input: "env1=$$ENV1",
+					want:  `malformed config env reference \$\$ENV1 in "env1=\$\$ENV1"`,
+				},
+				{
+					input: "env1=$$ENV1:-foo",
+					want:  `malformed config env reference \$\$ENV1:-foo in "env1=\$\$ENV1:-foo"`,
+				},
+				{
+					input: "pass=pa$$word",
+					want:  `malformed config env reference \$\$word in "pass=pa\$\$word"`,
+				},
+				{
+					input: "trailing=$$",
+					want:  `malformed config env reference \$\$ in "trailing=\$\$"`,
+				},
+			}
+			for _, test := range tests {
+				_, gotErr := env.apply(test.input)
+				if gotErr == nil {
+					testhelpers.LogErrorNil(t, "configEnv.apply()", tc, test.want)
+					continue
+				}
+				testhelpers.RegexMatch(t, "configEnv.apply()", tc, "gotErr error string", test.want, gotErr.Error())
+			}
+		},
+	},
+	{
+		name: "Config Env - apply - Undefined Variable",
+		test: func(t *testing.T, ctx context.Context, env *configEnv, tc string) {
+			input := "data=$$CONTAINR_DATA_DIR$$/foo"
+			want := `config env variable CONTAINR_DATA_DIR is not defined in "data=\$\$CONTAINR_DATA_DIR\$\$/foo"`
+
+			_, gotErr := env.apply(input)
+			if gotErr == nil {
+				testhelpers.LogErrorNil(t, "configEnv.apply()", tc, want)
+				return
+			}
+			if !testhelpers.RegexMatch(t, "configEnv.apply()", tc, "gotErr error string", want, gotErr.Error()) {
+				return
+			}
+		},
+	},
+	{
+		name: "Config Env - apply - Required Value Not Set",
+		test: func(t *testing.T, ctx context.Context, env *configEnv, tc string) {
+			input := "env=$$EMPTY:?$$;env4=$$ENV4:?ENV4 must be set$$"
+			want := `required config env variable EMPTY is not set in "env=\$\$EMPTY:\?\$\$;env4=\$\$ENV4:\?ENV4 must be set\$\$"`
+
+			env = env.override(ctx, EnvMap{"EMPTY": ""}, EnvOrder{"EMPTY"})
+			_, gotErr := env.apply(input)
+			if gotErr == nil {
+				testhelpers.LogErrorNil(t, "configEnv.apply()", tc, want)
+				return
+			}
+			if !testhelpers.RegexMatch(t, "configEnv.apply()", tc, "gotErr error string", want, gotErr.Error()) {
+				return
+			}
+		},
+	},
+	{
+		name: "Config Env - apply - Required Value Not Set With Message",
+		test: func(t *testing.T, ctx context.Context, env *configEnv, tc string) {
+			input := "env4=$$ENV4:?ENV4 must be set$$"
+			want := `required config env variable ENV4 is not set: ENV4 must be set in "env4=\$\$ENV4:\?ENV4 must be set\$\$"`
+
+			_, gotErr := env.apply(input)
+			if gotErr == nil {
+				testhelpers.LogErrorNil(t, "configEnv.apply()", tc, want)
+				return
+			}
+			if !testhelpers.RegexMatch(t, "configEnv.apply()", tc, "gotErr error string", want, gotErr.Error()) {
+				return
+			}
+		},
+	},
 
 	{
 		name: "Config Env - override - Unequal Lengths Between Override Map And Order",
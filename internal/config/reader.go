@@ -9,10 +9,28 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/TwiN/deepmerge"
+	"gopkg.in/yaml.v3"
 )
 
+// MergedConfigs represents the yaml documents of all the config files
+// under the configs dir merged into a single document, while retaining
+// the file and line where every merged node was defined.
+type MergedConfigs struct {
+	root  *yaml.Node
+	files map[*yaml.Node]string
+}
+
 func MergedConfigsReader(ctx context.Context, path string) (io.Reader, error) {
+	m, err := ReadMergedConfigs(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return m.Reader()
+}
+
+// ReadMergedConfigs reads and merges all the config files under the
+// specified configs path.
+func ReadMergedConfigs(ctx context.Context, path string) (*MergedConfigs, error) {
 	pathStat, err := os.Stat(path)
 	if err != nil {
 		return nil, fmt.Errorf("os.Stat() failed on homelab configs path, reason: %w", err)
@@ -21,7 +39,7 @@ func MergedConfigsReader(ctx context.Context, path string) (io.Reader, error) {
 		return nil, fmt.Errorf("homelab configs path %s must be a directory", path)
 	}
 
-	var result []byte
+	var result *MergedConfigs
 	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return fmt.Errorf("failed to read contents of directory %s, reason: %w", path, err)
@@ -38,9 +56,23 @@ func MergedConfigsReader(ctx context.Context, path string) (io.Reader, error) {
 		if err != nil {
 			return fmt.Errorf("failed to read homelab config file %s, reason: %w", p, err)
 		}
-		result, err = deepmerge.YAML(result, configFile)
+		doc := yaml.Node{}
+		if err := yaml.Unmarshal(configFile, &doc); err != nil {
+			return fmt.Errorf("failed to parse homelab config file %s, reason: %w", p, err)
+		}
+
+		if result == nil {
+			result = &MergedConfigs{
+				root:  &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"},
+				files: make(map[*yaml.Node]string),
+			}
+		}
+		rel, err := filepath.Rel(path, p)
 		if err != nil {
-			return fmt.Errorf("failed to deep merge config file %s, reason: %w", p, err)
+			rel = p
+		}
+		if err := result.mergeFile(rel, &doc); err != nil {
+			return fmt.Errorf("failed to merge config file %s, reason: %w", p, err)
 		}
 		return nil
 	})
@@ -50,9 +82,171 @@ func MergedConfigsReader(ctx context.Context, path string) (io.Reader, error) {
 		return nil, err
 	}
 
-	if len(result) == 0 {
+	if result == nil {
 		return nil, fmt.Errorf("no homelab configs found in %s", path)
 	}
 
-	return bytes.NewReader(result), nil
+	return result, nil
+}
+
+// Reader returns a reader for the merged config document.
+func (m *MergedConfigs) Reader() (io.Reader, error) {
+	out, err := yaml.Marshal(m.root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize the merged homelab config, reason: %w", err)
+	}
+	return bytes.NewReader(out), nil
+}
+
+func (m *MergedConfigs) mergeFile(file string, doc *yaml.Node) error {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		// Empty config file.
+		return nil
+	}
+	root := doc.Content[0]
+	if isNullNode(root) {
+		return nil
+	}
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: config file must contain a mapping at the top level", root.Line)
+	}
+
+	m.trackFile(file, root)
+	return m.mergeMappings("", m.root, root)
+}
+
+func (m *MergedConfigs) trackFile(file string, node *yaml.Node) {
+	m.files[node] = file
+	for _, c := range node.Content {
+		m.trackFile(file, c)
+	}
+}
+
+func (m *MergedConfigs) sourcePos(node *yaml.Node) (SourcePos, bool) {
+	file, found := m.files[node]
+	if !found {
+		return SourcePos{}, false
+	}
+	return SourcePos{File: file, Line: node.Line}, true
+}
+
+// keyedSequences maps the path of the sequences within the config
+// whose items are identified by a key, to the function that returns the
+// key of an item. Items with the same key across the config files are
+// merged into a single item.
+var keyedSequences = map[string]func(*yaml.Node) string{
+	"containers":                          containerItemKey,
+	"groups":                              nameItemKey,
+	"hosts":                               nameItemKey,
+	"ipam.networks.bridgeModeNetworks":    nameItemKey,
+	"ipam.networks.containerModeNetworks": nameItemKey,
+	"secrets":                             nameItemKey,
+}
+
+// mergeMappings merges the src mapping node into the dst mapping node.
+// Mappings are merged recursively, items of keyed sequences are merged
+// by their key while other sequences are concatenated. A scalar defined
+// in more than one config file must have the same value everywhere.
+func (m *MergedConfigs) mergeMappings(path string, dst, src *yaml.Node) error {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		srcKey, srcVal := src.Content[i], src.Content[i+1]
+		dstIdx := mappingKeyIndex(dst, srcKey.Value)
+		if dstIdx < 0 {
+			dst.Content = append(dst.Content, srcKey, srcVal)
+			continue
+		}
+
+		p := srcKey.Value
+		if len(path) > 0 {
+			p = fmt.Sprintf("%s.%s", path, srcKey.Value)
+		}
+		merged, err := m.mergeValues(p, dst.Content[dstIdx+1], srcVal)
+		if err != nil {
+			return err
+		}
+		dst.Content[dstIdx+1] = merged
+	}
+	return nil
+}
+
+func (m *MergedConfigs) mergeValues(path string, dst, src *yaml.Node) (*yaml.Node, error) {
+	switch {
+	case isNullNode(src):
+		return dst, nil
+	case isNullNode(dst):
+		return src, nil
+	case dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode:
+		return dst, m.mergeMappings(path, dst, src)
+	case dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode:
+		return dst, m.mergeSequences(path, dst, src)
+	case dst.Kind == yaml.ScalarNode && src.Kind == yaml.ScalarNode && dst.ShortTag() == src.ShortTag() && dst.Value == src.Value:
+		return dst, nil
+	}
+
+	dstPos, _ := m.sourcePos(dst)
+	srcPos, _ := m.sourcePos(src)
+	return nil, fmt.Errorf("%s is defined with conflicting values in %s and %s", path, dstPos, srcPos)
+}
+
+func (m *MergedConfigs) mergeSequences(path string, dst, src *yaml.Node) error {
+	keyFn, keyed := keyedSequences[path]
+	if !keyed {
+		dst.Content = append(dst.Content, src.Content...)
+		return nil
+	}
+
+	// Items are only merged with the items from the previously merged
+	// config files, so that an item defined more than once within the
+	// same config file is still reported while validating the config.
+	prev := len(dst.Content)
+	for _, item := range src.Content {
+		key := keyFn(item)
+		idx := -1
+		if len(key) > 0 {
+			for i := 0; i < prev; i++ {
+				if keyFn(dst.Content[i]) == key {
+					idx = i
+					break
+				}
+			}
+		}
+		if idx < 0 {
+			dst.Content = append(dst.Content, item)
+			continue
+		}
+
+		merged, err := m.mergeValues(fmt.Sprintf("%s[%s]", path, key), dst.Content[idx], item)
+		if err != nil {
+			return err
+		}
+		dst.Content[idx] = merged
+	}
+	return nil
+}
+
+func containerItemKey(node *yaml.Node) string {
+	info := mappingValue(node, "info")
+	group := scalarValue(info, "group")
+	container := scalarValue(info, "container")
+	if len(group) == 0 || len(container) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", group, container)
+}
+
+func nameItemKey(node *yaml.Node) string {
+	return scalarValue(node, "name")
+}
+
+func isNullNode(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
+
+func mappingKeyIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
 }
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/tuxgal/homelab/internal/deepcopy"
+)
+
+const (
+	// RedactedValue replaces the secret values within the redacted config.
+	RedactedValue = "<redacted>"
+	// Minimum length of the secret values which are redacted even when
+	// they are only a part of a string. The shorter secret values are
+	// redacted only from the strings which match them entirely, to avoid
+	// mangling the unrelated strings which happen to contain them.
+	minRedactedSubstringLen = 8
+)
+
+// Redacted returns a copy of the homelab config with the specified
+// secret values replaced by RedactedValue.
+func Redacted(conf *Homelab, secrets []string) *Homelab {
+	var values []string
+	short := make(map[string]bool)
+	for _, s := range secrets {
+		s = strings.TrimSpace(s)
+		switch {
+		case len(s) >= minRedactedSubstringLen:
+			values = append(values, s)
+		case len(s) > 0:
+			short[s] = true
+		}
+	}
+	// Longer values are matched first, so that a secret value containing
+	// another secret value is redacted entirely.
+	slices.SortFunc(values, func(a, b string) int {
+		return len(b) - len(a)
+	})
+	var pairs []string
+	for _, v := range values {
+		pairs = append(pairs, v, RedactedValue)
+	}
+	res := deepcopy.MustCopy(conf)
+	if len(pairs) == 0 && len(short) == 0 {
+		return res
+	}
+
+	r := strings.NewReplacer(pairs...)
+	w := stringsWalker{
+		fn: func(_, s string) (string, error) {
+			if short[s] {
+				return RedactedValue, nil
+			}
+			return r.Replace(s), nil
+		},
+	}
+	// Replacing the strings never fails.
+	_ = w.walk(reflect.ValueOf(res).Elem(), "")
+	return res
+}
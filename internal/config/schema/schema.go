@@ -0,0 +1,139 @@
+// Package schema generates the JSON Schema of the homelab config, which
+// can be used by editors (e.g. through yaml-language-server) to provide
+// validation and completion while editing the homelab config files.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tuxgal/homelab/internal/config"
+	"github.com/tuxgal/homelab/internal/docker"
+)
+
+const (
+	draft = "https://json-schema.org/draft/2020-12/schema"
+	title = "Homelab config"
+)
+
+// Schema represents a JSON Schema (or a sub-schema within it).
+type Schema struct {
+	Draft                string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// configEnvRefPattern matches the strings containing a config env
+// variable reference (e.g. $$VAR$$ or $$VAR:-default$$), which are
+// accepted by the fields restricted to the enums as well, since the
+// references are substituted only after the config is parsed.
+const configEnvRefPattern = `\$\$[A-Za-z_][A-Za-z0-9_]*(:[-?].*)?\$\$`
+
+// enums maps the fields of the config structs (in the Type.Field format)
+// to the list of values they accept, apart from the config env variable
+// references.
+var enums = map[string][]string{
+	"ContainerLogging.Driver":     docker.LogDrivers(),
+	"ContainerRestartPolicy.Mode": docker.RestartPolicyModes(),
+	"Mount.Type":                  {"bind", "tmpfs"},
+	"PublishedPort.Protocol":      {"tcp", "udp"},
+}
+
+type generator struct {
+	defs map[string]*Schema
+}
+
+// Generate returns the JSON Schema of the homelab config.
+func Generate() *Schema {
+	g := &generator{defs: make(map[string]*Schema)}
+	root := g.structSchema(reflect.TypeOf(config.Homelab{}))
+	root.Draft = draft
+	root.Title = title
+	root.Defs = g.defs
+	return root
+}
+
+// JSON returns the schema serialized as indented JSON.
+func (s *Schema) JSON() ([]byte, error) {
+	out, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize the homelab config schema, reason: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+func (g *generator) typeSchema(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.Struct:
+		// Every named struct is defined only once under $defs and
+		// referenced from everywhere else.
+		name := t.Name()
+		if _, found := g.defs[name]; !found {
+			// Reserve the name first to terminate recursive types.
+			g.defs[name] = nil
+			g.defs[name] = g.structSchema(t)
+		}
+		return &Schema{Ref: fmt.Sprintf("#/$defs/%s", name)}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		// Arbitrary content (e.g. the ignored config).
+		return &Schema{}
+	}
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	// The homelab config is parsed with unknown fields disallowed.
+	noAdditional := false
+	s := &Schema{
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: &noAdditional,
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if len(name) == 0 {
+			name = strings.ToLower(f.Name)
+		}
+
+		prop := g.typeSchema(f.Type)
+		if values, found := enums[fmt.Sprintf("%s.%s", t.Name(), f.Name)]; found {
+			prop.AnyOf = []*Schema{
+				{Enum: values},
+				{Pattern: configEnvRefPattern},
+			}
+		}
+		s.Properties[name] = prop
+	}
+	return s
+}
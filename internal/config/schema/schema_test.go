@@ -0,0 +1,161 @@
+package schema
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/tuxgal/homelab/internal/docker"
+	"github.com/tuxgal/homelab/internal/testhelpers"
+)
+
+var generateTests = []struct {
+	name string
+	got  func(s *Schema) *Schema
+	want *Schema
+}{
+	{
+		name: "Generate() - Global Property",
+		got: func(s *Schema) *Schema {
+			return s.Properties["global"]
+		},
+		want: &Schema{
+			Ref: "#/$defs/Global",
+		},
+	},
+	{
+		name: "Generate() - Containers Property",
+		got: func(s *Schema) *Schema {
+			return s.Properties["containers"]
+		},
+		want: &Schema{
+			Type: "array",
+			Items: &Schema{
+				Ref: "#/$defs/Container",
+			},
+		},
+	},
+	{
+		name: "Generate() - Ignore Property",
+		got: func(s *Schema) *Schema {
+			return s.Properties["ignore"]
+		},
+		want: &Schema{
+			Type:  "array",
+			Items: &Schema{},
+		},
+	},
+	{
+		name: "Generate() - Restart Policy",
+		got: func(s *Schema) *Schema {
+			return s.Defs["ContainerRestartPolicy"]
+		},
+		want: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"mode": {
+					Type: "string",
+					AnyOf: []*Schema{
+						{
+							Enum: docker.RestartPolicyModes(),
+						},
+						{
+							Pattern: configEnvRefPattern,
+						},
+					},
+				},
+				"maxRetryCount": {
+					Type: "integer",
+				},
+			},
+			AdditionalProperties: new(bool),
+		},
+	},
+	{
+		name: "Generate() - Container Device Skips Unparsed Fields",
+		got: func(s *Schema) *Schema {
+			return s.Defs["ContainerDevice"]
+		},
+		want: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"static": {
+					Type: "array",
+					Items: &Schema{
+						Ref: "#/$defs/Device",
+					},
+				},
+				"dynamic": {
+					Type: "array",
+					Items: &Schema{
+						Type: "string",
+					},
+				},
+			},
+			AdditionalProperties: new(bool),
+		},
+	},
+}
+
+var configEnvRefPatternTests = []struct {
+	name  string
+	value string
+	want  bool
+}{
+	{
+		name:  "Config Env Ref Pattern - Reference",
+		value: "$$RESTART_MODE$$",
+		want:  true,
+	},
+	{
+		name:  "Config Env Ref Pattern - Reference With Default",
+		value: "$$RESTART_MODE:-unless-stopped$$",
+		want:  true,
+	},
+	{
+		name:  "Config Env Ref Pattern - Required Reference",
+		value: "$$PROTO:?must be set$$",
+		want:  true,
+	},
+	{
+		name:  "Config Env Ref Pattern - Enum Value",
+		value: "always",
+		want:  false,
+	},
+	{
+		name:  "Config Env Ref Pattern - Unterminated Reference",
+		value: "$$RESTART_MODE",
+		want:  false,
+	},
+}
+
+func TestConfigEnvRefPattern(t *testing.T) {
+	t.Parallel()
+
+	re := regexp.MustCompile(configEnvRefPattern)
+	for _, test := range configEnvRefPatternTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testhelpers.CmpDiff(t, "configEnvRefPattern", tc.name, "match", tc.want, re.MatchString(tc.value))
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	s := Generate()
+	if s.Draft != draft || s.Title != title || s.AdditionalProperties == nil || *s.AdditionalProperties {
+		testhelpers.LogCustom(t, "Generate()", "Generate() - Root", "root schema is missing the draft, title or disallowing additional properties")
+	}
+
+	for _, test := range generateTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testhelpers.CmpDiff(t, "Generate()", tc.name, "schema", tc.want, tc.got(s))
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ElementKind represents the kind of a top level element within the
+// homelab config.
+type ElementKind string
+
+const (
+	ElementGlobal    ElementKind = "global"
+	ElementIPAM      ElementKind = "ipam"
+	ElementNetwork   ElementKind = "network"
+	ElementHost      ElementKind = "host"
+	ElementGroup     ElementKind = "group"
+	ElementContainer ElementKind = "container"
+	ElementSecret    ElementKind = "secret"
+)
+
+// ElementRef identifies a top level element within the homelab config.
+type ElementRef struct {
+	Kind ElementKind
+	// Name is the name of the element, empty for the elements which
+	// occur only once in the config (i.e. global and ipam). Containers
+	// are named using the group/container format.
+	Name string
+}
+
+// SourcePos represents the position within a config file.
+type SourcePos struct {
+	// File is the path of the config file relative to the configs dir.
+	File string
+	Line int
+}
+
+// SourceIndex maps the top level elements of the homelab config to the
+// position within the config files where they are defined. When an
+// element is defined in more than one config file, the position of its
+// first definition is retained.
+type SourceIndex map[ElementRef]SourcePos
+
+func GlobalElementRef() ElementRef {
+	return ElementRef{Kind: ElementGlobal}
+}
+
+func IPAMElementRef() ElementRef {
+	return ElementRef{Kind: ElementIPAM}
+}
+
+func NetworkElementRef(name string) ElementRef {
+	return ElementRef{Kind: ElementNetwork, Name: name}
+}
+
+func HostElementRef(name string) ElementRef {
+	return ElementRef{Kind: ElementHost, Name: name}
+}
+
+func GroupElementRef(name string) ElementRef {
+	return ElementRef{Kind: ElementGroup, Name: name}
+}
+
+func ContainerElementRef(ref ContainerReference) ElementRef {
+	return ElementRef{Kind: ElementContainer, Name: fmt.Sprintf("%s/%s", ref.Group, ref.Container)}
+}
+
+func SecretElementRef(name string) ElementRef {
+	return ElementRef{Kind: ElementSecret, Name: name}
+}
+
+func (e ElementRef) String() string {
+	if len(e.Name) == 0 {
+		return string(e.Kind)
+	}
+	return fmt.Sprintf("%s %s", e.Kind, e.Name)
+}
+
+func (s SourcePos) String() string {
+	return fmt.Sprintf("%s:%d", s.File, s.Line)
+}
+
+// Lookup returns the position where the specified element is defined.
+func (s SourceIndex) Lookup(ref ElementRef) (SourcePos, bool) {
+	pos, found := s[ref]
+	return pos, found
+}
+
+// Provenance maps the path of every value within the merged homelab
+// config to the position within the config files where it is defined.
+// Paths are made up of the mapping keys separated by a dot, along with
+// the index for the sequence items (e.g. containers[0].image.image).
+type Provenance map[string]SourcePos
+
+// SourceIndex returns the source index for the top level elements of
+// the merged homelab config.
+func (m *MergedConfigs) SourceIndex() SourceIndex {
+	idx := SourceIndex{}
+	root := m.root
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, val := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "global":
+			idx.add(m, GlobalElementRef(), key)
+		case "ipam":
+			idx.add(m, IPAMElementRef(), key)
+			networks := mappingValue(val, "networks")
+			for _, n := range sequenceItems(mappingValue(networks, "bridgeModeNetworks")) {
+				idx.add(m, NetworkElementRef(scalarValue(n, "name")), n)
+			}
+			for _, n := range sequenceItems(mappingValue(networks, "containerModeNetworks")) {
+				idx.add(m, NetworkElementRef(scalarValue(n, "name")), n)
+			}
+		case "hosts":
+			for _, h := range sequenceItems(val) {
+				idx.add(m, HostElementRef(scalarValue(h, "name")), h)
+			}
+		case "groups":
+			for _, g := range sequenceItems(val) {
+				idx.add(m, GroupElementRef(scalarValue(g, "name")), g)
+			}
+		case "containers":
+			for _, c := range sequenceItems(val) {
+				info := mappingValue(c, "info")
+				ref := ContainerReference{
+					Group:     scalarValue(info, "group"),
+					Container: scalarValue(info, "container"),
+				}
+				idx.add(m, ContainerElementRef(ref), c)
+			}
+		case "secrets":
+			for _, sec := range sequenceItems(val) {
+				idx.add(m, SecretElementRef(scalarValue(sec, "name")), sec)
+			}
+		}
+	}
+	return idx
+}
+
+// Provenance returns the provenance of every scalar value within the
+// merged homelab config.
+func (m *MergedConfigs) Provenance() Provenance {
+	prov := Provenance{}
+	walkScalars(m.root, "", func(path string, node *yaml.Node) {
+		if pos, found := m.sourcePos(node); found {
+			prov[path] = pos
+		}
+	})
+	return prov
+}
+
+// AnnotatedYAML returns the homelab config serialized as yaml, with
+// every value annotated with the position within the config files where
+// it is defined.
+func AnnotatedYAML(conf *Homelab, prov Provenance) (string, error) {
+	node := yaml.Node{}
+	if err := node.Encode(conf); err != nil {
+		return "", fmt.Errorf("failed to serialize the homelab config, reason: %w", err)
+	}
+	walkScalars(&node, "", func(path string, n *yaml.Node) {
+		if pos, found := prov[path]; found {
+			n.LineComment = pos.String()
+		}
+	})
+
+	res := bytes.Buffer{}
+	enc := yaml.NewEncoder(&res)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return "", fmt.Errorf("failed to serialize the homelab config, reason: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("failed to serialize the homelab config, reason: %w", err)
+	}
+	return res.String(), nil
+}
+
+func (s SourceIndex) add(m *MergedConfigs, ref ElementRef, node *yaml.Node) {
+	if _, found := s[ref]; found {
+		return
+	}
+	if pos, found := m.sourcePos(node); found {
+		s[ref] = pos
+	}
+}
+
+// walkScalars invokes the specified function for every scalar value
+// within the node along with its path.
+func walkScalars(node *yaml.Node, path string, fn func(string, *yaml.Node)) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, c := range node.Content {
+			walkScalars(c, path, fn)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			p := node.Content[i].Value
+			if len(path) > 0 {
+				p = fmt.Sprintf("%s.%s", path, p)
+			}
+			walkScalars(node.Content[i+1], p, fn)
+		}
+	case yaml.SequenceNode:
+		for i, c := range node.Content {
+			walkScalars(c, fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	case yaml.ScalarNode:
+		fn(path, node)
+	}
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node.Content
+}
+
+func scalarValue(node *yaml.Node, key string) string {
+	val := mappingValue(node, key)
+	if val == nil || val.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return val.Value
+}
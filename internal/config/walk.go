@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// stringsWalker replaces every string reachable from a value with the
+// result of fn, except within the struct fields for which skip returns
+// true. Paths are made up of the yaml field names separated by a dot,
+// along with the index for the sequence items.
+type stringsWalker struct {
+	fn   func(path, s string) (string, error)
+	skip func(f reflect.StructField) bool
+}
+
+func (w *stringsWalker) walk(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.String:
+		res, err := w.fn(path, v.String())
+		if err != nil {
+			return err
+		}
+		v.SetString(res)
+	case reflect.Struct:
+		return w.walkStruct(v, path)
+	case reflect.Array, reflect.Slice:
+		for i := range v.Len() {
+			if err := w.walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		return w.walkMap(v, path)
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return w.walk(v.Elem(), path)
+		}
+	}
+	// Other kinds (including interfaces which hold arbitrary content
+	// that homelab does not interpret) contain no strings to replace.
+	return nil
+}
+
+func (w *stringsWalker) walkStruct(v reflect.Value, path string) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() || (w.skip != nil && w.skip(f)) {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if len(name) == 0 || name == "-" {
+			name = f.Name
+		}
+		if len(path) > 0 {
+			name = fmt.Sprintf("%s.%s", path, name)
+		}
+		if err := w.walk(v.Field(i), name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *stringsWalker) walkMap(v reflect.Value, path string) error {
+	// Map elements are not addressable, so the strings are replaced
+	// within a copy of each value which is then stored back in the map.
+	iter := v.MapRange()
+	for iter.Next() {
+		val := reflect.New(iter.Value().Type()).Elem()
+		val.Set(iter.Value())
+		if err := w.walk(val, fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+			return err
+		}
+		v.SetMapIndex(iter.Key(), val)
+	}
+	return nil
+}
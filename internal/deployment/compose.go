@@ -0,0 +1,322 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/tuxgal/homelab/internal/compose"
+)
+
+// ComposeExport represents the compose document translated from the
+// containers of the deployment, along with the warnings about the
+// constructs which could not be represented in the compose document.
+type ComposeExport struct {
+	Project  *compose.Project
+	Warnings []string
+}
+
+// ExportCompose translates the specified containers and the bridge mode
+// networks they are attached to into a compose document. Containers not
+// allowed to run on the current host are skipped.
+func (d *Deployment) ExportCompose(ctx context.Context, containers ContainerList) *ComposeExport {
+	res := &ComposeExport{Project: compose.NewProject()}
+
+	exported := make(containerSet)
+	for _, ct := range containers {
+		if !ct.isAllowedOnCurrentHost() {
+			res.warnf("Container %s is not allowed to run on the current host and was skipped", ct.Name())
+			continue
+		}
+		exported[ct.config.Info] = true
+	}
+
+	networks := make(map[string]*Network)
+	for _, ct := range containers {
+		if !exported[ct.config.Info] {
+			continue
+		}
+		log(ctx).Debugf("Exporting container %s to the compose document", ct.Name())
+		res.Project.Services[ct.Name()] = res.composeService(ct, d.dockerConfigs[ct.config.Info], exported)
+		for _, e := range ct.endpoints {
+			if e.network.mode == NetworkModeBridge {
+				networks[e.network.Name()] = e.network
+			}
+		}
+	}
+
+	if len(networks) > 0 {
+		res.Project.Networks = make(map[string]*compose.Network, len(networks))
+		for name, n := range networks {
+			res.Project.Networks[name] = composeNetwork(n)
+		}
+	}
+	return res
+}
+
+func (e *ComposeExport) warnf(format string, args ...any) {
+	e.Warnings = append(e.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (e *ComposeExport) composeService(ct *Container, cdc *containerDockerConfigs, exported containerSet) *compose.Service {
+	cc := cdc.ContainerConfig
+	hc := cdc.HostConfig
+	svc := &compose.Service{
+		ContainerName: ct.Name(),
+		Image:         cc.Image,
+		Hostname:      cc.Hostname,
+		DomainName:    cc.Domainname,
+		User:          cc.User,
+		GroupAdd:      hc.GroupAdd,
+		Tty:           cc.Tty,
+		Entrypoint:    escapeComposeValues(cc.Entrypoint),
+		Command:       escapeComposeValues(cc.Cmd),
+		Environment:   escapeComposeValues(cc.Env),
+		Restart:       composeRestartPolicy(hc.RestartPolicy),
+		StopSignal:    cc.StopSignal,
+		DNS:           hc.DNS,
+		DNSOpt:        hc.DNSOptions,
+		DNSSearch:     hc.DNSSearch,
+		ExtraHosts:    hc.ExtraHosts,
+		ReadOnly:      hc.ReadonlyRootfs,
+		Privileged:    hc.Privileged,
+		CapAdd:        hc.CapAdd,
+		CapDrop:       hc.CapDrop,
+		Sysctls:       hc.Sysctls,
+		Pid:           string(hc.PidMode),
+		Ipc:           string(hc.IpcMode),
+		ShmSize:       hc.ShmSize,
+		OomScoreAdj:   hc.OomScoreAdj,
+		Ports:         composePorts(hc.PortBindings),
+		Init:          hc.Init,
+	}
+	if cc.StopTimeout != nil {
+		svc.StopGracePeriod = fmt.Sprintf("%ds", *cc.StopTimeout)
+	}
+	if len(cc.Labels) > 0 {
+		svc.Labels = make(map[string]string, len(cc.Labels))
+		for k, v := range cc.Labels {
+			svc.Labels[k] = escapeComposeValue(v)
+		}
+	}
+	if len(hc.LogConfig.Type) > 0 {
+		svc.Logging = &compose.Logging{
+			Driver:  hc.LogConfig.Type,
+			Options: hc.LogConfig.Config,
+		}
+	}
+	e.composeHealthCheck(ct, svc, cc.Healthcheck)
+	e.composeNetworks(ct, svc, exported)
+	e.composeVolumes(ct, svc)
+	e.composeResources(ct, svc, &hc.Resources)
+	e.composeDependencies(ct, svc, exported)
+
+	if hc.AutoRemove {
+		e.warnf("Container %s is configured to be removed automatically when it exits, which cannot be represented in the compose document", ct.Name())
+	}
+	if len(ct.config.Lifecycle.StartPreHook) > 0 {
+		e.warnf("Container %s has a start pre-hook, which cannot be represented in the compose document", ct.Name())
+	}
+	if ct.config.Lifecycle.WaitAfterStartDelay > 0 {
+		e.warnf("Container %s has a wait after start delay, which cannot be represented in the compose document", ct.Name())
+	}
+	return svc
+}
+
+func (e *ComposeExport) composeHealthCheck(ct *Container, svc *compose.Service, h *dcontainer.HealthConfig) {
+	if h == nil {
+		return
+	}
+	svc.HealthCheck = &compose.HealthCheck{
+		Test:    escapeComposeValues(h.Test),
+		Retries: h.Retries,
+	}
+	if h.Interval != 0 {
+		svc.HealthCheck.Interval = h.Interval.String()
+	}
+	if h.Timeout != 0 {
+		svc.HealthCheck.Timeout = h.Timeout.String()
+	}
+	if h.StartPeriod != 0 {
+		svc.HealthCheck.StartPeriod = h.StartPeriod.String()
+	}
+	if h.StartInterval != 0 {
+		svc.HealthCheck.StartInterval = h.StartInterval.String()
+	}
+}
+
+func (e *ComposeExport) composeNetworks(ct *Container, svc *compose.Service, exported containerSet) {
+	if len(ct.endpoints) == 0 {
+		svc.NetworkMode = "none"
+		return
+	}
+
+	primary := ct.endpoints[0].network
+	if primary.mode == NetworkModeContainer {
+		target := primary.containerModeInfo.container
+		if exported[target] {
+			svc.NetworkMode = fmt.Sprintf("service:%s", containerName(&target))
+		} else {
+			svc.NetworkMode = fmt.Sprintf("container:%s", containerName(&target))
+		}
+		return
+	}
+
+	svc.Networks = make(map[string]*compose.ServiceNetwork, len(ct.endpoints))
+	for i, ep := range ct.endpoints {
+		sn := &compose.ServiceNetwork{
+			IPv4Address: ep.ipv4,
+			IPv6Address: ep.ipv6,
+		}
+		// Connect to the primary network first, followed by the rest of
+		// the networks in the order of their priority.
+		if len(ct.endpoints) > 1 {
+			sn.Priority = len(ct.endpoints) - i
+		}
+		svc.Networks[ep.network.Name()] = sn
+	}
+}
+
+func (e *ComposeExport) composeVolumes(ct *Container, svc *compose.Service) {
+	for _, m := range ct.mountsOfType("bind") {
+		svc.Volumes = append(svc.Volumes, &compose.Volume{
+			Type:     "bind",
+			Source:   m.src,
+			Target:   m.dst,
+			ReadOnly: m.readOnly,
+		})
+	}
+	for _, m := range ct.mountsOfType("tmpfs") {
+		v := &compose.Volume{
+			Type:   "tmpfs",
+			Target: m.dst,
+		}
+		if m.tmpfsSize > 0 {
+			v.Tmpfs = &compose.TmpfsVolume{Size: m.tmpfsSize}
+		}
+		svc.Volumes = append(svc.Volumes, v)
+	}
+	for _, s := range ct.secretMounts() {
+		e.warnf("Container %s mounts the secret %s from %s, which is written only while starting the container with homelab", ct.Name(), s.name, s.hostPath)
+	}
+}
+
+func (e *ComposeExport) composeResources(ct *Container, svc *compose.Service, r *dcontainer.Resources) {
+	for _, d := range r.Devices {
+		svc.Devices = append(svc.Devices, fmt.Sprintf("%s:%s:%s", d.PathOnHost, d.PathInContainer, d.CgroupPermissions))
+	}
+	if len(ct.config.Filesystem.Devices.DynamicCommand) > 0 {
+		e.warnf("Container %s has dynamic devices, which were exported as evaluated on the current host", ct.Name())
+	}
+
+	svc.MemLimit = r.Memory
+	svc.MemReservation = r.MemoryReservation
+	svc.MemSwapLimit = r.MemorySwap
+	svc.CPUs = float64(r.NanoCPUs) / 1e9
+	svc.CPUShares = r.CPUShares
+	svc.CPUSet = r.CpusetCpus
+	if r.PidsLimit != nil {
+		svc.PidsLimit = *r.PidsLimit
+	}
+	if r.OomKillDisable != nil {
+		svc.OomKillDisable = *r.OomKillDisable
+	}
+	if r.BlkioWeight != 0 {
+		svc.BlkioConfig = &compose.BlkioConfig{Weight: r.BlkioWeight}
+	}
+	if len(r.Ulimits) > 0 {
+		svc.Ulimits = make(map[string]*compose.Ulimit, len(r.Ulimits))
+		for _, u := range r.Ulimits {
+			svc.Ulimits[u.Name] = &compose.Ulimit{Soft: u.Soft, Hard: u.Hard}
+		}
+	}
+}
+
+func (e *ComposeExport) composeDependencies(ct *Container, svc *compose.Service, exported containerSet) {
+	for _, dep := range ct.dependencies {
+		if !exported[dep.config.Info] {
+			e.warnf("Container %s depends on container %s which is not part of the compose document", ct.Name(), dep.Name())
+			continue
+		}
+		svc.DependsOn = append(svc.DependsOn, dep.Name())
+	}
+	slices.Sort(svc.DependsOn)
+}
+
+func composeNetwork(n *Network) *compose.Network {
+	opts := n.createOptions()
+	res := &compose.Network{
+		Name:       n.Name(),
+		Driver:     opts.Driver,
+		DriverOpts: opts.Options,
+		EnableIPv6: n.bridgeModeInfo.enableV6,
+		IPAM:       &compose.IPAM{Driver: opts.IPAM.Driver},
+	}
+	for _, c := range opts.IPAM.Config {
+		res.IPAM.Config = append(res.IPAM.Config, &compose.IPAMConfig{
+			Subnet:  c.Subnet,
+			Gateway: c.Gateway,
+		})
+	}
+	return res
+}
+
+func composeRestartPolicy(p dcontainer.RestartPolicy) string {
+	if p.Name == dcontainer.RestartPolicyOnFailure && p.MaximumRetryCount > 0 {
+		return fmt.Sprintf("%s:%d", p.Name, p.MaximumRetryCount)
+	}
+	return string(p.Name)
+}
+
+func composePorts(pMap nat.PortMap) []string {
+	ports := make([]nat.Port, 0, len(pMap))
+	for p := range pMap {
+		ports = append(ports, p)
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Int() != ports[j].Int() {
+			return ports[i].Int() < ports[j].Int()
+		}
+		return ports[i].Proto() < ports[j].Proto()
+	})
+
+	var res []string
+	for _, p := range ports {
+		for _, b := range pMap[p] {
+			hostIP := b.HostIP
+			if strings.Contains(hostIP, ":") {
+				hostIP = fmt.Sprintf("[%s]", hostIP)
+			}
+			switch {
+			case len(hostIP) > 0:
+				res = append(res, fmt.Sprintf("%s:%s:%s", hostIP, b.HostPort, p))
+			case len(b.HostPort) > 0:
+				res = append(res, fmt.Sprintf("%s:%s", b.HostPort, p))
+			default:
+				res = append(res, string(p))
+			}
+		}
+	}
+	return res
+}
+
+// escapeComposeValue escapes the value to prevent compose from
+// interpolating the variables within it.
+func escapeComposeValue(val string) string {
+	return strings.ReplaceAll(val, "$", "$$")
+}
+
+func escapeComposeValues(vals []string) []string {
+	if vals == nil {
+		return nil
+	}
+	res := make([]string, 0, len(vals))
+	for _, v := range vals {
+		res = append(res, escapeComposeValue(v))
+	}
+	return res
+}
@@ -0,0 +1,213 @@
+package deployment
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/tuxgal/homelab/internal/testhelpers"
+	"github.com/tuxgal/homelab/internal/testutils"
+)
+
+var exportComposeTests = []struct {
+	name         string
+	group        string
+	want         string
+	wantWarnings []string
+}{
+	{
+		name: "All Groups",
+		want: `services:
+  g1-c1:
+    container_name: g1-c1
+    image: abc/xyz:1.0
+    hostname: xyz
+    domainname: example.tld
+    user: 1000:1000
+    command:
+      - --verbose
+    environment:
+      - PASSWORD=pa$$word
+    labels:
+      app: xyz
+    restart: on-failure:3
+    stop_signal: SIGINT
+    stop_grace_period: 10s
+    healthcheck:
+      test:
+        - CMD
+        - /healthcheck
+        - --port
+        - "8080"
+      interval: 30s
+      timeout: 5s
+      retries: 3
+    networks:
+      net1:
+        ipv4_address: 172.18.100.11
+        ipv6_address: fd99:172:18:100::11
+        priority: 1
+      net2:
+        ipv4_address: 172.18.101.11
+        priority: 2
+    ports:
+      - 127.0.0.1:53:53/udp
+      - 127.0.0.1:80:8080/tcp
+    dns:
+      - 1.1.1.1
+    volumes:
+      - type: bind
+        source: /data/xyz
+        target: /data
+      - type: bind
+        source: /etc/xyz
+        target: /config
+        read_only: true
+      - type: bind
+        source: testdata/dummy-base-dir/g1/c1/secrets/db-password
+        target: /run/secrets/db-password
+        read_only: true
+      - type: tmpfs
+        target: /cache
+        tmpfs:
+          size: 10000000
+    devices:
+      - /dev/dri:/dev/dri:rw
+    read_only: true
+    mem_limit: 536870912
+    cpus: 1.5
+    ulimits:
+      nofile:
+        soft: 1024
+        hard: 2048
+    logging:
+      driver: json-file
+      options:
+        max-size: 10m
+  g1-c2:
+    container_name: g1-c2
+    image: abc/sidecar
+    domainname: example.tld
+    depends_on:
+      - g1-c1
+    restart: unless-stopped
+    network_mode: service:g1-c1
+  g2-c3:
+    container_name: g2-c3
+    image: abc/xyz3
+    domainname: example.tld
+    depends_on:
+      - g1-c1
+    restart: unless-stopped
+    networks:
+      net2:
+        ipv4_address: 172.18.101.31
+networks:
+  net1:
+    name: net1
+    driver: bridge
+    driver_opts:
+      com.docker.network.bridge.enable_icc: "true"
+      com.docker.network.bridge.enable_ip_masquerade: "true"
+      com.docker.network.bridge.host_binding_ipv4: 172.18.100.1
+      com.docker.network.bridge.mtu: "1500"
+      com.docker.network.bridge.name: docker-net1
+    enable_ipv6: true
+    ipam:
+      driver: default
+      config:
+        - subnet: 172.18.100.0/24
+          gateway: 172.18.100.1
+        - subnet: fd99:172:18:100::/64
+          gateway: fd99:172:18:100::1
+  net2:
+    name: net2
+    driver: bridge
+    driver_opts:
+      com.docker.network.bridge.enable_icc: "true"
+      com.docker.network.bridge.enable_ip_masquerade: "true"
+      com.docker.network.bridge.host_binding_ipv4: 172.18.101.1
+      com.docker.network.bridge.mtu: "1500"
+      com.docker.network.bridge.name: docker-net2
+    ipam:
+      driver: default
+      config:
+        - subnet: 172.18.101.0/24
+          gateway: 172.18.101.1
+`,
+		wantWarnings: []string{
+			"Container g1-c1 mounts the secret db-password from testdata/dummy-base-dir/g1/c1/secrets/db-password, which is written only while starting the container with homelab",
+			"Container g1-c2 has a start pre-hook, which cannot be represented in the compose document",
+		},
+	},
+	{
+		name:  "Single Group",
+		group: "g2",
+		want: `services:
+  g2-c3:
+    container_name: g2-c3
+    image: abc/xyz3
+    domainname: example.tld
+    restart: unless-stopped
+    networks:
+      net2:
+        ipv4_address: 172.18.101.31
+networks:
+  net2:
+    name: net2
+    driver: bridge
+    driver_opts:
+      com.docker.network.bridge.enable_icc: "true"
+      com.docker.network.bridge.enable_ip_masquerade: "true"
+      com.docker.network.bridge.host_binding_ipv4: 172.18.101.1
+      com.docker.network.bridge.mtu: "1500"
+      com.docker.network.bridge.name: docker-net2
+    ipam:
+      driver: default
+      config:
+        - subnet: 172.18.101.0/24
+          gateway: 172.18.101.1
+`,
+		wantWarnings: []string{
+			"Container g2-c3 depends on container g1-c1 which is not part of the compose document",
+		},
+	},
+}
+
+func TestExportCompose(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range exportComposeTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := testutils.NewVanillaTestContext()
+			p := fmt.Sprintf("%s/testdata/export-compose-cmd", testhelpers.Pwd())
+			dep, gotErr := FromConfigsPath(ctx, p)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfigsPath()", tc.name, gotErr)
+				return
+			}
+
+			var containers ContainerList
+			if len(tc.group) > 0 {
+				containers, gotErr = dep.QueryAllContainersInGroup(ctx, tc.group)
+			} else {
+				containers, gotErr = dep.QueryAllContainersInAllGroups(ctx)
+			}
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "QueryAllContainers()", tc.name, gotErr)
+				return
+			}
+
+			got := dep.ExportCompose(ctx, containers)
+			gotYAML, gotErr := got.Project.YAML()
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "Project.YAML()", tc.name, gotErr)
+				return
+			}
+			testhelpers.CmpDiff(t, "ExportCompose()", tc.name, "compose document", tc.want, string(gotYAML))
+			testhelpers.CmpDiff(t, "ExportCompose()", tc.name, "warnings", tc.wantWarnings, got.Warnings)
+		})
+	}
+}
@@ -2,7 +2,11 @@ package deployment
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math"
 	"slices"
 	"sort"
 	"strings"
@@ -12,15 +16,27 @@ import (
 	dmount "github.com/docker/docker/api/types/mount"
 	dnetwork "github.com/docker/docker/api/types/network"
 	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
 	"github.com/tuxgal/homelab/internal/cmdexec"
 	"github.com/tuxgal/homelab/internal/config"
 	"github.com/tuxgal/homelab/internal/docker"
+	"github.com/tuxgal/homelab/internal/dryrun"
 	"github.com/tuxgal/homelab/internal/utils"
 )
 
 const (
 	// Delay between successive purge (stop and remove) kill attempts.
 	purgeKillDelay = 20 * time.Millisecond
+	// Label holding the hash of the effective configuration the container
+	// was created with.
+	configHashLabel = "homelab.config-hash"
+	// Default timeout in seconds for the container to become healthy.
+	defaultWaitForHealthyTimeout = 120
+	// Delay between successive health checks of the container while
+	// waiting for it to become healthy.
+	waitForHealthyPollInterval = 500 * time.Millisecond
+	// Size representing no limit, applicable only to the memory swap.
+	unlimitedSize = "-1"
 )
 
 type Container struct {
@@ -29,6 +45,8 @@ type Container struct {
 	group         *ContainerGroup
 	endpoints     networkEndpointList
 	allowedOnHost bool
+	dependencies  ContainerList
+	secrets       secretMap
 }
 
 type containerNetworkEndpoint struct {
@@ -37,6 +55,23 @@ type containerNetworkEndpoint struct {
 	ipv6    string
 }
 
+// StartOptions represents the options for starting a container.
+type StartOptions struct {
+	// ChangedOnly skips recreating the container if it is running already
+	// with the same effective configuration and image.
+	ChangedOnly bool
+}
+
+// StopOptions represents the options for stopping a container, which
+// override the configured stop signal and timeout when set.
+type StopOptions struct {
+	// Signal is the signal to stop the container with.
+	Signal string
+	// Timeout is the number of seconds to wait for the container to stop
+	// before killing it, or -1 to wait indefinitely.
+	Timeout *int
+}
+
 type containerDockerConfigs struct {
 	ContainerConfig *dcontainer.Config
 	HostConfig      *dcontainer.HostConfig
@@ -49,13 +84,14 @@ type containerSet map[config.ContainerReference]bool
 type containerMap map[config.ContainerReference]*Container
 type containerDockerConfigMap map[config.ContainerReference]*containerDockerConfigs
 
-func newContainer(group *ContainerGroup, config *config.Container, globalConfig *config.Global, endpoints networkEndpointList, allowedOnHost bool) *Container {
+func newContainer(group *ContainerGroup, config *config.Container, globalConfig *config.Global, endpoints networkEndpointList, allowedOnHost bool, secrets secretMap) *Container {
 	return &Container{
 		config:        config,
 		globalConfig:  globalConfig,
 		group:         group,
 		endpoints:     endpoints,
 		allowedOnHost: allowedOnHost,
+		secrets:       secrets,
 	}
 }
 
@@ -63,7 +99,7 @@ func (c *Container) isAllowedOnCurrentHost() bool {
 	return c.allowedOnHost
 }
 
-func (c *Container) Start(ctx context.Context, dc *docker.Client) (bool, error) {
+func (c *Container) Start(ctx context.Context, dc *docker.Client, opts StartOptions) (bool, error) {
 	log(ctx).Debugf("Starting container %s ...", c.Name())
 
 	// Validate the container is allowed to run on the current host.
@@ -71,7 +107,7 @@ func (c *Container) Start(ctx context.Context, dc *docker.Client) (bool, error)
 		return false, nil
 	}
 
-	err := c.startInternal(ctx, dc)
+	err := c.startInternal(ctx, dc, opts)
 	if err != nil {
 		return false, utils.LogToErrorAndReturn(ctx, "Failed to start container %s, reason:%v", c.Name(), err)
 	}
@@ -81,10 +117,10 @@ func (c *Container) Start(ctx context.Context, dc *docker.Client) (bool, error)
 	return true, nil
 }
 
-func (c *Container) Stop(ctx context.Context, dc *docker.Client) (bool, error) {
+func (c *Container) Stop(ctx context.Context, dc *docker.Client, opts StopOptions) (bool, error) {
 	log(ctx).Debugf("Stopping container %s ...", c.Name())
 
-	stopped, st, err := c.stopInternal(ctx, dc)
+	stopped, st, err := c.stopInternal(ctx, dc, opts)
 	if err != nil {
 		return false, utils.LogToErrorAndReturn(ctx, "Failed to stop container %s, reason:%v", c.Name(), err)
 	}
@@ -107,10 +143,76 @@ func (c *Container) Stop(ctx context.Context, dc *docker.Client) (bool, error) {
 	return stopped, nil
 }
 
-func (c *Container) Purge(ctx context.Context, dc *docker.Client) (bool, error) {
+func (c *Container) Restart(ctx context.Context, dc *docker.Client) (bool, error) {
+	log(ctx).Debugf("Restarting container %s ...", c.Name())
+
+	restarted, err := c.restartInternal(ctx, dc)
+	if err != nil {
+		return false, utils.LogToErrorAndReturn(ctx, "Failed to restart container %s, reason:%v", c.Name(), err)
+	}
+
+	if restarted {
+		log(ctx).Debugf("Restarted container %s", c.Name())
+		log(ctx).InfoEmpty()
+	}
+
+	return restarted, nil
+}
+
+func (c *Container) Pause(ctx context.Context, dc *docker.Client) (bool, error) {
+	log(ctx).Debugf("Pausing container %s ...", c.Name())
+
+	paused, st, err := c.pauseInternal(ctx, dc)
+	if err != nil {
+		return false, utils.LogToErrorAndReturn(ctx, "Failed to pause container %s, reason:%v", c.Name(), err)
+	}
+
+	if paused {
+		log(ctx).Debugf("Paused container %s", c.Name())
+	} else {
+		switch st {
+		case docker.ContainerStateNotFound:
+			log(ctx).Warnf("Container %s cannot be paused since it was not found", c.Name())
+		case docker.ContainerStatePaused:
+			log(ctx).Warnf("Container %s is already paused", c.Name())
+		default:
+			log(ctx).Warnf("Container %s cannot be paused since it is in state %s", c.Name(), st)
+		}
+	}
+	log(ctx).InfoEmpty()
+
+	return paused, nil
+}
+
+func (c *Container) Unpause(ctx context.Context, dc *docker.Client) (bool, error) {
+	log(ctx).Debugf("Unpausing container %s ...", c.Name())
+
+	unpaused, st, err := c.unpauseInternal(ctx, dc)
+	if err != nil {
+		return false, utils.LogToErrorAndReturn(ctx, "Failed to unpause container %s, reason:%v", c.Name(), err)
+	}
+
+	if unpaused {
+		log(ctx).Debugf("Unpaused container %s", c.Name())
+	} else {
+		if st == docker.ContainerStateNotFound {
+			log(ctx).Warnf("Container %s cannot be unpaused since it was not found", c.Name())
+		} else {
+			log(ctx).Warnf("Container %s cannot be unpaused since it is in state %s", c.Name(), st)
+		}
+	}
+	log(ctx).InfoEmpty()
+
+	return unpaused, nil
+}
+
+func (c *Container) Purge(ctx context.Context, dc *docker.Client, opts StopOptions) (bool, error) {
 	log(ctx).Debugf("Purging container %s ...", c.Name())
 
-	purged, err := c.purgeInternal(ctx, dc)
+	purged, err := c.purgeInternal(ctx, dc, opts)
+	if err == nil {
+		err = c.removeSecrets(ctx)
+	}
 	if err != nil {
 		return false, utils.LogToErrorAndReturn(ctx, "Failed to purge container %s, reason:%v", c.Name(), err)
 	}
@@ -123,16 +225,14 @@ func (c *Container) Purge(ctx context.Context, dc *docker.Client) (bool, error)
 	return purged, nil
 }
 
-func (c *Container) startInternal(ctx context.Context, dc *docker.Client) error {
-	// 1. Execute start pre-hook command if specified.
-	if len(c.config.Lifecycle.StartPreHook) > 0 {
-		log(ctx).Infof("Output from start pre-hook for container %s >>>", c.Name())
-		cmd := c.config.Lifecycle.StartPreHook
-		exec := cmdexec.MustExecutor(ctx)
-		out, err := exec.Run(cmd[0], cmd[1:]...)
-		log(ctx).Printf("%s", strings.TrimSpace(out))
+func (c *Container) startInternal(ctx context.Context, dc *docker.Client, opts StartOptions) error {
+	// 1. Execute start pre-hook command if specified. In changed only mode,
+	// the pre-hook is executed only after determining that the container
+	// needs to be (re)created.
+	if !opts.ChangedOnly {
+		err := c.execStartPreHook(ctx)
 		if err != nil {
-			return fmt.Errorf("encountered error while running the start pre-hook for container %s, reason: %w", c.Name(), err)
+			return err
 		}
 	}
 
@@ -147,9 +247,38 @@ func (c *Container) startInternal(ctx context.Context, dc *docker.Client) error
 		}
 	}
 
+	secrets, err := c.resolveSecrets(ctx)
+	if err != nil {
+		return err
+	}
+	cdc := c.generateDockerConfigs()
+	hash, err := c.configHash(ctx, dc, cdc)
+	if err != nil {
+		return err
+	}
+	if opts.ChangedOnly {
+		upToDate, err := c.isUpToDate(ctx, dc, hash)
+		if err != nil {
+			return err
+		}
+		if upToDate && !c.secretsUpToDate(ctx, secrets) {
+			log(ctx).Debugf("Container %s needs to be recreated since its secrets changed", c.Name())
+			upToDate = false
+		}
+		if upToDate {
+			log(ctx).Infof("Container %s is up to date, skipping", c.Name())
+			return nil
+		}
+		err = c.execStartPreHook(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	// 3. Purge (i.e. stop and remove) any previously existing containers
-	// under the same name.
-	purged, err := c.purgeInternal(ctx, dc)
+	// under the same name, stopping them using the configured stop signal
+	// and timeout.
+	purged, err := c.purgeInternal(ctx, dc, StopOptions{Signal: c.stopSignal(), Timeout: c.stopTimeout()})
 	if err != nil {
 		return err
 	}
@@ -157,7 +286,14 @@ func (c *Container) startInternal(ctx context.Context, dc *docker.Client) error
 		log(ctx).Debugf("Purged container %s", c.Name())
 	}
 
-	// 4. For the primary network interface of the container, create
+	// 4. Write the secrets of the container to the files bind mounted
+	// into the container.
+	err = c.materializeSecrets(ctx, secrets)
+	if err != nil {
+		return err
+	}
+
+	// 5. For the primary network interface of the container, create
 	// the network for the container prior to creating the container
 	// attached to this network.
 	if len(c.endpoints) > 0 {
@@ -179,15 +315,15 @@ func (c *Container) startInternal(ctx context.Context, dc *docker.Client) error
 		log(ctx).Warnf("Container %s has no network endpoints configured, this is uncommon!", c.Name())
 	}
 
-	// 5. Create the container.
+	// 6. Create the container.
 	log(ctx).Infof("Creating container %s", c.Name())
-	cdc := c.generateDockerConfigs()
+	cdc.ContainerConfig.Labels = withConfigHashLabel(cdc.ContainerConfig.Labels, hash)
 	err = dc.CreateContainer(ctx, c.Name(), cdc.ContainerConfig, cdc.HostConfig, cdc.NetworkConfig)
 	if err != nil {
 		return err
 	}
 
-	// 6. For each non-primary network interface of the container, create
+	// 7. For each non-primary network interface of the container, create
 	// the network for the container if it doesn't exist already prior to
 	// connecting the container to the network.
 	for i := 1; i < len(c.endpoints); i++ {
@@ -202,24 +338,134 @@ func (c *Container) startInternal(ctx context.Context, dc *docker.Client) error
 		}
 	}
 
-	// 7. Start the created container.
+	// 8. Start the created container.
 	log(ctx).Infof("Starting container %s", c.Name())
 	err = dc.StartContainer(ctx, c.Name())
 	if err != nil {
 		return err
 	}
 
+	// 9. Wait for the container to become healthy if requested.
+	if c.config.Lifecycle.WaitForHealthy {
+		err = c.waitForHealthy(ctx, dc)
+		if err != nil {
+			return err
+		}
+	}
+
 	delay := c.waitAfterStartDelay()
 	if delay != 0 {
 		wait := time.Duration(delay) * time.Second
-		log(ctx).Infof("Waiting for %v after container startup of %s", wait, c.Name())
-		time.Sleep(wait)
+		if rec, ok := dryrun.RecorderFromContext(ctx); ok {
+			rec.Record("Wait for %v after starting container %s", wait, c.Name())
+		} else {
+			log(ctx).Infof("Waiting for %v after container startup of %s", wait, c.Name())
+			time.Sleep(wait)
+		}
+	}
+
+	return nil
+}
+
+func (c *Container) execStartPreHook(ctx context.Context) error {
+	if len(c.config.Lifecycle.StartPreHook) == 0 {
+		return nil
 	}
 
+	log(ctx).Infof("Output from start pre-hook for container %s >>>", c.Name())
+	cmd := c.config.Lifecycle.StartPreHook
+	exec := cmdexec.MustExecutor(ctx)
+	out, err := exec.Run(cmd[0], cmd[1:]...)
+	log(ctx).Printf("%s", strings.TrimSpace(out))
+	if err != nil {
+		return fmt.Errorf("encountered error while running the start pre-hook for container %s, reason: %w", c.Name(), err)
+	}
 	return nil
 }
 
-func (c *Container) stopInternal(ctx context.Context, dc *docker.Client) (bool, docker.ContainerState, error) {
+// configHash returns the hash of the effective configuration of the
+// container, comprising the generated docker configs, all the network
+// endpoints and the ID of the locally available image the container
+// would be created from. The secret values are never included since
+// the hash is visible to anyone who can inspect the container.
+func (c *Container) configHash(ctx context.Context, dc *docker.Client, cdc *containerDockerConfigs) (string, error) {
+	_, imageID := dc.QueryLocalImage(ctx, c.imageReference())
+	endpoints := make([]string, 0, len(c.endpoints))
+	for _, e := range c.endpoints {
+		endpoints = append(endpoints, fmt.Sprintf("%s/%s/%s", e.network.Name(), e.ipv4, e.ipv6))
+	}
+
+	b, err := json.Marshal(struct {
+		Configs   *containerDockerConfigs
+		Endpoints []string
+		ImageID   string
+	}{
+		Configs:   cdc,
+		Endpoints: endpoints,
+		ImageID:   imageID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to compute the config hash for container %s, reason: %w", c.Name(), err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// isUpToDate returns true if the container is running already with the
+// effective configuration matching the specified hash.
+func (c *Container) isUpToDate(ctx context.Context, dc *docker.Client, hash string) (bool, error) {
+	info, err := dc.GetContainerInfo(ctx, c.Name())
+	if err != nil {
+		return false, err
+	}
+	if info.State != docker.ContainerStateRunning {
+		log(ctx).Debugf("Container %s needs to be started since it is in state %s", c.Name(), info.State)
+		return false, nil
+	}
+	if info.Labels[configHashLabel] != hash {
+		log(ctx).Debugf("Container %s needs to be recreated since its configuration or image changed", c.Name())
+		return false, nil
+	}
+	return true, nil
+}
+
+func (c *Container) waitForHealthy(ctx context.Context, dc *docker.Client) error {
+	timeout := time.Duration(c.waitForHealthyTimeout()) * time.Second
+	if rec, ok := dryrun.RecorderFromContext(ctx); ok {
+		rec.Record("Wait for up to %v for container %s to become healthy", timeout, c.Name())
+		return nil
+	}
+
+	log(ctx).Infof("Waiting for up to %v for container %s to become healthy", timeout, c.Name())
+	deadline := time.Now().Add(timeout)
+	for {
+		info, err := dc.GetContainerInfo(ctx, c.Name())
+		if err != nil {
+			return err
+		}
+		if info.State != docker.ContainerStateRunning {
+			return fmt.Errorf("container %s is in state %s while waiting for it to become healthy", c.Name(), info.State)
+		}
+		log(ctx).Debugf("waitForHealthy - Container %s current health: %s", c.Name(), info.Health)
+
+		switch info.Health {
+		case docker.ContainerHealthHealthy:
+			log(ctx).Infof("Container %s is healthy", c.Name())
+			return nil
+		case docker.ContainerHealthUnhealthy:
+			return fmt.Errorf("container %s became unhealthy while waiting for it to become healthy", c.Name())
+		case docker.ContainerHealthNone:
+			return fmt.Errorf("container %s has no health check to wait for", c.Name())
+		}
+
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("timed out after %v waiting for container %s to become healthy", timeout, c.Name())
+		}
+		time.Sleep(waitForHealthyPollInterval)
+	}
+}
+
+func (c *Container) stopInternal(ctx context.Context, dc *docker.Client, opts StopOptions) (bool, docker.ContainerState, error) {
 	st, err := dc.GetContainerState(ctx, c.Name())
 	if err != nil {
 		return false, docker.ContainerStateUnknown, err
@@ -244,7 +490,8 @@ func (c *Container) stopInternal(ctx context.Context, dc *docker.Client) (bool,
 
 		// Stop the container.
 		log(ctx).Infof("Stopping container %s", c.Name())
-		if err := dc.StopContainer(ctx, c.Name()); err != nil {
+		signal, timeout := c.effectiveStopSignalAndTimeout(opts)
+		if err := dc.StopContainer(ctx, c.Name(), signal, timeout); err != nil {
 			return false, st, err
 		}
 		return true, st, nil
@@ -258,9 +505,66 @@ func (c *Container) stopInternal(ctx context.Context, dc *docker.Client) (bool,
 	return false, st, fmt.Errorf("failed to stop container %s since it is in state %s", c.Name(), st)
 }
 
-func (c *Container) purgeInternal(ctx context.Context, dc *docker.Client) (bool, error) {
+func (c *Container) restartInternal(ctx context.Context, dc *docker.Client) (bool, error) {
+	st, err := dc.GetContainerState(ctx, c.Name())
+	if err != nil {
+		return false, err
+	}
+	if st == docker.ContainerStateNotFound {
+		return false, nil
+	}
+
+	// Restart the container in place without recreating it, stopping it
+	// first using the configured stop signal and timeout.
+	log(ctx).Infof("Restarting container %s", c.Name())
+	err = dc.RestartContainer(ctx, c.Name(), c.stopSignal(), c.stopTimeout())
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Container) pauseInternal(ctx context.Context, dc *docker.Client) (bool, docker.ContainerState, error) {
+	st, err := dc.GetContainerState(ctx, c.Name())
+	if err != nil {
+		return false, docker.ContainerStateUnknown, err
+	}
+	log(ctx).Debugf("pauseInternal - Container %s current state: %s", c.Name(), st)
+
+	// Only running containers can be paused.
+	if st != docker.ContainerStateRunning {
+		return false, st, nil
+	}
+
+	log(ctx).Infof("Pausing container %s", c.Name())
+	if err := dc.PauseContainer(ctx, c.Name()); err != nil {
+		return false, st, err
+	}
+	return true, st, nil
+}
+
+func (c *Container) unpauseInternal(ctx context.Context, dc *docker.Client) (bool, docker.ContainerState, error) {
+	st, err := dc.GetContainerState(ctx, c.Name())
+	if err != nil {
+		return false, docker.ContainerStateUnknown, err
+	}
+	log(ctx).Debugf("unpauseInternal - Container %s current state: %s", c.Name(), st)
+
+	// Only paused containers can be unpaused.
+	if st != docker.ContainerStatePaused {
+		return false, st, nil
+	}
+
+	log(ctx).Infof("Unpausing container %s", c.Name())
+	if err := dc.UnpauseContainer(ctx, c.Name()); err != nil {
+		return false, st, err
+	}
+	return true, st, nil
+}
+
+func (c *Container) purgeInternal(ctx context.Context, dc *docker.Client, opts StopOptions) (bool, error) {
 	// Stop the container once (if possible).
-	stopped, _, err := c.stopInternal(ctx, dc)
+	stopped, _, err := c.stopInternal(ctx, dc, opts)
 	if err != nil {
 		return false, err
 	}
@@ -272,14 +576,15 @@ func (c *Container) purgeInternal(ctx context.Context, dc *docker.Client) (bool,
 	}
 
 	purged := false
-	// We will attempt killing the container attemptsRemaining times
-	// in the worst case before giving up. The one extra attempt is to
-	// remove the container which has been terminated.
-	stopAndRemoveAttempts := dc.ContainerPurgeKillAttempts() + 1
-	attemptsRemaining := stopAndRemoveAttempts
+	// We will keep attempting to kill the container every purgeKillDelay
+	// until the grace period elapses in the worst case before giving up.
+	// The one extra attempt after the grace period elapses is to remove
+	// the container which has been terminated.
+	gracePeriod := c.purgeGracePeriod(dc)
+	deadline := time.Now().Add(gracePeriod)
 
-	for !purged && attemptsRemaining > 0 {
-		attemptsRemaining--
+	for !purged {
+		lastAttempt := !time.Now().Before(deadline)
 
 		st, err := dc.GetContainerState(ctx, c.Name())
 		if err != nil {
@@ -297,7 +602,7 @@ func (c *Container) purgeInternal(ctx context.Context, dc *docker.Client) (bool,
 			log(ctx).Infof("Killing container %s", c.Name())
 			_ = dc.KillContainer(ctx, c.Name())
 			// Add a delay before checking the container state again.
-			time.Sleep(purgeKillDelay)
+			time.Sleep(min(purgeKillDelay, time.Until(deadline)))
 		case docker.ContainerStateCreated, docker.ContainerStateExited, docker.ContainerStateDead:
 			// Directly remove the container.
 			log(ctx).Infof("Removing container %s", c.Name())
@@ -310,10 +615,14 @@ func (c *Container) purgeInternal(ctx context.Context, dc *docker.Client) (bool,
 			// unknown handling in next steps.
 			log(ctx).Warnf("container %s is in REMOVING state already, can lead to issues for any further operations including creating container with the same name", c.Name())
 			// Add a delay before checking the container state again.
-			time.Sleep(purgeKillDelay)
+			time.Sleep(min(purgeKillDelay, time.Until(deadline)))
 		default:
 			log(ctx).Fatalf("container %s is in an unsupported state %v, possibly indicating a bug in the code", c.Name(), st)
 		}
+
+		if lastAttempt {
+			break
+		}
 	}
 
 	if purged {
@@ -327,7 +636,7 @@ func (c *Container) purgeInternal(ctx context.Context, dc *docker.Client) (bool,
 		return false, err
 	}
 	if st != docker.ContainerStateNotFound {
-		return false, fmt.Errorf("failed to purge container %s after %d attempts", c.Name(), stopAndRemoveAttempts)
+		return false, fmt.Errorf("failed to purge container %s within the grace period of %s", c.Name(), gracePeriod)
 	}
 	return true, nil
 }
@@ -347,7 +656,7 @@ func (c *Container) dockerContainerConfig(pSet nat.PortSet) *dcontainer.Config {
 		Domainname:      c.domainName(),
 		User:            c.userAndGroup(),
 		ExposedPorts:    pSet,
-		Tty:             c.attachToTty(),
+		Tty:             c.AttachToTty(),
 		Env:             c.envVars(),
 		Cmd:             c.args(),
 		Healthcheck:     c.healthCheck(),
@@ -380,6 +689,11 @@ func (c *Container) dockerHostConfig(pMap nat.PortMap) *dcontainer.HostConfig {
 		ShmSize:        c.shmSize(),
 		Sysctls:        c.sysctls(),
 		Resources:      c.resources(),
+		LogConfig:      c.logConfig(),
+		Init:           c.initProcess(),
+		OomScoreAdj:    c.oomScoreAdj(),
+		PidMode:        dcontainer.PidMode(c.config.Security.PidMode),
+		IpcMode:        dcontainer.IpcMode(c.config.Security.IpcMode),
 		Mounts:         c.nonBindMounts(),
 	}
 }
@@ -398,6 +712,11 @@ func (c *Container) Name() string {
 	return containerName(&c.config.Info)
 }
 
+// Reference returns the group and the container name of the container.
+func (c *Container) Reference() config.ContainerReference {
+	return c.config.Info
+}
+
 func (c *Container) hostName() string {
 	return c.config.Network.HostName
 }
@@ -419,7 +738,9 @@ func (c *Container) userAndGroup() string {
 	return u
 }
 
-func (c *Container) attachToTty() bool {
+// AttachToTty returns true if the container is configured to be
+// attached to a TTY.
+func (c *Container) AttachToTty() bool {
 	return c.config.Runtime.AttachToTty
 }
 
@@ -509,8 +830,20 @@ func (c *Container) labels() map[string]string {
 	return res
 }
 
+func withConfigHashLabel(labels map[string]string, hash string) map[string]string {
+	res := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		res[k] = v
+	}
+	res[configHashLabel] = hash
+	return res
+}
+
 func (c *Container) stopSignal() string {
-	return c.config.Lifecycle.StopSignal
+	if len(c.config.Lifecycle.StopSignal) > 0 {
+		return c.config.Lifecycle.StopSignal
+	}
+	return c.globalConfig.Container.StopSignal
 }
 
 func (c *Container) stopTimeout() *int {
@@ -524,10 +857,42 @@ func (c *Container) stopTimeout() *int {
 	return &t
 }
 
+// effectiveStopSignalAndTimeout returns the stop signal and timeout
+// specified in the options, falling back to the configured ones.
+func (c *Container) effectiveStopSignalAndTimeout(opts StopOptions) (string, *int) {
+	signal := opts.Signal
+	if len(signal) == 0 {
+		signal = c.stopSignal()
+	}
+	timeout := opts.Timeout
+	if timeout == nil {
+		timeout = c.stopTimeout()
+	}
+	return signal, timeout
+}
+
+func (c *Container) purgeGracePeriod(dc *docker.Client) time.Duration {
+	p := c.config.Lifecycle.PurgeGracePeriod
+	if p == 0 {
+		p = c.globalConfig.Container.PurgeGracePeriod
+	}
+	if p == 0 {
+		return dc.ContainerPurgeGracePeriod()
+	}
+	return time.Duration(p) * time.Second
+}
+
 func (c *Container) waitAfterStartDelay() int {
 	return c.config.Lifecycle.WaitAfterStartDelay
 }
 
+func (c *Container) waitForHealthyTimeout() int {
+	if c.config.Lifecycle.WaitForHealthyTimeout > 0 {
+		return c.config.Lifecycle.WaitForHealthyTimeout
+	}
+	return defaultWaitForHealthyTimeout
+}
+
 func (c *Container) imageReference() string {
 	return c.config.Image.Image
 }
@@ -687,8 +1052,123 @@ func (c *Container) resources() dcontainer.Resources {
 		m.CgroupPermissions = perms.String()
 		devs = append(devs, m)
 	}
-	return dcontainer.Resources{
-		Devices: devs,
+
+	r := effectiveResources(&c.config.Resources, &c.globalConfig.Container.Resources)
+	res := dcontainer.Resources{
+		Devices:           devs,
+		Memory:            ramInBytes(r.Memory),
+		MemoryReservation: ramInBytes(r.MemoryReservation),
+		MemorySwap:        ramInBytes(r.MemorySwap),
+		NanoCPUs:          int64(math.Round(r.CPUs * 1e9)),
+		CPUShares:         r.CPUShares,
+		CpusetCpus:        r.CPUSet,
+		BlkioWeight:       r.BlkioWeight,
+	}
+	if r.PidsLimit != 0 {
+		res.PidsLimit = &r.PidsLimit
+	}
+	if c.config.Runtime.OOMKillDisable {
+		res.OomKillDisable = &c.config.Runtime.OOMKillDisable
+	}
+	res.Ulimits = c.ulimits()
+	return res
+}
+
+func (c *Container) ulimits() []*dcontainer.Ulimit {
+	var res []*dcontainer.Ulimit
+	for _, u := range c.config.Runtime.Ulimits {
+		res = append(res, mustParseUlimit(&u))
+	}
+	return res
+}
+
+// initProcess returns nil when init is not specified in the config,
+// leaving it to the docker daemon default.
+func (c *Container) initProcess() *bool {
+	return c.config.Runtime.Init
+}
+
+func (c *Container) oomScoreAdj() int {
+	return c.config.Runtime.OOMScoreAdj
+}
+
+func ulimitSpec(u *config.Ulimit) string {
+	if u.Hard == 0 {
+		return fmt.Sprintf("%s=%d", u.Name, u.Soft)
+	}
+	return fmt.Sprintf("%s=%d:%d", u.Name, u.Soft, u.Hard)
+}
+
+func mustParseUlimit(u *config.Ulimit) *dcontainer.Ulimit {
+	res, err := units.ParseUlimit(ulimitSpec(u))
+	if err != nil {
+		panic(fmt.Sprintf("unable to parse ulimit %s, reason: %v, possibly indicating a bug in the code", u.Name, err))
+	}
+	return res
+}
+
+// effectiveResources returns the resource limits with every limit that
+// is not set for the container falling back to the global default.
+func effectiveResources(conf *config.ContainerResources, globalConf *config.ContainerResources) *config.ContainerResources {
+	res := *conf
+	if len(res.Memory) == 0 {
+		res.Memory = globalConf.Memory
+	}
+	if len(res.MemoryReservation) == 0 {
+		res.MemoryReservation = globalConf.MemoryReservation
+	}
+	if len(res.MemorySwap) == 0 {
+		res.MemorySwap = globalConf.MemorySwap
+	}
+	if res.CPUs == 0 {
+		res.CPUs = globalConf.CPUs
+	}
+	if res.CPUShares == 0 {
+		res.CPUShares = globalConf.CPUShares
+	}
+	if len(res.CPUSet) == 0 {
+		res.CPUSet = globalConf.CPUSet
+	}
+	if res.PidsLimit == 0 {
+		res.PidsLimit = globalConf.PidsLimit
+	}
+	if res.BlkioWeight == 0 {
+		res.BlkioWeight = globalConf.BlkioWeight
+	}
+	return &res
+}
+
+// ramInBytes returns the size in bytes, treating the empty size as unset
+// and -1 as unlimited.
+func ramInBytes(size string) int64 {
+	switch size {
+	case "":
+		return 0
+	case unlimitedSize:
+		return -1
+	}
+	return utils.MustParseRAMInBytes(size)
+}
+
+func (c *Container) logConfig() dcontainer.LogConfig {
+	logging := &c.config.Logging
+	if len(logging.Driver) == 0 {
+		logging = &c.globalConfig.Container.Logging
+	}
+	if len(logging.Driver) == 0 {
+		return dcontainer.LogConfig{}
+	}
+
+	var opts map[string]string
+	if len(logging.Options) > 0 {
+		opts = make(map[string]string, len(logging.Options))
+		for _, o := range logging.Options {
+			opts[o.Name] = o.Value
+		}
+	}
+	return dcontainer.LogConfig{
+		Type:   logging.Driver,
+		Config: opts,
 	}
 }
 
@@ -762,11 +1242,121 @@ func containerMapToList(cm containerMap) ContainerList {
 			return c1.group.config.Order < c2.group.config.Order
 		}
 	})
+	return orderByDependencies(res, false)
+}
+
+// orderByDependencies returns the containers reordered such that every
+// container appears after all its dependencies present in the list, or
+// after all its dependents present in the list if reverse is true. The
+// relative order of the containers in the list is retained otherwise.
+func orderByDependencies(list ContainerList, reverse bool) ContainerList {
+	inList := make(map[*Container]bool, len(list))
+	for _, c := range list {
+		inList[c] = true
+	}
+
+	// For every container, track the number of containers in the list it
+	// needs to wait for, and the containers in the list waiting on it.
+	pending := make(map[*Container]int, len(list))
+	waiting := make(map[*Container]ContainerList, len(list))
+	for _, c := range list {
+		for _, d := range c.dependencies {
+			if !inList[d] {
+				continue
+			}
+			if reverse {
+				pending[d]++
+				waiting[c] = append(waiting[c], d)
+			} else {
+				pending[c]++
+				waiting[d] = append(waiting[d], c)
+			}
+		}
+	}
+
+	res := make(ContainerList, 0, len(list))
+	done := make(map[*Container]bool, len(list))
+	for len(res) < len(list) {
+		var next *Container
+		for _, c := range list {
+			if !done[c] && pending[c] == 0 {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			// Dependency cycles are rejected while validating the config,
+			// hence this is never expected to happen.
+			panic("dependency cycle found while ordering containers, possibly indicating a bug in the code")
+		}
+		done[next] = true
+		res = append(res, next)
+		for _, w := range waiting[next] {
+			pending[w]--
+		}
+	}
 	return res
 }
 
+// InStopOrder returns the containers reordered such that every container
+// appears before all its dependencies present in the list.
+func (c ContainerList) InStopOrder() ContainerList {
+	return orderByDependencies(c, true)
+}
+
+// OrderLevels splits the containers into consecutive levels, where every
+// level holds the adjacent containers in the list sharing the same group
+// and container order, none of which depend on each other. The containers
+// within a level can hence be acted upon concurrently, while the levels
+// themselves still need to be acted upon in order.
+func (c ContainerList) OrderLevels() []ContainerList {
+	var res []ContainerList
+	var level ContainerList
+	for _, ct := range c {
+		if len(level) > 0 && (!level[0].sameOrder(ct) || level.related(ct)) {
+			res = append(res, level)
+			level = nil
+		}
+		level = append(level, ct)
+	}
+	if len(level) > 0 {
+		res = append(res, level)
+	}
+	return res
+}
+
+func (c ContainerList) related(ct *Container) bool {
+	for _, other := range c {
+		if other.reliesOn(ct) || ct.reliesOn(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// reliesOn returns true if the container either depends on the other
+// container, or shares the network stack of the other container.
+func (c *Container) reliesOn(other *Container) bool {
+	if slices.Contains(c.dependencies, other) {
+		return true
+	}
+	for _, e := range c.endpoints {
+		if e.network.mode == NetworkModeContainer && containerName(&e.network.containerModeInfo.container) == other.Name() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Container) sameOrder(other *Container) bool {
+	return c.group.config.Order == other.group.config.Order && c.config.Lifecycle.Order == other.config.Lifecycle.Order
+}
+
 type mountSpec struct {
 	spec      string
+	src       string
+	dst       string
+	readOnly  bool
 	tmpfsSize int64
 }
 
@@ -809,18 +1399,32 @@ func (c *Container) mountsOfType(mountType string) []*mountSpec {
 	for _, mount := range containerMountNames {
 		res = append(res, containerMounts[mount])
 	}
+
+	// Secrets are always bind mounted read-only after all the other
+	// mounts.
+	if mountType == "bind" {
+		for _, s := range c.secretMounts() {
+			res = append(res, &mountSpec{
+				spec:     fmt.Sprintf("%s:%s:ro", s.hostPath, s.target),
+				src:      s.hostPath,
+				dst:      s.target,
+				readOnly: true,
+			})
+		}
+	}
 	return res
 }
 
 func buildMountSpec(mount *config.Mount) *mountSpec {
 	switch mount.Type {
 	case "bind":
+		spec := fmt.Sprintf("%s:%s", mount.Src, mount.Dst)
 		if mount.ReadOnly {
-			return &mountSpec{spec: fmt.Sprintf("%s:%s:ro", mount.Src, mount.Dst)}
+			spec = fmt.Sprintf("%s:ro", spec)
 		}
-		return &mountSpec{spec: fmt.Sprintf("%s:%s", mount.Src, mount.Dst)}
+		return &mountSpec{spec: spec, src: mount.Src, dst: mount.Dst, readOnly: mount.ReadOnly}
 	case "tmpfs":
-		return &mountSpec{spec: mount.Dst, tmpfsSize: mount.TmpfsSize}
+		return &mountSpec{spec: mount.Dst, dst: mount.Dst, tmpfsSize: mount.TmpfsSize}
 	default:
 		panic(fmt.Sprintf("invalid mount type %s", mount.Type))
 	}
@@ -0,0 +1,46 @@
+package deployment
+
+import (
+	"context"
+	"io"
+
+	"github.com/tuxgal/homelab/internal/docker"
+	"github.com/tuxgal/homelab/internal/utils"
+)
+
+// ExecOptions represents the options for running a command inside a
+// container.
+type ExecOptions struct {
+	// User is the user (and optionally the group) to run the command
+	// as, defaults to the configured container user when empty.
+	User string
+	// Tty attaches a TTY to the command.
+	Tty bool
+}
+
+// Exec runs the command inside the running container. The command is
+// run as the configured container user, unless a user is specified.
+// Returns the exit code of the command.
+func (c *Container) Exec(ctx context.Context, dc *docker.Client, cmd []string, opts ExecOptions, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	st, err := dc.GetContainerState(ctx, c.Name())
+	if err != nil {
+		return 0, utils.LogToErrorAndReturn(ctx, "Failed to execute command in container %s, reason:%v", c.Name(), err)
+	}
+	if st != docker.ContainerStateRunning {
+		return 0, utils.LogToErrorAndReturn(ctx, "Failed to execute command in container %s, reason:container is not running (state: %s)", c.Name(), st)
+	}
+
+	user := opts.User
+	if len(user) == 0 {
+		user = c.userAndGroup()
+	}
+	exitCode, err := dc.ExecInContainer(ctx, c.Name(), docker.ContainerExecOptions{
+		Cmd:  cmd,
+		User: user,
+		Tty:  opts.Tty,
+	}, stdin, stdout, stderr)
+	if err != nil {
+		return 0, utils.LogToErrorAndReturn(ctx, "Failed to execute command in container %s, reason:%v", c.Name(), err)
+	}
+	return exitCode, nil
+}
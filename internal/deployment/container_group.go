@@ -23,8 +23,8 @@ func NewContainerGroup(groupConfig *config.ContainerGroup) *ContainerGroup {
 	}
 }
 
-func (c *ContainerGroup) addContainer(config *config.Container, globalConfig *config.Global, endpoints networkEndpointList, isAllowedOnCurrentHost bool) {
-	ct := newContainer(c, config, globalConfig, endpoints, isAllowedOnCurrentHost)
+func (c *ContainerGroup) addContainer(config *config.Container, globalConfig *config.Global, endpoints networkEndpointList, isAllowedOnCurrentHost bool, secrets secretMap) {
+	ct := newContainer(c, config, globalConfig, endpoints, isAllowedOnCurrentHost, secrets)
 	c.containers[config.Info] = ct
 }
 
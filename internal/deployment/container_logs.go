@@ -0,0 +1,27 @@
+package deployment
+
+import (
+	"context"
+	"io"
+
+	"github.com/tuxgal/homelab/internal/docker"
+	"github.com/tuxgal/homelab/internal/utils"
+)
+
+// Logs writes the logs of the container to the specified writer. Returns
+// false if the container was not found on the docker host.
+func (c *Container) Logs(ctx context.Context, dc *docker.Client, opts docker.ContainerLogsOptions, w io.Writer) (bool, error) {
+	st, err := dc.GetContainerState(ctx, c.Name())
+	if err != nil {
+		return false, utils.LogToErrorAndReturn(ctx, "Failed to retrieve logs of container %s, reason:%v", c.Name(), err)
+	}
+	if st == docker.ContainerStateNotFound {
+		return false, nil
+	}
+
+	err = dc.ContainerLogs(ctx, c.Name(), opts, w, w)
+	if err != nil {
+		return false, utils.LogToErrorAndReturn(ctx, "Failed to retrieve logs of container %s, reason:%v", c.Name(), err)
+	}
+	return true, nil
+}
@@ -0,0 +1,165 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tuxgal/homelab/internal/docker"
+	"github.com/tuxgal/homelab/internal/utils"
+)
+
+// ContainerStatus represents the configured state of a container in the
+// deployment alongside its live state on the docker host.
+type ContainerStatus struct {
+	Name          string
+	AllowedOnHost bool
+	WantState     docker.ContainerState
+	State         docker.ContainerState
+	WantImage     string
+	Image         string
+	Endpoints     []*ContainerEndpointStatus
+}
+
+// ContainerEndpointStatus represents the configured and the live IP
+// addresses of a container's network endpoint.
+type ContainerEndpointStatus struct {
+	Network  string
+	WantIPv4 string
+	WantIPv6 string
+	IPv4     string
+	IPv6     string
+}
+
+// Status returns the configured state of the container alongside its
+// live state on the docker host.
+func (c *Container) Status(ctx context.Context, dc *docker.Client) (*ContainerStatus, error) {
+	info, err := dc.GetContainerInfo(ctx, c.Name())
+	if err != nil {
+		return nil, utils.LogToErrorAndReturn(ctx, "Failed to query status of container %s, reason:%v", c.Name(), err)
+	}
+
+	st := &ContainerStatus{
+		Name:          c.Name(),
+		AllowedOnHost: c.isAllowedOnCurrentHost(),
+		WantState:     docker.ContainerStateNotFound,
+		State:         info.State,
+		WantImage:     c.imageReference(),
+		Image:         info.Image,
+	}
+	if st.AllowedOnHost {
+		st.WantState = docker.ContainerStateRunning
+	}
+
+	for _, e := range c.endpoints {
+		es := &ContainerEndpointStatus{
+			Network:  e.network.Name(),
+			WantIPv4: e.ipv4,
+			WantIPv6: e.ipv6,
+		}
+		if actual, found := info.Endpoints[e.network.Name()]; found {
+			es.IPv4 = actual.IPv4
+			es.IPv6 = actual.IPv6
+		}
+		st.Endpoints = append(st.Endpoints, es)
+	}
+	return st, nil
+}
+
+// InSync returns true if the live state of the container matches its
+// configured state.
+func (s *ContainerStatus) InSync() bool {
+	if s.State != s.WantState {
+		return false
+	}
+	if s.State == docker.ContainerStateNotFound {
+		return true
+	}
+	if s.Image != s.WantImage {
+		return false
+	}
+	for _, e := range s.Endpoints {
+		if e.IPv4 != e.WantIPv4 || e.IPv6 != e.WantIPv6 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *ContainerStatus) String() string {
+	var sb strings.Builder
+	sync := "in sync"
+	if !s.InSync() {
+		sync = "out of sync"
+	}
+	allowed := "allowed"
+	if !s.AllowedOnHost {
+		allowed = "not allowed"
+	}
+	fmt.Fprintf(&sb, "Container %s (%s, %s on host)", s.Name, sync, allowed)
+	fmt.Fprintf(&sb, "\n  State: %s (configured: %s)", s.State, s.WantState)
+	image := s.Image
+	if len(image) == 0 {
+		image = "-"
+	}
+	fmt.Fprintf(&sb, "\n  Image: %s (configured: %s)", image, s.WantImage)
+	for _, e := range s.Endpoints {
+		fmt.Fprintf(&sb, "\n  Network %s: %s (configured: %s)", e.Network, endpointIPsString(e.IPv4, e.IPv6), endpointIPsString(e.WantIPv4, e.WantIPv6))
+	}
+	return sb.String()
+}
+
+// QueryUnmanagedContainers returns the names of the containers present
+// on the docker host that look like they are managed by homelab (i.e.
+// named after one of the configured groups), but are missing in the
+// deployment config. If group is not empty, only the containers that
+// look like they belong to the specified group are returned.
+func (d *Deployment) QueryUnmanagedContainers(ctx context.Context, dc *docker.Client, group string) ([]string, error) {
+	names, err := dc.ListContainerNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	managed := make(map[string]struct{})
+	for _, ct := range d.queryAllContainers() {
+		managed[ct.Name()] = struct{}{}
+	}
+
+	var res []string
+	for _, n := range names {
+		if _, found := managed[n]; found {
+			continue
+		}
+		g, found := d.groupOfContainerName(n)
+		if !found || (group != "" && g != group) {
+			continue
+		}
+		res = append(res, n)
+	}
+	slices.Sort(res)
+	return res, nil
+}
+
+// groupOfContainerName returns the group the container name looks like
+// it belongs to. The longest matching group name wins, since group names
+// can be prefixes of one another (e.g. media and media-dl).
+func (d *Deployment) groupOfContainerName(name string) (string, bool) {
+	res := ""
+	for g := range d.Groups {
+		if len(g) > len(res) && strings.HasPrefix(name, fmt.Sprintf("%s-", g)) {
+			res = g
+		}
+	}
+	return res, len(res) > 0
+}
+
+func endpointIPsString(ipv4, ipv6 string) string {
+	if len(ipv4) == 0 && len(ipv6) == 0 {
+		return "-"
+	}
+	if len(ipv6) == 0 {
+		return ipv4
+	}
+	return fmt.Sprintf("%s, %s", ipv4, ipv6)
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -457,6 +459,34 @@ var containerStartTests = []struct {
 			}),
 		},
 	},
+	{
+		name: "Container Start - Doesn't Exist Already - With Wait For Healthy",
+		config: buildCustomSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz",
+			func(ct *config.Container) {
+				ct.Health.Cmd = []string{
+					"custom-health-cmd",
+				}
+				ct.Lifecycle.WaitForHealthy = true
+
+			},
+		),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+	},
 }
 
 func TestContainerStart(t *testing.T) {
@@ -473,9 +503,9 @@ func TestContainerStart(t *testing.T) {
 			if tc.ctxInfo.InspectLevel == inspect.HomelabInspectLevelNone {
 				tc.ctxInfo.InspectLevel = inspect.HomelabInspectLevelDebug
 			}
-			if tc.ctxInfo.ContainerPurgeKillAttempts == 0 {
-				// Reduce the number of attempts to keep the tests executing quickly.
-				tc.ctxInfo.ContainerPurgeKillAttempts = 5
+			if tc.ctxInfo.ContainerPurgeGracePeriod == 0 {
+				// Reduce the grace period to keep the tests executing quickly.
+				tc.ctxInfo.ContainerPurgeGracePeriod = 100 * time.Millisecond
 			}
 			ctx := testutils.NewTestContext(tc.ctxInfo)
 
@@ -498,7 +528,7 @@ func TestContainerStart(t *testing.T) {
 				tc.preExec(ctx)
 			}
 
-			gotStarted, gotErr := ct.Start(ctx, dc)
+			gotStarted, gotErr := ct.Start(ctx, dc, StartOptions{})
 			if gotErr != nil {
 				testhelpers.LogErrorNotNilWithOutput(t, "container.start()", tc.name, buf, gotErr)
 				return
@@ -517,6 +547,318 @@ func TestContainerStart(t *testing.T) {
 	}
 }
 
+func TestContainerStartPurgesWithConfiguredStopOptions(t *testing.T) {
+	t.Parallel()
+
+	tc := "Container Start - Purge Existing With Configured Stop Signal And Timeout"
+	cRef := config.ContainerReference{
+		Group:     "g1",
+		Container: "c1",
+	}
+	conf := buildCustomSingleContainerConfig(cRef, "abc/xyz", func(ct *config.Container) {
+		ct.Image.SkipImagePull = true
+		ct.Lifecycle.StopSignal = "SIGHUP"
+		ct.Lifecycle.StopTimeout = 30
+	})
+
+	buf := new(bytes.Buffer)
+	ctx := testutils.NewTestContext(&testutils.TestContextInfo{
+		Logger: testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf),
+		DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+			Containers: []*fakedocker.FakeContainerInitInfo{
+				{
+					Name:  "g1-c1",
+					Image: "abc/xyz",
+					State: docker.ContainerStateRunning,
+				},
+			},
+			ExistingImages: utils.StringSet{
+				"abc/xyz": {},
+			},
+		}),
+		ContainerPurgeGracePeriod: 100 * time.Millisecond,
+	})
+
+	dep, gotErr := FromConfig(ctx, &conf)
+	if gotErr != nil {
+		testhelpers.LogErrorNotNil(t, "FromConfig()", tc, gotErr)
+		return
+	}
+	ct, gotErr := dep.queryContainer(cRef)
+	if gotErr != nil {
+		testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc, gotErr)
+		return
+	}
+
+	dc := docker.NewClient(ctx)
+	defer dc.Close()
+
+	if _, gotErr := ct.Start(ctx, dc, StartOptions{}); gotErr != nil {
+		testhelpers.LogErrorNotNilWithOutput(t, "container.Start()", tc, buf, gotErr)
+		return
+	}
+
+	want := &dcontainer.StopOptions{
+		Signal:  "SIGHUP",
+		Timeout: testhelpers.NewInt(30),
+	}
+	d := fakedocker.FakeDockerHostFromContext(ctx)
+	testhelpers.CmpDiff(t, "container.Start()", tc, "stop options", want, d.LastContainerStopOptions("g1-c1"))
+}
+
+var containerStartChangedOnlyTests = []struct {
+	name          string
+	startFirst    bool
+	initialConfig config.Homelab
+	config        config.Homelab
+	cRef          config.ContainerReference
+	ctxInfo       *testutils.TestContextInfo
+	wantRecreated bool
+}{
+	{
+		name: "Container Start Changed Only - Doesn't Exist Already",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		wantRecreated: true,
+	},
+	{
+		name:       "Container Start Changed Only - Running With Unchanged Config",
+		startFirst: true,
+		initialConfig: buildCustomSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz",
+			func(ct *config.Container) {
+				ct.Image.SkipImagePull = true
+			}),
+		config: buildCustomSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz",
+			func(ct *config.Container) {
+				ct.Image.SkipImagePull = true
+			}),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ExistingImages: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		wantRecreated: false,
+	},
+	{
+		name:       "Container Start Changed Only - Running With Unchanged Config - Newer Image Pulled",
+		startFirst: true,
+		initialConfig: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		wantRecreated: true,
+	},
+	{
+		name:       "Container Start Changed Only - Running With Changed Config",
+		startFirst: true,
+		initialConfig: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		config: buildCustomSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz",
+			func(ct *config.Container) {
+				ct.Runtime.Env = []config.ContainerEnv{
+					{
+						Var:   "MY_ENV",
+						Value: "my-env-value",
+					},
+				}
+			}),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		wantRecreated: true,
+	},
+	{
+		name: "Container Start Changed Only - Exited Without Config Hash",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateExited,
+					},
+				},
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		wantRecreated: true,
+	},
+	{
+		name: "Container Start Changed Only - Running Without Config Hash",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		wantRecreated: true,
+	},
+}
+
+func TestContainerStartChangedOnly(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range containerStartChangedOnlyTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			buf := new(bytes.Buffer)
+			tc.ctxInfo.Logger = testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf)
+			if tc.ctxInfo.ContainerPurgeGracePeriod == 0 {
+				// Reduce the grace period to keep the tests executing quickly.
+				tc.ctxInfo.ContainerPurgeGracePeriod = 100 * time.Millisecond
+			}
+			ctx := testutils.NewTestContext(tc.ctxInfo)
+
+			dc := docker.NewClient(ctx)
+			defer dc.Close()
+
+			if tc.startFirst {
+				dep, gotErr := FromConfig(ctx, &tc.initialConfig)
+				if gotErr != nil {
+					testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+					return
+				}
+				ct, gotErr := dep.queryContainer(tc.cRef)
+				if gotErr != nil {
+					testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc.name, gotErr)
+					return
+				}
+				if _, gotErr := ct.Start(ctx, dc, StartOptions{}); gotErr != nil {
+					testhelpers.LogErrorNotNilWithOutput(t, "container.Start()", tc.name, buf, gotErr)
+					return
+				}
+			}
+
+			dep, gotErr := FromConfig(ctx, &tc.config)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+				return
+			}
+			ct, gotErr := dep.queryContainer(tc.cRef)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc.name, gotErr)
+				return
+			}
+
+			logStart := buf.Len()
+			if _, gotErr := ct.Start(ctx, dc, StartOptions{ChangedOnly: true}); gotErr != nil {
+				testhelpers.LogErrorNotNilWithOutput(t, "container.Start()", tc.name, buf, gotErr)
+				return
+			}
+
+			gotRecreated := strings.Contains(buf.String()[logStart:], "Creating container g1-c1")
+			if gotRecreated != tc.wantRecreated {
+				testhelpers.LogCustomWithOutput(t, "container.Start()", tc.name, buf, fmt.Sprintf("gotRecreated (%t) != wantRecreated (%t)", gotRecreated, tc.wantRecreated))
+				return
+			}
+
+			d := fakedocker.FakeDockerHostFromContext(ctx)
+			gotState := d.GetContainerState("g1-c1")
+			if gotState != docker.ContainerStateRunning {
+				testhelpers.LogCustomWithOutput(t, "Container state after container.Start()", tc.name, buf, fmt.Sprintf("gotState (%s) != ContainerStateRunning", gotState))
+			}
+		})
+	}
+}
+
 var containerStartErrorTests = []struct {
 	name      string
 	config    config.Homelab
@@ -661,7 +1003,7 @@ var containerStartErrorTests = []struct {
 				},
 			}),
 		},
-		want: `Failed to start container g1-c1, reason:failed to purge container g1-c1 after 6 attempts`,
+		want: `Failed to start container g1-c1, reason:failed to purge container g1-c1 within the grace period of 100ms`,
 	},
 	{
 		name: "Container Start - Unkillable Existing Container",
@@ -691,7 +1033,7 @@ var containerStartErrorTests = []struct {
 				},
 			}),
 		},
-		want: `Failed to start container g1-c1, reason:failed to purge container g1-c1 after 6 attempts`,
+		want: `Failed to start container g1-c1, reason:failed to purge container g1-c1 within the grace period of 100ms`,
 	},
 	{
 		name: "Container Start - Container State Unknown",
@@ -970,10 +1312,221 @@ var containerStartErrorTests = []struct {
 		},
 		want: `Failed to start container g1-c1, reason:failed to connect container g1-c1 to network proxy-bridge, reason: failed to connect container g1-c1 to network proxy-bridge on the fake docker host`,
 	},
-}
-
-func TestContainerStartErrors(t *testing.T) {
-	t.Parallel()
+	{
+		name: "Container Start - Wait For Healthy - Unhealthy",
+		config: buildCustomSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz",
+			func(ct *config.Container) {
+				ct.Health.Cmd = []string{
+					"custom-health-cmd",
+				}
+				ct.Lifecycle.WaitForHealthy = true
+
+			},
+		),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+				UnhealthyContainers: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
+		},
+		want: `Failed to start container g1-c1, reason:container g1-c1 became unhealthy while waiting for it to become healthy`,
+	},
+	{
+		name: "Container Start - Wait For Healthy - Timeout",
+		config: buildCustomSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz",
+			func(ct *config.Container) {
+				ct.Health.Cmd = []string{
+					"custom-health-cmd",
+				}
+				ct.Lifecycle.WaitForHealthy = true
+				ct.Lifecycle.WaitForHealthyTimeout = 1
+
+			},
+		),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+				StartingContainers: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
+		},
+		want: `Failed to start container g1-c1, reason:timed out after 1s waiting for container g1-c1 to become healthy`,
+	},
+	{
+		name: "Container Start - Wait For Healthy - No Health Check",
+		config: buildCustomSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz",
+			func(ct *config.Container) {
+				ct.Lifecycle.WaitForHealthy = true
+
+			},
+		),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Failed to start container g1-c1, reason:container g1-c1 has no health check to wait for`,
+	},
+	{
+		name: "Container Start - Secret With Missing File",
+		config: func() config.Homelab {
+			conf := buildCustomSingleContainerConfig(
+				config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				"abc/xyz",
+				func(ct *config.Container) {
+					ct.Secrets = []config.ContainerSecret{
+						{
+							Name: "db-password",
+						},
+					}
+				},
+			)
+			conf.Secrets = []config.Secret{
+				{
+					Name: "db-password",
+					File: "testdata/secrets/non-existent.txt",
+				},
+			}
+			return conf
+		}(),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Failed to start container g1-c1, reason:failed to read the file for secret db-password, reason: open testdata/secrets/non-existent\.txt: no such file or directory`,
+	},
+	{
+		name: "Container Start - Secret With Invalid Command",
+		config: func() config.Homelab {
+			conf := buildCustomSingleContainerConfig(
+				config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				"abc/xyz",
+				func(ct *config.Container) {
+					ct.Secrets = []config.ContainerSecret{
+						{
+							Name: "db-password",
+						},
+					}
+				},
+			)
+			conf.Secrets = []config.Secret{
+				{
+					Name: "db-password",
+					Command: []string{
+						"garbage-command",
+						"garbage-arg1",
+					},
+				},
+			}
+			return conf
+		}(),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Failed to start container g1-c1, reason:failed to run the command for secret db-password, reason: invalid fake executor command garbage-command \["garbage-arg1"\]`,
+	},
+	{
+		name: "Container Start - Secret With Env Not Set",
+		config: func() config.Homelab {
+			conf := buildCustomSingleContainerConfig(
+				config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				"abc/xyz",
+				func(ct *config.Container) {
+					ct.Secrets = []config.ContainerSecret{
+						{
+							Name: "db-password",
+						},
+					}
+				},
+			)
+			conf.Global.ProcessEnvAllowlist = []string{
+				"HOMELAB_TEST_UNSET_DB_PASSWORD",
+			}
+			conf.Secrets = []config.Secret{
+				{
+					Name: "db-password",
+					Env:  "HOMELAB_TEST_UNSET_DB_PASSWORD",
+				},
+			}
+			return conf
+		}(),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Failed to start container g1-c1, reason:env HOMELAB_TEST_UNSET_DB_PASSWORD for secret db-password is not set in the environment`,
+	},
+}
+
+func TestContainerStartErrors(t *testing.T) {
+	t.Parallel()
 
 	for _, test := range containerStartErrorTests {
 		tc := test
@@ -982,9 +1535,9 @@ func TestContainerStartErrors(t *testing.T) {
 
 			buf := new(bytes.Buffer)
 			tc.ctxInfo.Logger = testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf)
-			if tc.ctxInfo.ContainerPurgeKillAttempts == 0 {
-				// Reduce the number of attempts to keep the tests executing quickly.
-				tc.ctxInfo.ContainerPurgeKillAttempts = 5
+			if tc.ctxInfo.ContainerPurgeGracePeriod == 0 {
+				// Reduce the grace period to keep the tests executing quickly.
+				tc.ctxInfo.ContainerPurgeGracePeriod = 100 * time.Millisecond
 			}
 			ctx := testutils.NewTestContext(tc.ctxInfo)
 
@@ -1007,7 +1560,7 @@ func TestContainerStartErrors(t *testing.T) {
 				return
 			}
 
-			gotStarted, gotErr := ct.Start(ctx, dc)
+			gotStarted, gotErr := ct.Start(ctx, dc, StartOptions{})
 			if gotErr == nil {
 				testhelpers.LogErrorNilWithOutput(t, "container.start()", tc.name, buf, tc.want)
 				return
@@ -1030,7 +1583,9 @@ var containerStopTests = []struct {
 	cRef                    config.ContainerReference
 	ctxInfo                 *testutils.TestContextInfo
 	preExec                 func(context.Context)
+	stopOpts                StopOptions
 	wantContainerStopIssued bool
+	wantStopOptions         *dcontainer.StopOptions
 	wantStoppedReturnVal    bool
 	wantState               docker.ContainerState
 }{
@@ -1107,6 +1662,155 @@ var containerStopTests = []struct {
 		wantStoppedReturnVal:    true,
 		wantState:               docker.ContainerStateExited,
 	},
+	{
+		name: "Container Stop - Configured Stop Signal And Timeout",
+		config: func() config.Homelab {
+			conf := buildSingleContainerConfig(
+				config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				"abc/xyz")
+			conf.Containers[0].Lifecycle.StopSignal = "SIGHUP"
+			conf.Containers[0].Lifecycle.StopTimeout = 30
+			return conf
+		}(),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		wantContainerStopIssued: true,
+		wantStopOptions: &dcontainer.StopOptions{
+			Signal:  "SIGHUP",
+			Timeout: testhelpers.NewInt(30),
+		},
+		wantStoppedReturnVal: true,
+		wantState:            docker.ContainerStateExited,
+	},
+	{
+		name: "Container Stop - Global Stop Signal And Timeout",
+		config: func() config.Homelab {
+			conf := buildSingleContainerConfig(
+				config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				"abc/xyz")
+			conf.Global.Container.StopSignal = "SIGQUIT"
+			conf.Global.Container.StopTimeout = 15
+			return conf
+		}(),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		wantContainerStopIssued: true,
+		wantStopOptions: &dcontainer.StopOptions{
+			Signal:  "SIGQUIT",
+			Timeout: testhelpers.NewInt(15),
+		},
+		wantStoppedReturnVal: true,
+		wantState:            docker.ContainerStateExited,
+	},
+	{
+		name: "Container Stop - Configured Stop Signal Overrides Global Stop Signal",
+		config: func() config.Homelab {
+			conf := buildSingleContainerConfig(
+				config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				"abc/xyz")
+			conf.Global.Container.StopSignal = "SIGQUIT"
+			conf.Global.Container.StopTimeout = 15
+			conf.Containers[0].Lifecycle.StopSignal = "SIGHUP"
+			return conf
+		}(),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		wantContainerStopIssued: true,
+		wantStopOptions: &dcontainer.StopOptions{
+			Signal:  "SIGHUP",
+			Timeout: testhelpers.NewInt(15),
+		},
+		wantStoppedReturnVal: true,
+		wantState:            docker.ContainerStateExited,
+	},
+	{
+		name: "Container Stop - Overridden Stop Signal And Timeout",
+		config: func() config.Homelab {
+			conf := buildSingleContainerConfig(
+				config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				"abc/xyz")
+			conf.Containers[0].Lifecycle.StopSignal = "SIGHUP"
+			conf.Containers[0].Lifecycle.StopTimeout = 30
+			return conf
+		}(),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		stopOpts: StopOptions{
+			Signal:  "SIGINT",
+			Timeout: testhelpers.NewInt(-1),
+		},
+		wantContainerStopIssued: true,
+		wantStopOptions: &dcontainer.StopOptions{
+			Signal:  "SIGINT",
+			Timeout: testhelpers.NewInt(-1),
+		},
+		wantStoppedReturnVal: true,
+		wantState:            docker.ContainerStateExited,
+	},
 	{
 		name: "Container Stop - Exists Already In Running State - Pull Image Before Stop",
 		config: buildCustomSingleContainerConfig(
@@ -1330,9 +2034,9 @@ func TestContainerStop(t *testing.T) {
 			if tc.ctxInfo.InspectLevel == inspect.HomelabInspectLevelNone {
 				tc.ctxInfo.InspectLevel = inspect.HomelabInspectLevelDebug
 			}
-			if tc.ctxInfo.ContainerPurgeKillAttempts == 0 {
-				// Reduce the number of attempts to keep the tests executing quickly.
-				tc.ctxInfo.ContainerPurgeKillAttempts = 5
+			if tc.ctxInfo.ContainerPurgeGracePeriod == 0 {
+				// Reduce the grace period to keep the tests executing quickly.
+				tc.ctxInfo.ContainerPurgeGracePeriod = 100 * time.Millisecond
 			}
 			ctx := testutils.NewTestContext(tc.ctxInfo)
 
@@ -1355,7 +2059,7 @@ func TestContainerStop(t *testing.T) {
 				tc.preExec(ctx)
 			}
 
-			gotStoppedReturnVal, gotErr := ct.Stop(ctx, dc)
+			gotStoppedReturnVal, gotErr := ct.Stop(ctx, dc, tc.stopOpts)
 			if gotErr != nil {
 				testhelpers.LogErrorNotNilWithOutput(t, "container.stop()", tc.name, buf, gotErr)
 				return
@@ -1370,6 +2074,9 @@ func TestContainerStop(t *testing.T) {
 			if gotStopIssued != tc.wantContainerStopIssued {
 				testhelpers.LogCustomWithOutput(t, "ContainerStop issued", tc.name, buf, fmt.Sprintf("got (%t) != want (%t)", gotStopIssued, tc.wantContainerStopIssued))
 			}
+			if tc.wantStopOptions != nil {
+				testhelpers.CmpDiff(t, "container.stop()", tc.name, "stop options", tc.wantStopOptions, d.ContainerStopOptions(cName))
+			}
 
 			gotState := d.GetContainerState(cName)
 			if gotState != tc.wantState {
@@ -1516,9 +2223,9 @@ func TestContainerStopErrors(t *testing.T) {
 
 			buf := new(bytes.Buffer)
 			tc.ctxInfo.Logger = testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf)
-			if tc.ctxInfo.ContainerPurgeKillAttempts == 0 {
-				// Reduce the number of attempts to keep the tests executing quickly.
-				tc.ctxInfo.ContainerPurgeKillAttempts = 5
+			if tc.ctxInfo.ContainerPurgeGracePeriod == 0 {
+				// Reduce the grace period to keep the tests executing quickly.
+				tc.ctxInfo.ContainerPurgeGracePeriod = 100 * time.Millisecond
 			}
 			ctx := testutils.NewTestContext(tc.ctxInfo)
 
@@ -1541,7 +2248,7 @@ func TestContainerStopErrors(t *testing.T) {
 				return
 			}
 
-			gotStoppedReturnVal, gotErr := ct.Stop(ctx, dc)
+			gotStoppedReturnVal, gotErr := ct.Stop(ctx, dc, StopOptions{})
 			if gotErr == nil {
 				testhelpers.LogErrorNilWithOutput(t, "container.stop()", tc.name, buf, tc.want)
 				return
@@ -1556,16 +2263,17 @@ func TestContainerStopErrors(t *testing.T) {
 	}
 }
 
-var containerPurgeTests = []struct {
-	name       string
-	config     config.Homelab
-	cRef       config.ContainerReference
-	ctxInfo    *testutils.TestContextInfo
-	preExec    func(context.Context)
-	wantPurged bool
+var containerRestartTests = []struct {
+	name                   string
+	config                 config.Homelab
+	cRef                   config.ContainerReference
+	ctxInfo                *testutils.TestContextInfo
+	wantRestartedReturnVal bool
+	wantRestartOptions     *dcontainer.StopOptions
+	wantState              docker.ContainerState
 }{
 	{
-		name: "Container Purge - Doesn't Exist Already",
+		name: "Container Restart - Doesn't Exist Already",
 		config: buildSingleContainerConfig(
 			config.ContainerReference{
 				Group:     "g1",
@@ -1577,12 +2285,14 @@ var containerPurgeTests = []struct {
 			Container: "c1",
 		},
 		ctxInfo: &testutils.TestContextInfo{
-			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{}),
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
 		},
-		wantPurged: false,
+		wantRestartedReturnVal: false,
+		wantRestartOptions:     nil,
+		wantState:              docker.ContainerStateNotFound,
 	},
 	{
-		name: "Container Purge - In Created State",
+		name: "Container Restart - Exists Already In Running State",
 		config: buildSingleContainerConfig(
 			config.ContainerReference{
 				Group:     "g1",
@@ -1599,15 +2309,17 @@ var containerPurgeTests = []struct {
 					{
 						Name:  "g1-c1",
 						Image: "abc/xyz",
-						State: docker.ContainerStateCreated,
+						State: docker.ContainerStateRunning,
 					},
 				},
 			}),
 		},
-		wantPurged: true,
+		wantRestartedReturnVal: true,
+		wantRestartOptions:     &dcontainer.StopOptions{},
+		wantState:              docker.ContainerStateRunning,
 	},
 	{
-		name: "Container Purge - In Running State",
+		name: "Container Restart - Exists Already In Exited State",
 		config: buildSingleContainerConfig(
 			config.ContainerReference{
 				Group:     "g1",
@@ -1624,21 +2336,28 @@ var containerPurgeTests = []struct {
 					{
 						Name:  "g1-c1",
 						Image: "abc/xyz",
-						State: docker.ContainerStateRunning,
+						State: docker.ContainerStateExited,
 					},
 				},
 			}),
 		},
-		wantPurged: true,
+		wantRestartedReturnVal: true,
+		wantRestartOptions:     &dcontainer.StopOptions{},
+		wantState:              docker.ContainerStateRunning,
 	},
 	{
-		name: "Container Purge - In Paused State",
-		config: buildSingleContainerConfig(
-			config.ContainerReference{
-				Group:     "g1",
-				Container: "c1",
-			},
-			"abc/xyz"),
+		name: "Container Restart - Configured Stop Signal And Timeout",
+		config: func() config.Homelab {
+			conf := buildSingleContainerConfig(
+				config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				"abc/xyz")
+			conf.Containers[0].Lifecycle.StopSignal = "SIGHUP"
+			conf.Containers[0].Lifecycle.StopTimeout = 30
+			return conf
+		}(),
 		cRef: config.ContainerReference{
 			Group:     "g1",
 			Container: "c1",
@@ -1649,21 +2368,30 @@ var containerPurgeTests = []struct {
 					{
 						Name:  "g1-c1",
 						Image: "abc/xyz",
-						State: docker.ContainerStatePaused,
+						State: docker.ContainerStateRunning,
 					},
 				},
 			}),
 		},
-		wantPurged: true,
+		wantRestartedReturnVal: true,
+		wantRestartOptions: &dcontainer.StopOptions{
+			Signal:  "SIGHUP",
+			Timeout: testhelpers.NewInt(30),
+		},
+		wantState: docker.ContainerStateRunning,
 	},
 	{
-		name: "Container Purge - In Restarting State",
-		config: buildSingleContainerConfig(
-			config.ContainerReference{
-				Group:     "g1",
-				Container: "c1",
-			},
-			"abc/xyz"),
+		name: "Container Restart - Global Stop Timeout",
+		config: func() config.Homelab {
+			conf := buildSingleContainerConfig(
+				config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				"abc/xyz")
+			conf.Global.Container.StopTimeout = 15
+			return conf
+		}(),
 		cRef: config.ContainerReference{
 			Group:     "g1",
 			Container: "c1",
@@ -1674,12 +2402,816 @@ var containerPurgeTests = []struct {
 					{
 						Name:  "g1-c1",
 						Image: "abc/xyz",
-						State: docker.ContainerStateRestarting,
+						State: docker.ContainerStateRunning,
 					},
 				},
 			}),
 		},
-		wantPurged: true,
+		wantRestartedReturnVal: true,
+		wantRestartOptions: &dcontainer.StopOptions{
+			Timeout: testhelpers.NewInt(15),
+		},
+		wantState: docker.ContainerStateRunning,
+	},
+}
+
+func TestContainerRestart(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range containerRestartTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			tc.ctxInfo.Logger = testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf)
+			ctx := testutils.NewTestContext(tc.ctxInfo)
+
+			dep, gotErr := FromConfig(ctx, &tc.config)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+				return
+			}
+
+			dc := docker.NewClient(ctx)
+			defer dc.Close()
+
+			ct, gotErr := dep.queryContainer(tc.cRef)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc.name, gotErr)
+				return
+			}
+
+			gotRestartedReturnVal, gotErr := ct.Restart(ctx, dc)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNilWithOutput(t, "container.restart()", tc.name, buf, gotErr)
+				return
+			}
+			if gotRestartedReturnVal != tc.wantRestartedReturnVal {
+				testhelpers.LogCustomWithOutput(t, "container.restart() return value", tc.name, buf, fmt.Sprintf("gotRestarted (%t) != wantRestarted (%t)", gotRestartedReturnVal, tc.wantRestartedReturnVal))
+			}
+
+			cName := fmt.Sprintf("%s-%s", tc.cRef.Group, tc.cRef.Container)
+			d := fakedocker.FakeDockerHostFromContext(ctx)
+			testhelpers.CmpDiff(t, "container.restart()", tc.name, "restart options", tc.wantRestartOptions, d.ContainerRestartOptions(cName))
+
+			gotState := d.GetContainerState(cName)
+			if gotState != tc.wantState {
+				testhelpers.LogCustomWithOutput(t, "Container state after container.restart()", tc.name, buf, fmt.Sprintf("got (%s) != want (%s)", gotState, tc.wantState))
+			}
+		})
+	}
+}
+
+var containerRestartErrorTests = []struct {
+	name    string
+	config  config.Homelab
+	cRef    config.ContainerReference
+	ctxInfo *testutils.TestContextInfo
+	want    string
+}{
+	{
+		name: "Container Restart - Restart Existing Container Fails",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				FailContainerRestart: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
+		},
+		want: `Failed to restart container g1-c1, reason:failed to restart the container, reason: failed to restart container g1-c1 on the fake docker host`,
+	},
+	{
+		name: "Container Restart - Inspect Existing Container Fails",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				FailContainerInspect: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
+		},
+		want: `Failed to restart container g1-c1, reason:failed to retrieve the container state, reason: failed to inspect container g1-c1 on the fake docker host`,
+	},
+}
+
+func TestContainerRestartErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range containerRestartErrorTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			tc.ctxInfo.Logger = testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf)
+			ctx := testutils.NewTestContext(tc.ctxInfo)
+
+			dep, gotErr := FromConfig(ctx, &tc.config)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+				return
+			}
+
+			dc := docker.NewClient(ctx)
+			defer dc.Close()
+
+			ct, gotErr := dep.queryContainer(tc.cRef)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc.name, gotErr)
+				return
+			}
+
+			gotRestartedReturnVal, gotErr := ct.Restart(ctx, dc)
+			if gotErr == nil {
+				testhelpers.LogErrorNilWithOutput(t, "container.restart()", tc.name, buf, tc.want)
+				return
+			}
+			if gotRestartedReturnVal {
+				testhelpers.LogCustomWithOutput(t, "container.restart() return value", tc.name, buf, "gotRestarted (true) != wantRestarted (false)")
+			}
+			if !testhelpers.RegexMatchWithOutput(t, "container.restart()", tc.name, buf, "gotErr error string", tc.want, gotErr.Error()) {
+				return
+			}
+		})
+	}
+}
+
+var containerPauseTests = []struct {
+	name                string
+	config              config.Homelab
+	cRef                config.ContainerReference
+	ctxInfo             *testutils.TestContextInfo
+	wantPausedReturnVal bool
+	wantState           docker.ContainerState
+}{
+	{
+		name: "Container Pause - Doesn't Exist Already",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		wantPausedReturnVal: false,
+		wantState:           docker.ContainerStateNotFound,
+	},
+	{
+		name: "Container Pause - Exists Already In Running State",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		wantPausedReturnVal: true,
+		wantState:           docker.ContainerStatePaused,
+	},
+	{
+		name: "Container Pause - Exists Already In Paused State",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStatePaused,
+					},
+				},
+			}),
+		},
+		wantPausedReturnVal: false,
+		wantState:           docker.ContainerStatePaused,
+	},
+	{
+		name: "Container Pause - Exists Already In Exited State",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateExited,
+					},
+				},
+			}),
+		},
+		wantPausedReturnVal: false,
+		wantState:           docker.ContainerStateExited,
+	},
+}
+
+func TestContainerPause(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range containerPauseTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			tc.ctxInfo.Logger = testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf)
+			ctx := testutils.NewTestContext(tc.ctxInfo)
+
+			dep, gotErr := FromConfig(ctx, &tc.config)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+				return
+			}
+
+			dc := docker.NewClient(ctx)
+			defer dc.Close()
+
+			ct, gotErr := dep.queryContainer(tc.cRef)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc.name, gotErr)
+				return
+			}
+
+			gotPausedReturnVal, gotErr := ct.Pause(ctx, dc)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNilWithOutput(t, "container.pause()", tc.name, buf, gotErr)
+				return
+			}
+			if gotPausedReturnVal != tc.wantPausedReturnVal {
+				testhelpers.LogCustomWithOutput(t, "container.pause() return value", tc.name, buf, fmt.Sprintf("gotPaused (%t) != wantPaused (%t)", gotPausedReturnVal, tc.wantPausedReturnVal))
+			}
+
+			cName := fmt.Sprintf("%s-%s", tc.cRef.Group, tc.cRef.Container)
+			d := fakedocker.FakeDockerHostFromContext(ctx)
+			gotState := d.GetContainerState(cName)
+			if gotState != tc.wantState {
+				testhelpers.LogCustomWithOutput(t, "Container state after container.pause()", tc.name, buf, fmt.Sprintf("got (%s) != want (%s)", gotState, tc.wantState))
+			}
+		})
+	}
+}
+
+var containerPauseErrorTests = []struct {
+	name    string
+	config  config.Homelab
+	cRef    config.ContainerReference
+	ctxInfo *testutils.TestContextInfo
+	want    string
+}{
+	{
+		name: "Container Pause - Pause Existing Container Fails",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				FailContainerPause: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
+		},
+		want: `Failed to pause container g1-c1, reason:failed to pause the container, reason: failed to pause container g1-c1 on the fake docker host`,
+	},
+	{
+		name: "Container Pause - Inspect Existing Container Fails",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				FailContainerInspect: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
+		},
+		want: `Failed to pause container g1-c1, reason:failed to retrieve the container state, reason: failed to inspect container g1-c1 on the fake docker host`,
+	},
+}
+
+func TestContainerPauseErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range containerPauseErrorTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			tc.ctxInfo.Logger = testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf)
+			ctx := testutils.NewTestContext(tc.ctxInfo)
+
+			dep, gotErr := FromConfig(ctx, &tc.config)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+				return
+			}
+
+			dc := docker.NewClient(ctx)
+			defer dc.Close()
+
+			ct, gotErr := dep.queryContainer(tc.cRef)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc.name, gotErr)
+				return
+			}
+
+			gotPausedReturnVal, gotErr := ct.Pause(ctx, dc)
+			if gotErr == nil {
+				testhelpers.LogErrorNilWithOutput(t, "container.pause()", tc.name, buf, tc.want)
+				return
+			}
+			if gotPausedReturnVal {
+				testhelpers.LogCustomWithOutput(t, "container.pause() return value", tc.name, buf, "gotPaused (true) != wantPaused (false)")
+			}
+			if !testhelpers.RegexMatchWithOutput(t, "container.pause()", tc.name, buf, "gotErr error string", tc.want, gotErr.Error()) {
+				return
+			}
+		})
+	}
+}
+
+var containerUnpauseTests = []struct {
+	name                  string
+	config                config.Homelab
+	cRef                  config.ContainerReference
+	ctxInfo               *testutils.TestContextInfo
+	wantUnpausedReturnVal bool
+	wantState             docker.ContainerState
+}{
+	{
+		name: "Container Unpause - Doesn't Exist Already",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		wantUnpausedReturnVal: false,
+		wantState:             docker.ContainerStateNotFound,
+	},
+	{
+		name: "Container Unpause - Exists Already In Paused State",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStatePaused,
+					},
+				},
+			}),
+		},
+		wantUnpausedReturnVal: true,
+		wantState:             docker.ContainerStateRunning,
+	},
+	{
+		name: "Container Unpause - Exists Already In Running State",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		wantUnpausedReturnVal: false,
+		wantState:             docker.ContainerStateRunning,
+	},
+	{
+		name: "Container Unpause - Exists Already In Exited State",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateExited,
+					},
+				},
+			}),
+		},
+		wantUnpausedReturnVal: false,
+		wantState:             docker.ContainerStateExited,
+	},
+}
+
+func TestContainerUnpause(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range containerUnpauseTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			tc.ctxInfo.Logger = testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf)
+			ctx := testutils.NewTestContext(tc.ctxInfo)
+
+			dep, gotErr := FromConfig(ctx, &tc.config)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+				return
+			}
+
+			dc := docker.NewClient(ctx)
+			defer dc.Close()
+
+			ct, gotErr := dep.queryContainer(tc.cRef)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc.name, gotErr)
+				return
+			}
+
+			gotUnpausedReturnVal, gotErr := ct.Unpause(ctx, dc)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNilWithOutput(t, "container.unpause()", tc.name, buf, gotErr)
+				return
+			}
+			if gotUnpausedReturnVal != tc.wantUnpausedReturnVal {
+				testhelpers.LogCustomWithOutput(t, "container.unpause() return value", tc.name, buf, fmt.Sprintf("gotUnpaused (%t) != wantUnpaused (%t)", gotUnpausedReturnVal, tc.wantUnpausedReturnVal))
+			}
+
+			cName := fmt.Sprintf("%s-%s", tc.cRef.Group, tc.cRef.Container)
+			d := fakedocker.FakeDockerHostFromContext(ctx)
+			gotState := d.GetContainerState(cName)
+			if gotState != tc.wantState {
+				testhelpers.LogCustomWithOutput(t, "Container state after container.unpause()", tc.name, buf, fmt.Sprintf("got (%s) != want (%s)", gotState, tc.wantState))
+			}
+		})
+	}
+}
+
+var containerUnpauseErrorTests = []struct {
+	name    string
+	config  config.Homelab
+	cRef    config.ContainerReference
+	ctxInfo *testutils.TestContextInfo
+	want    string
+}{
+	{
+		name: "Container Unpause - Unpause Existing Container Fails",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStatePaused,
+					},
+				},
+				FailContainerUnpause: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
+		},
+		want: `Failed to unpause container g1-c1, reason:failed to unpause the container, reason: failed to unpause container g1-c1 on the fake docker host`,
+	},
+	{
+		name: "Container Unpause - Inspect Existing Container Fails",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStatePaused,
+					},
+				},
+				FailContainerInspect: utils.StringSet{
+					"g1-c1": {},
+				},
+			}),
+		},
+		want: `Failed to unpause container g1-c1, reason:failed to retrieve the container state, reason: failed to inspect container g1-c1 on the fake docker host`,
+	},
+}
+
+func TestContainerUnpauseErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range containerUnpauseErrorTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			tc.ctxInfo.Logger = testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf)
+			ctx := testutils.NewTestContext(tc.ctxInfo)
+
+			dep, gotErr := FromConfig(ctx, &tc.config)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+				return
+			}
+
+			dc := docker.NewClient(ctx)
+			defer dc.Close()
+
+			ct, gotErr := dep.queryContainer(tc.cRef)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc.name, gotErr)
+				return
+			}
+
+			gotUnpausedReturnVal, gotErr := ct.Unpause(ctx, dc)
+			if gotErr == nil {
+				testhelpers.LogErrorNilWithOutput(t, "container.unpause()", tc.name, buf, tc.want)
+				return
+			}
+			if gotUnpausedReturnVal {
+				testhelpers.LogCustomWithOutput(t, "container.unpause() return value", tc.name, buf, "gotUnpaused (true) != wantUnpaused (false)")
+			}
+			if !testhelpers.RegexMatchWithOutput(t, "container.unpause()", tc.name, buf, "gotErr error string", tc.want, gotErr.Error()) {
+				return
+			}
+		})
+	}
+}
+
+var containerPurgeTests = []struct {
+	name       string
+	config     config.Homelab
+	cRef       config.ContainerReference
+	ctxInfo    *testutils.TestContextInfo
+	preExec    func(context.Context)
+	wantPurged bool
+}{
+	{
+		name: "Container Purge - Doesn't Exist Already",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{}),
+		},
+		wantPurged: false,
+	},
+	{
+		name: "Container Purge - In Created State",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateCreated,
+					},
+				},
+			}),
+		},
+		wantPurged: true,
+	},
+	{
+		name: "Container Purge - In Running State",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+			}),
+		},
+		wantPurged: true,
+	},
+	{
+		name: "Container Purge - In Paused State",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStatePaused,
+					},
+				},
+			}),
+		},
+		wantPurged: true,
+	},
+	{
+		name: "Container Purge - In Restarting State",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRestarting,
+					},
+				},
+			}),
+		},
+		wantPurged: true,
 	},
 	{
 		name: "Container Purge - In Removing State",
@@ -1819,6 +3351,37 @@ var containerPurgeTests = []struct {
 		},
 		wantPurged: true,
 	},
+	{
+		name: "Container Purge - In Running State Requiring Kills Beyond Default Grace Period",
+		config: func() config.Homelab {
+			conf := buildSingleContainerConfig(
+				config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				"abc/xyz")
+			conf.Containers[0].Lifecycle.PurgeGracePeriod = 1
+			return conf
+		}(),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:               "g1-c1",
+						Image:              "abc/xyz",
+						State:              docker.ContainerStateRunning,
+						RequiredExtraStops: 1000,
+						RequiredExtraKills: 10,
+					},
+				},
+			}),
+		},
+		wantPurged: true,
+	},
 }
 
 func TestContainerPurge(t *testing.T) {
@@ -1835,9 +3398,9 @@ func TestContainerPurge(t *testing.T) {
 			if tc.ctxInfo.InspectLevel == inspect.HomelabInspectLevelNone {
 				tc.ctxInfo.InspectLevel = inspect.HomelabInspectLevelDebug
 			}
-			if tc.ctxInfo.ContainerPurgeKillAttempts == 0 {
-				// Reduce the number of attempts to keep the tests executing quickly.
-				tc.ctxInfo.ContainerPurgeKillAttempts = 5
+			if tc.ctxInfo.ContainerPurgeGracePeriod == 0 {
+				// Reduce the grace period to keep the tests executing quickly.
+				tc.ctxInfo.ContainerPurgeGracePeriod = 100 * time.Millisecond
 			}
 			ctx := testutils.NewTestContext(tc.ctxInfo)
 
@@ -1860,7 +3423,7 @@ func TestContainerPurge(t *testing.T) {
 				tc.preExec(ctx)
 			}
 
-			gotPurged, gotErr := ct.Purge(ctx, dc)
+			gotPurged, gotErr := ct.Purge(ctx, dc, StopOptions{})
 			if gotErr != nil {
 				testhelpers.LogErrorNotNilWithOutput(t, "container.Purge()", tc.name, buf, gotErr)
 				return
@@ -1916,7 +3479,7 @@ var containerPurgeErrorTests = []struct {
 				},
 			}),
 		},
-		want: `Failed to purge container g1-c1, reason:failed to purge container g1-c1 after 6 attempts`,
+		want: `Failed to purge container g1-c1, reason:failed to purge container g1-c1 within the grace period of 100ms`,
 	},
 	{
 		name: "Container Purge - Unkillable Existing Container",
@@ -1946,7 +3509,7 @@ var containerPurgeErrorTests = []struct {
 				},
 			}),
 		},
-		want: `Failed to purge container g1-c1, reason:failed to purge container g1-c1 after 6 attempts`,
+		want: `Failed to purge container g1-c1, reason:failed to purge container g1-c1 within the grace period of 100ms`,
 	},
 	{
 		name: "Container Purge - Container State Unknown",
@@ -2006,10 +3569,156 @@ var containerPurgeErrorTests = []struct {
 				},
 			}),
 		},
-		want: `Failed to purge container g1-c1, reason:failed to retrieve the container state, reason: failed to inspect container g1-c1 on the fake docker host`,
+		want: `Failed to purge container g1-c1, reason:failed to retrieve the container state, reason: failed to inspect container g1-c1 on the fake docker host`,
+	},
+	{
+		name: "Container Purge - Stop Existing Container Failure",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRunning,
+					},
+				},
+				FailContainerStop: utils.StringSet{
+					"g1-c1": {},
+				},
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Failed to purge container g1-c1, reason:failed to stop the container, reason: failed to stop container g1-c1 on the fake docker host`,
+	},
+	{
+		name: "Container Purge - Remove Existing Container Failure",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:  "g1-c1",
+						Image: "abc/xyz",
+						State: docker.ContainerStateRestarting,
+					},
+				},
+				FailContainerRemove: utils.StringSet{
+					"g1-c1": {},
+				},
+				ValidImagesForPull: utils.StringSet{
+					"abc/xyz": {},
+				},
+			}),
+		},
+		want: `Failed to purge container g1-c1, reason:failed to remove the container, reason: failed to remove container g1-c1 on the fake docker host`,
+	},
+}
+
+func TestContainerPurgeErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range containerPurgeErrorTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			tc.ctxInfo.Logger = testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf)
+			if tc.ctxInfo.ContainerPurgeGracePeriod == 0 {
+				// Reduce the grace period to keep the tests executing quickly.
+				tc.ctxInfo.ContainerPurgeGracePeriod = 100 * time.Millisecond
+			}
+			ctx := testutils.NewTestContext(tc.ctxInfo)
+
+			if tc.wantPanic {
+				defer testhelpers.ExpectPanicWithOutput(t, "container.Purge()", tc.name, buf, tc.want)
+			}
+
+			dep, gotErr := FromConfig(ctx, &tc.config)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+				return
+			}
+
+			dc := docker.NewClient(ctx)
+			defer dc.Close()
+
+			ct, gotErr := dep.queryContainer(tc.cRef)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc.name, gotErr)
+				return
+			}
+
+			gotPurged, gotErr := ct.Purge(ctx, dc, StopOptions{})
+			if gotErr == nil {
+				testhelpers.LogErrorNilWithOutput(t, "container.Purge()", tc.name, buf, tc.want)
+				return
+			}
+			if gotPurged {
+				testhelpers.LogCustomWithOutput(t, "container.Purge()", tc.name, buf, "gotPurged (true) != wantPurged (false)")
+				return
+			}
+
+			if !testhelpers.RegexMatchWithOutput(t, "container.Purge()", tc.name, buf, "gotErr error string", tc.want, gotErr.Error()) {
+				return
+			}
+		})
+	}
+}
+
+var containerStatusTests = []struct {
+	name       string
+	config     config.Homelab
+	cRef       config.ContainerReference
+	ctxInfo    *testutils.TestContextInfo
+	startFirst bool
+	wantInSync bool
+	want       string
+}{
+	{
+		name: "Container Status - Doesn't Exist",
+		config: buildSingleContainerConfig(
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			},
+			"abc/xyz"),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		want: `Container g1-c1 \(out of sync, allowed on host\)
+  State: NotFound \(configured: Running\)
+  Image: - \(configured: abc/xyz\)
+  Network g1-bridge: - \(configured: 172\.18\.101\.11\)
+  Network proxy-bridge: - \(configured: 172\.18\.201\.11\)`,
 	},
 	{
-		name: "Container Purge - Stop Existing Container Failure",
+		name: "Container Status - After Start",
 		config: buildSingleContainerConfig(
 			config.ContainerReference{
 				Group:     "g1",
@@ -2022,25 +3731,21 @@ var containerPurgeErrorTests = []struct {
 		},
 		ctxInfo: &testutils.TestContextInfo{
 			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
-				Containers: []*fakedocker.FakeContainerInitInfo{
-					{
-						Name:  "g1-c1",
-						Image: "abc/xyz",
-						State: docker.ContainerStateRunning,
-					},
-				},
-				FailContainerStop: utils.StringSet{
-					"g1-c1": {},
-				},
 				ValidImagesForPull: utils.StringSet{
 					"abc/xyz": {},
 				},
 			}),
 		},
-		want: `Failed to purge container g1-c1, reason:failed to stop the container, reason: failed to stop container g1-c1 on the fake docker host`,
+		startFirst: true,
+		wantInSync: true,
+		want: `Container g1-c1 \(in sync, allowed on host\)
+  State: Running \(configured: Running\)
+  Image: abc/xyz \(configured: abc/xyz\)
+  Network g1-bridge: 172\.18\.101\.11 \(configured: 172\.18\.101\.11\)
+  Network proxy-bridge: 172\.18\.201\.11 \(configured: 172\.18\.201\.11\)`,
 	},
 	{
-		name: "Container Purge - Remove Existing Container Failure",
+		name: "Container Status - Different Image",
 		config: buildSingleContainerConfig(
 			config.ContainerReference{
 				Group:     "g1",
@@ -2056,42 +3761,35 @@ var containerPurgeErrorTests = []struct {
 				Containers: []*fakedocker.FakeContainerInitInfo{
 					{
 						Name:  "g1-c1",
-						Image: "abc/xyz",
-						State: docker.ContainerStateRestarting,
+						Image: "abc/xyz:old",
+						State: docker.ContainerStateExited,
 					},
 				},
-				FailContainerRemove: utils.StringSet{
-					"g1-c1": {},
-				},
-				ValidImagesForPull: utils.StringSet{
-					"abc/xyz": {},
-				},
 			}),
 		},
-		want: `Failed to purge container g1-c1, reason:failed to remove the container, reason: failed to remove container g1-c1 on the fake docker host`,
+		want: `Container g1-c1 \(out of sync, allowed on host\)
+  State: Exited \(configured: Running\)
+  Image: abc/xyz:old \(configured: abc/xyz\)
+  Network g1-bridge: - \(configured: 172\.18\.101\.11\)
+  Network proxy-bridge: - \(configured: 172\.18\.201\.11\)`,
 	},
 }
 
-func TestContainerPurgeErrors(t *testing.T) {
+func TestContainerStatus(t *testing.T) {
 	t.Parallel()
 
-	for _, test := range containerPurgeErrorTests {
+	for _, test := range containerStatusTests {
 		tc := test
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-
 			buf := new(bytes.Buffer)
 			tc.ctxInfo.Logger = testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf)
-			if tc.ctxInfo.ContainerPurgeKillAttempts == 0 {
-				// Reduce the number of attempts to keep the tests executing quickly.
-				tc.ctxInfo.ContainerPurgeKillAttempts = 5
+			if tc.ctxInfo.ContainerPurgeGracePeriod == 0 {
+				// Reduce the grace period to keep the tests executing quickly.
+				tc.ctxInfo.ContainerPurgeGracePeriod = 100 * time.Millisecond
 			}
 			ctx := testutils.NewTestContext(tc.ctxInfo)
 
-			if tc.wantPanic {
-				defer testhelpers.ExpectPanicWithOutput(t, "container.Purge()", tc.name, buf, tc.want)
-			}
-
 			dep, gotErr := FromConfig(ctx, &tc.config)
 			if gotErr != nil {
 				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
@@ -2107,19 +3805,128 @@ func TestContainerPurgeErrors(t *testing.T) {
 				return
 			}
 
-			gotPurged, gotErr := ct.Purge(ctx, dc)
-			if gotErr == nil {
-				testhelpers.LogErrorNilWithOutput(t, "container.Purge()", tc.name, buf, tc.want)
+			if tc.startFirst {
+				if _, gotErr := ct.Start(ctx, dc, StartOptions{}); gotErr != nil {
+					testhelpers.LogErrorNotNilWithOutput(t, "container.Start()", tc.name, buf, gotErr)
+					return
+				}
+			}
+
+			got, gotErr := ct.Status(ctx, dc)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNilWithOutput(t, "container.Status()", tc.name, buf, gotErr)
 				return
 			}
-			if gotPurged {
-				testhelpers.LogCustomWithOutput(t, "container.Purge()", tc.name, buf, "gotPurged (true) != wantPurged (false)")
+			if got.InSync() != tc.wantInSync {
+				testhelpers.LogCustomWithOutput(t, "container.Status()", tc.name, buf, fmt.Sprintf("InSync() (%t) != wantInSync (%t)", got.InSync(), tc.wantInSync))
+				return
+			}
+			if !testhelpers.RegexMatchWithOutput(t, "container.Status()", tc.name, buf, "status string", tc.want, got.String()) {
 				return
 			}
+		})
+	}
+}
 
-			if !testhelpers.RegexMatchWithOutput(t, "container.Purge()", tc.name, buf, "gotErr error string", tc.want, gotErr.Error()) {
+var queryUnmanagedContainersTests = []struct {
+	name  string
+	group string
+	want  []string
+}{
+	{
+		name: "Query Unmanaged Containers - All Groups",
+		want: []string{"media-c2", "media-dl-c2"},
+	},
+	{
+		name:  "Query Unmanaged Containers - Group Prefix Of Another Group",
+		group: "media",
+		want:  []string{"media-c2"},
+	},
+	{
+		name:  "Query Unmanaged Containers - Group With Prefix Group",
+		group: "media-dl",
+		want:  []string{"media-dl-c2"},
+	},
+}
+
+func TestQueryUnmanagedContainers(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range queryUnmanagedContainersTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := config.Homelab{
+				Global: config.Global{
+					BaseDir: testhelpers.HomelabBaseDir(),
+				},
+				Groups: []config.ContainerGroup{
+					{
+						Name:  "media",
+						Order: 1,
+					},
+					{
+						Name:  "media-dl",
+						Order: 2,
+					},
+				},
+				Containers: []config.Container{
+					{
+						Info: config.ContainerReference{
+							Group:     "media",
+							Container: "c1",
+						},
+						Image: config.ContainerImage{
+							Image: "abc/xyz",
+						},
+						Lifecycle: config.ContainerLifecycle{
+							Order: 1,
+						},
+					},
+					{
+						Info: config.ContainerReference{
+							Group:     "media-dl",
+							Container: "c1",
+						},
+						Image: config.ContainerImage{
+							Image: "abc/xyz",
+						},
+						Lifecycle: config.ContainerLifecycle{
+							Order: 1,
+						},
+					},
+				},
+			}
+			var containers []*fakedocker.FakeContainerInitInfo
+			for _, n := range []string{"media-c1", "media-c2", "media-dl-c1", "media-dl-c2", "other-c1"} {
+				containers = append(containers, &fakedocker.FakeContainerInitInfo{
+					Name:  n,
+					Image: "abc/xyz",
+					State: docker.ContainerStateRunning,
+				})
+			}
+			ctx := testutils.NewTestContext(&testutils.TestContextInfo{
+				DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+					Containers: containers,
+				}),
+			})
+
+			dep, gotErr := FromConfig(ctx, &conf)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+				return
+			}
+
+			dc := docker.NewClient(ctx)
+			defer dc.Close()
+
+			got, gotErr := dep.QueryUnmanagedContainers(ctx, dc, tc.group)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "deployment.QueryUnmanagedContainers()", tc.name, gotErr)
 				return
 			}
+			testhelpers.CmpDiff(t, "deployment.QueryUnmanagedContainers()", tc.name, "unmanaged containers", tc.want, got)
 		})
 	}
 }
@@ -2254,6 +4061,112 @@ var containerDockerConfigTests = []struct {
 			},
 		},
 	},
+	{
+		name: "Container Docker Configs - Secrets",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Secrets: []config.Secret{
+				{
+					Name: "db-password",
+					File: "testdata/secrets/db-password.txt",
+				},
+				{
+					Name: "api-token",
+					File: "testdata/secrets/db-password.txt",
+				},
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "abc/xyz:latest",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "some-mount",
+								Type: "bind",
+								Src:  "testdata/dummy-base-dir/abc",
+								Dst:  "/abc",
+							},
+						},
+					},
+					Secrets: []config.ContainerSecret{
+						{
+							Name: "db-password",
+						},
+						{
+							Name:   "api-token",
+							Target: "/etc/app/token",
+						},
+					},
+				},
+			},
+		},
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		wantDockerConfigs: &containerDockerConfigs{
+			ContainerConfig: &dcontainer.Config{
+				Image: "abc/xyz:latest",
+			},
+			HostConfig: &dcontainer.HostConfig{
+				Binds: []string{
+					"testdata/dummy-base-dir/abc:/abc",
+					"testdata/dummy-base-dir/g1/c1/secrets/db-password:/run/secrets/db-password:ro",
+					"testdata/dummy-base-dir/g1/c1/secrets/api-token:/etc/app/token:ro",
+				},
+				NetworkMode: "none",
+			},
+		},
+	},
+	{
+		name: "Container Docker Configs - Init Disabled",
+		config: func() config.Homelab {
+			conf := buildSingleContainerNoNetworkConfig(
+				config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				"abc/xyz:latest")
+			conf.Containers[0].Runtime.Init = testhelpers.NewBool(false)
+			return conf
+		}(),
+		cRef: config.ContainerReference{
+			Group:     "g1",
+			Container: "c1",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewEmptyFakeDockerHost(),
+		},
+		wantDockerConfigs: &containerDockerConfigs{
+			ContainerConfig: &dcontainer.Config{
+				Image: "abc/xyz:latest",
+			},
+			HostConfig: &dcontainer.HostConfig{
+				Init:        testhelpers.NewBool(false),
+				NetworkMode: "none",
+			},
+		},
+	},
 }
 
 func TestContainerDockerConfigs(t *testing.T) {
@@ -2270,9 +4183,9 @@ func TestContainerDockerConfigs(t *testing.T) {
 			if tc.ctxInfo.InspectLevel == inspect.HomelabInspectLevelNone {
 				tc.ctxInfo.InspectLevel = inspect.HomelabInspectLevelDebug
 			}
-			if tc.ctxInfo.ContainerPurgeKillAttempts == 0 {
-				// Reduce the number of attempts to keep the tests executing quickly.
-				tc.ctxInfo.ContainerPurgeKillAttempts = 5
+			if tc.ctxInfo.ContainerPurgeGracePeriod == 0 {
+				// Reduce the grace period to keep the tests executing quickly.
+				tc.ctxInfo.ContainerPurgeGracePeriod = 100 * time.Millisecond
 			}
 			ctx := testutils.NewTestContext(tc.ctxInfo)
 
@@ -2296,6 +4209,290 @@ func TestContainerDockerConfigs(t *testing.T) {
 	}
 }
 
+func TestContainerStartWithSecrets(t *testing.T) {
+	t.Parallel()
+
+	tc := "Container Start - With Secrets"
+	baseDir := t.TempDir()
+	cRef := config.ContainerReference{
+		Group:     "g1",
+		Container: "c1",
+	}
+	conf := buildCustomSingleContainerConfig(cRef, "abc/xyz", func(ct *config.Container) {
+		ct.Secrets = []config.ContainerSecret{
+			{
+				Name: "db-password",
+			},
+		}
+	})
+	conf.Global.BaseDir = baseDir
+	conf.Global.Env = []config.ConfigEnv{
+		{
+			Var:   "SECRETS_DIR",
+			Value: "testdata/secrets",
+		},
+	}
+	conf.Secrets = []config.Secret{
+		{
+			Name: "db-password",
+			File: "$$SECRETS_DIR$$/db-password.txt",
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	ctx := testutils.NewTestContext(&testutils.TestContextInfo{
+		Logger: testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf),
+		DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+			ValidImagesForPull: utils.StringSet{
+				"abc/xyz": {},
+			},
+		}),
+	})
+
+	dep, gotErr := FromConfig(ctx, &conf)
+	if gotErr != nil {
+		testhelpers.LogErrorNotNil(t, "FromConfig()", tc, gotErr)
+		return
+	}
+	ct, gotErr := dep.queryContainer(cRef)
+	if gotErr != nil {
+		testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc, gotErr)
+		return
+	}
+
+	dc := docker.NewClient(ctx)
+	defer dc.Close()
+
+	// Start the container twice to ensure the read-only secret file
+	// written previously is replaced.
+	for range 2 {
+		_, gotErr = ct.Start(ctx, dc, StartOptions{})
+		if gotErr != nil {
+			testhelpers.LogErrorNotNilWithOutput(t, "container.start()", tc, buf, gotErr)
+			return
+		}
+	}
+
+	secretsDir := fmt.Sprintf("%s/g1/c1/secrets", baseDir)
+	dirInfo, err := os.Stat(secretsDir)
+	if err != nil {
+		testhelpers.LogErrorNotNil(t, "os.Stat()", tc, err)
+		return
+	}
+	testhelpers.CmpDiff(t, "container.start()", tc, "secrets dir perms", os.FileMode(0o700), dirInfo.Mode().Perm())
+
+	secretFile := fmt.Sprintf("%s/db-password", secretsDir)
+	fileInfo, err := os.Stat(secretFile)
+	if err != nil {
+		testhelpers.LogErrorNotNil(t, "os.Stat()", tc, err)
+		return
+	}
+	testhelpers.CmpDiff(t, "container.start()", tc, "secret file perms", os.FileMode(0o444), fileInfo.Mode().Perm())
+
+	got, err := os.ReadFile(secretFile)
+	if err != nil {
+		testhelpers.LogErrorNotNil(t, "os.ReadFile()", tc, err)
+		return
+	}
+	testhelpers.CmpDiff(t, "container.start()", tc, "secret file content", "s3cr3t-db-password\n", string(got))
+
+	_, gotErr = ct.Purge(ctx, dc, StopOptions{})
+	if gotErr != nil {
+		testhelpers.LogErrorNotNilWithOutput(t, "container.Purge()", tc, buf, gotErr)
+		return
+	}
+	if _, err := os.Stat(secretsDir); !os.IsNotExist(err) {
+		testhelpers.LogCustomWithOutput(t, "container.Purge()", tc, buf, fmt.Sprintf("secrets dir %s was not removed, os.Stat() err: %v", secretsDir, err))
+	}
+}
+
+func TestContainerStartChangedOnlyWithSecrets(t *testing.T) {
+	t.Parallel()
+
+	tc := "Container Start Changed Only - With Secrets"
+	baseDir := t.TempDir()
+	secretFile := fmt.Sprintf("%s/db-password.txt", t.TempDir())
+	cRef := config.ContainerReference{
+		Group:     "g1",
+		Container: "c1",
+	}
+	buildConfig := func() config.Homelab {
+		conf := buildCustomSingleContainerConfig(cRef, "abc/xyz", func(ct *config.Container) {
+			ct.Image.SkipImagePull = true
+			ct.Secrets = []config.ContainerSecret{
+				{
+					Name: "db-password",
+				},
+			}
+		})
+		conf.Global.BaseDir = baseDir
+		conf.Secrets = []config.Secret{
+			{
+				Name: "db-password",
+				File: secretFile,
+			},
+		}
+		return conf
+	}
+
+	buf := new(bytes.Buffer)
+	ctx := testutils.NewTestContext(&testutils.TestContextInfo{
+		Logger: testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf),
+		DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+			ExistingImages: utils.StringSet{
+				"abc/xyz": {},
+			},
+		}),
+		ContainerPurgeGracePeriod: 100 * time.Millisecond,
+	})
+
+	dc := docker.NewClient(ctx)
+	defer dc.Close()
+
+	steps := []struct {
+		secret        string
+		wantRecreated bool
+	}{
+		{
+			secret:        "s3cr3t-1",
+			wantRecreated: true,
+		},
+		{
+			secret:        "s3cr3t-1",
+			wantRecreated: false,
+		},
+		{
+			secret:        "s3cr3t-2",
+			wantRecreated: true,
+		},
+	}
+	for i, step := range steps {
+		if err := os.WriteFile(secretFile, []byte(step.secret), 0o600); err != nil {
+			testhelpers.LogErrorNotNil(t, "os.WriteFile()", tc, err)
+			return
+		}
+		// The secrets are resolved once per deployment.
+		conf := buildConfig()
+		dep, gotErr := FromConfig(ctx, &conf)
+		if gotErr != nil {
+			testhelpers.LogErrorNotNil(t, "FromConfig()", tc, gotErr)
+			return
+		}
+		ct, gotErr := dep.queryContainer(cRef)
+		if gotErr != nil {
+			testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc, gotErr)
+			return
+		}
+
+		logStart := buf.Len()
+		if _, gotErr := ct.Start(ctx, dc, StartOptions{ChangedOnly: true}); gotErr != nil {
+			testhelpers.LogErrorNotNilWithOutput(t, "container.Start()", tc, buf, gotErr)
+			return
+		}
+		gotRecreated := strings.Contains(buf.String()[logStart:], "Creating container g1-c1")
+		if gotRecreated != step.wantRecreated {
+			testhelpers.LogCustomWithOutput(t, "container.Start()", tc, buf, fmt.Sprintf("step %d: gotRecreated (%t) != wantRecreated (%t)", i, gotRecreated, step.wantRecreated))
+			return
+		}
+	}
+}
+
+func TestContainerStartPrunesSecrets(t *testing.T) {
+	t.Parallel()
+
+	tc := "Container Start - Prunes Secrets"
+	baseDir := t.TempDir()
+	secretFile := fmt.Sprintf("%s/s3cr3t.txt", t.TempDir())
+	if err := os.WriteFile(secretFile, []byte("s3cr3t"), 0o600); err != nil {
+		testhelpers.LogErrorNotNil(t, "os.WriteFile()", tc, err)
+		return
+	}
+	cRef := config.ContainerReference{
+		Group:     "g1",
+		Container: "c1",
+	}
+
+	buf := new(bytes.Buffer)
+	ctx := testutils.NewTestContext(&testutils.TestContextInfo{
+		Logger: testutils.NewCapturingTestLogger(tuxlog.LvlDebug, buf),
+		DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+			ExistingImages: utils.StringSet{
+				"abc/xyz": {},
+			},
+		}),
+		ContainerPurgeGracePeriod: 100 * time.Millisecond,
+	})
+
+	dc := docker.NewClient(ctx)
+	defer dc.Close()
+
+	secretsDir := fmt.Sprintf("%s/g1/c1/secrets", baseDir)
+	steps := []struct {
+		secrets []string
+		want    []string
+	}{
+		{
+			secrets: []string{"api-token", "db-password"},
+			want:    []string{"api-token", "db-password"},
+		},
+		{
+			secrets: []string{"db-password"},
+			want:    []string{"db-password"},
+		},
+		{
+			secrets: nil,
+			want:    nil,
+		},
+	}
+	for i, step := range steps {
+		conf := buildCustomSingleContainerConfig(cRef, "abc/xyz", func(ct *config.Container) {
+			ct.Image.SkipImagePull = true
+			for _, s := range step.secrets {
+				ct.Secrets = append(ct.Secrets, config.ContainerSecret{Name: s})
+			}
+		})
+		conf.Global.BaseDir = baseDir
+		conf.Secrets = []config.Secret{
+			{
+				Name: "api-token",
+				File: secretFile,
+			},
+			{
+				Name: "db-password",
+				File: secretFile,
+			},
+		}
+
+		dep, gotErr := FromConfig(ctx, &conf)
+		if gotErr != nil {
+			testhelpers.LogErrorNotNil(t, "FromConfig()", tc, gotErr)
+			return
+		}
+		ct, gotErr := dep.queryContainer(cRef)
+		if gotErr != nil {
+			testhelpers.LogErrorNotNil(t, "deployment.queryContainer()", tc, gotErr)
+			return
+		}
+		if _, gotErr := ct.Start(ctx, dc, StartOptions{}); gotErr != nil {
+			testhelpers.LogErrorNotNilWithOutput(t, "container.Start()", tc, buf, gotErr)
+			return
+		}
+
+		entries, err := os.ReadDir(secretsDir)
+		if err != nil && !os.IsNotExist(err) {
+			testhelpers.LogErrorNotNil(t, "os.ReadDir()", tc, err)
+			return
+		}
+		var got []string
+		for _, e := range entries {
+			got = append(got, e.Name())
+		}
+		if !testhelpers.CmpDiff(t, "container.Start()", tc, fmt.Sprintf("step %d secret files", i), step.want, got) {
+			return
+		}
+	}
+}
+
 func TestBuildMountSpecInvalidMountType(t *testing.T) {
 	t.Parallel()
 
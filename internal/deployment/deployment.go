@@ -12,22 +12,49 @@ import (
 )
 
 type Deployment struct {
-	Config            *config.Homelab
+	Config *config.Homelab
+	// Provenance is the position within the config files where every
+	// value in the config is defined, nil when the deployment was not
+	// built from the config files.
+	Provenance        config.Provenance
 	Groups            ContainerGroupMap
 	GroupsOrder       []string
 	Networks          NetworkMap
 	NetworksOrder     []string
 	allowedContainers containerSet
 	dockerConfigs     containerDockerConfigMap
+	secrets           secretMap
+	// configsDir is the directory which the relative paths within the
+	// config are resolved against.
+	configsDir string
 }
 
 func FromConfigsPath(ctx context.Context, configsPath string) (*Deployment, error) {
-	r, err := config.MergedConfigsReader(ctx, configsPath)
+	merged, err := config.ReadMergedConfigs(ctx, configsPath)
+	if err != nil {
+		return nil, err
+	}
+	r, err := merged.Reader()
 	if err != nil {
 		return nil, err
 	}
 
-	return FromReader(ctx, r)
+	conf := config.Homelab{}
+	err = conf.Parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &errorCollector{}
+	d := newDeployment(ctx, &conf, configsPath, v)
+	if v.failed() {
+		// Report the position within the config files where the element
+		// with the error is defined.
+		v.errs.AnnotateSources(merged.SourceIndex())
+		return nil, v.errs[0]
+	}
+	d.Provenance = merged.Provenance()
+	return d, nil
 }
 
 func FromReader(ctx context.Context, reader io.Reader) (*Deployment, error) {
@@ -39,41 +66,68 @@ func FromReader(ctx context.Context, reader io.Reader) (*Deployment, error) {
 	return FromConfig(ctx, &conf)
 }
 
+// FromConfig builds the deployment from the specified config, where the
+// relative paths within the config are resolved against the current
+// working directory.
 func FromConfig(ctx context.Context, conf *config.Homelab) (*Deployment, error) {
+	v := &errorCollector{}
+	d := newDeployment(ctx, conf, "", v)
+	if err := v.firstErr(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ValidateConfig validates the homelab config read from the specified
+// configs dir without stopping at the first error, and returns all the
+// errors encountered.
+func ValidateConfig(ctx context.Context, conf *config.Homelab, configsDir string) ValidationErrors {
+	v := &errorCollector{collectAll: true}
+	newDeployment(ctx, conf, configsDir, v)
+	return v.errs
+}
+
+func newDeployment(ctx context.Context, conf *config.Homelab, configsDir string, v *errorCollector) *Deployment {
 	d := Deployment{
 		Config:        conf,
 		dockerConfigs: containerDockerConfigMap{},
+		configsDir:    configsDir,
 	}
 
 	systemEnv := env.NewSystemConfigEnvManager(ctx)
-	envWithGlobal, err := validateGlobalConfig(ctx, systemEnv, &conf.Global)
-	if err != nil {
-		return nil, err
+	envWithGlobal := validateGlobalConfig(ctx, v, systemEnv, &conf.Global, configsDir)
+	if v.done() {
+		return nil
 	}
 
-	d.allowedContainers, err = validateHostsConfig(ctx, conf.Hosts)
-	if err != nil {
-		return nil, err
+	d.allowedContainers = validateHostsConfig(ctx, v, conf.Hosts)
+	if v.done() {
+		return nil
+	}
+
+	d.secrets = validateSecretsConfig(v, envWithGlobal, configsDir, conf.Secrets, conf.Global.ProcessEnvAllowlist)
+	if v.done() {
+		return nil
 	}
 
 	// First build the networks as they will be looked up while building
 	// the container groups and containers within.
 	var containerEndpoints map[config.ContainerReference]networkEndpointList
-	d.Networks, containerEndpoints, err = validateIPAMConfig(ctx, &conf.IPAM)
-	if err != nil {
-		return nil, err
+	d.Networks, containerEndpoints = validateIPAMConfig(ctx, v, &conf.IPAM)
+	if v.done() {
+		return nil
 	}
 	d.updateNetworksOrder()
 
-	d.Groups, err = validateGroupsConfig(conf.Groups)
-	if err != nil {
-		return nil, err
+	d.Groups = validateGroupsConfig(v, conf.Groups)
+	if v.done() {
+		return nil
 	}
 	d.updateGroupsOrder()
 
-	err = validateContainersConfig(ctx, envWithGlobal, conf.Containers, d.Groups, &conf.Global, containerEndpoints, d.allowedContainers)
-	if err != nil {
-		return nil, err
+	validateContainersConfig(ctx, v, envWithGlobal, configsDir, conf.Containers, d.Groups, &conf.Global, containerEndpoints, d.allowedContainers, d.secrets)
+	if v.failed() {
+		return nil
 	}
 
 	for _, g := range d.Groups {
@@ -83,7 +137,7 @@ func FromConfig(ctx context.Context, conf *config.Homelab) (*Deployment, error)
 		}
 	}
 
-	return &d, nil
+	return &d
 }
 
 func (d *Deployment) queryAllContainers() containerMap {
@@ -147,6 +201,27 @@ func (d *Deployment) QueryContainer(ctx context.Context, group, container string
 	return ContainerList{ct}, nil
 }
 
+// WithDependencies returns the specified containers along with all their
+// direct and transitive dependencies, ordered such that every container
+// appears after all its dependencies.
+func (d *Deployment) WithDependencies(ctx context.Context, containers ContainerList) ContainerList {
+	result := make(containerMap)
+	var add func(ct *Container)
+	add = func(ct *Container) {
+		if _, found := result[ct.config.Info]; found {
+			return
+		}
+		result[ct.config.Info] = ct
+		for _, dep := range ct.dependencies {
+			add(dep)
+		}
+	}
+	for _, ct := range containers {
+		add(ct)
+	}
+	return containerMapToList(result)
+}
+
 func (d *Deployment) QueryNetwork(ctx context.Context, network string) (NetworkList, error) {
 	net, err := d.queryNetwork(network)
 	if err != nil {
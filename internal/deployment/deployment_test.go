@@ -98,6 +98,16 @@ global:
         value: my-label-1-value
       - name: my-label-2
         value: my-label-2-value
+    resources:
+      memory: 4g
+      pidsLimit: 1000
+    logging:
+      driver: json-file
+      options:
+        - name: max-size
+          value: 10m
+        - name: max-file
+          value: 3
 ipam:
   networks:
     bridgeModeNetworks:
@@ -338,6 +348,8 @@ containers:
       capDrop:
         - NET_ADMIN
         - SYS_MODULE
+      pidMode: host
+      ipcMode: shareable
     health:
       cmd:
         - my-health-cmd
@@ -366,6 +378,29 @@ containers:
         - foo
         - bar-$$HUMAN_FRIENDLY_HOST_NAME$$
         - baz
+      init: true
+      ulimits:
+        - name: nofile
+          soft: 65536
+          hard: 131072
+        - name: nproc
+          soft: 4096
+      oomScoreAdj: -500
+      oomKillDisable: true
+    resources:
+      memory: 1g
+      memoryReservation: 512m
+      memorySwap: 2g
+      cpus: 1.5
+      cpuShares: 512
+      cpuset: 0-3,6
+      pidsLimit: 200
+      blkioWeight: 300
+    logging:
+      driver: journald
+      options:
+        - name: tag
+          value: "{{.Name}}"
   - info:
       group: group1
       container: ct2
@@ -559,6 +594,23 @@ ignore:
 							Value: "my-label-2-value",
 						},
 					},
+					Resources: config.ContainerResources{
+						Memory:    "4g",
+						PidsLimit: 1000,
+					},
+					Logging: config.ContainerLogging{
+						Driver: "json-file",
+						Options: []config.LoggingOption{
+							{
+								Name:  "max-size",
+								Value: "10m",
+							},
+							{
+								Name:  "max-file",
+								Value: "3",
+							},
+						},
+					},
 				},
 			},
 			IPAM: config.IPAM{
@@ -976,6 +1028,8 @@ ignore:
 							"NET_ADMIN",
 							"SYS_MODULE",
 						},
+						PidMode: "host",
+						IpcMode: "shareable",
 					},
 					Health: config.ContainerHealth{
 						Cmd: []string{
@@ -1016,6 +1070,39 @@ ignore:
 							"bar-FakeHost",
 							"baz",
 						},
+						Init: testhelpers.NewBool(true),
+						Ulimits: []config.Ulimit{
+							{
+								Name: "nofile",
+								Soft: 65536,
+								Hard: 131072,
+							},
+							{
+								Name: "nproc",
+								Soft: 4096,
+							},
+						},
+						OOMScoreAdj:    -500,
+						OOMKillDisable: true,
+					},
+					Resources: config.ContainerResources{
+						Memory:            "1g",
+						MemoryReservation: "512m",
+						MemorySwap:        "2g",
+						CPUs:              1.5,
+						CPUShares:         512,
+						CPUSet:            "0-3,6",
+						PidsLimit:         200,
+						BlkioWeight:       300,
+					},
+					Logging: config.ContainerLogging{
+						Driver: "journald",
+						Options: []config.LoggingOption{
+							{
+								Name:  "tag",
+								Value: "{{.Name}}",
+							},
+						},
 					},
 				},
 				{
@@ -1268,7 +1355,38 @@ ignore:
 								CgroupPermissions: "m",
 							},
 						},
+						Memory:            1073741824,
+						MemoryReservation: 536870912,
+						MemorySwap:        2147483648,
+						NanoCPUs:          1500000000,
+						CPUShares:         512,
+						CpusetCpus:        "0-3,6",
+						BlkioWeight:       300,
+						PidsLimit:         testhelpers.NewInt64(200),
+						OomKillDisable:    testhelpers.NewBool(true),
+						Ulimits: []*dcontainer.Ulimit{
+							{
+								Name: "nofile",
+								Soft: 65536,
+								Hard: 131072,
+							},
+							{
+								Name: "nproc",
+								Soft: 4096,
+								Hard: 4096,
+							},
+						},
+					},
+					LogConfig: dcontainer.LogConfig{
+						Type: "journald",
+						Config: map[string]string{
+							"tag": "{{.Name}}",
+						},
 					},
+					Init:        testhelpers.NewBool(true),
+					OomScoreAdj: -500,
+					PidMode:     "host",
+					IpcMode:     "shareable",
 					Mounts: []dmount.Mount{
 						{
 							Type:   "tmpfs",
@@ -1312,6 +1430,7 @@ ignore:
 						"MY_CONTAINER_ENV_VAR_3=/foo2/bar2/some-other-env-var-cmd",
 					},
 					Image:       "abc123/xyz123",
+					StopSignal:  "SIGTERM",
 					StopTimeout: testhelpers.NewInt(8),
 				},
 				HostConfig: &dcontainer.HostConfig{
@@ -1328,6 +1447,17 @@ ignore:
 						"dns-search-1",
 						"dns-search-2",
 					},
+					Resources: dcontainer.Resources{
+						Memory:    4294967296,
+						PidsLimit: testhelpers.NewInt64(1000),
+					},
+					LogConfig: dcontainer.LogConfig{
+						Type: "json-file",
+						Config: map[string]string{
+							"max-size": "10m",
+							"max-file": "3",
+						},
+					},
 				},
 				NetworkConfig: &dnetwork.NetworkingConfig{
 					EndpointsConfig: map[string]*dnetwork.EndpointSettings{
@@ -1354,6 +1484,7 @@ ignore:
 						"MY_CONTAINER_ENV_VAR_3=/foo2/bar2/some-other-env-var-cmd",
 					},
 					Image:       "abc123/xyz124",
+					StopSignal:  "SIGTERM",
 					StopTimeout: testhelpers.NewInt(8),
 				},
 				HostConfig: &dcontainer.HostConfig{
@@ -1370,6 +1501,17 @@ ignore:
 						"dns-search-1",
 						"dns-search-2",
 					},
+					Resources: dcontainer.Resources{
+						Memory:    4294967296,
+						PidsLimit: testhelpers.NewInt64(1000),
+					},
+					LogConfig: dcontainer.LogConfig{
+						Type: "json-file",
+						Config: map[string]string{
+							"max-size": "10m",
+							"max-file": "3",
+						},
+					},
 				},
 				NetworkConfig: &dnetwork.NetworkingConfig{
 					EndpointsConfig: map[string]*dnetwork.EndpointSettings{
@@ -1396,6 +1538,7 @@ ignore:
 						"MY_CONTAINER_ENV_VAR_3=/foo2/bar2/some-other-env-var-cmd",
 					},
 					Image:       "abc123/xyz125",
+					StopSignal:  "SIGTERM",
 					StopTimeout: testhelpers.NewInt(8),
 				},
 				HostConfig: &dcontainer.HostConfig{
@@ -1412,6 +1555,17 @@ ignore:
 						"dns-search-1",
 						"dns-search-2",
 					},
+					Resources: dcontainer.Resources{
+						Memory:    4294967296,
+						PidsLimit: testhelpers.NewInt64(1000),
+					},
+					LogConfig: dcontainer.LogConfig{
+						Type: "json-file",
+						Config: map[string]string{
+							"max-size": "10m",
+							"max-file": "3",
+						},
+					},
 				},
 				NetworkConfig: &dnetwork.NetworkingConfig{
 					EndpointsConfig: map[string]*dnetwork.EndpointSettings{
@@ -1438,6 +1592,7 @@ ignore:
 						"MY_CONTAINER_ENV_VAR_3=/foo2/bar2/some-other-env-var-cmd",
 					},
 					Image:       "abc123/xyz126",
+					StopSignal:  "SIGTERM",
 					StopTimeout: testhelpers.NewInt(8),
 				},
 				HostConfig: &dcontainer.HostConfig{
@@ -1454,6 +1609,17 @@ ignore:
 						"dns-search-1",
 						"dns-search-2",
 					},
+					Resources: dcontainer.Resources{
+						Memory:    4294967296,
+						PidsLimit: testhelpers.NewInt64(1000),
+					},
+					LogConfig: dcontainer.LogConfig{
+						Type: "json-file",
+						Config: map[string]string{
+							"max-size": "10m",
+							"max-file": "3",
+						},
+					},
 				},
 			},
 			config.ContainerReference{
@@ -1468,6 +1634,7 @@ ignore:
 						"MY_CONTAINER_ENV_VAR_3=/foo2/bar2/some-other-env-var-cmd",
 					},
 					Image:       "abc123/xyz127",
+					StopSignal:  "SIGTERM",
 					StopTimeout: testhelpers.NewInt(8),
 				},
 				HostConfig: &dcontainer.HostConfig{
@@ -1484,6 +1651,17 @@ ignore:
 						"dns-search-1",
 						"dns-search-2",
 					},
+					Resources: dcontainer.Resources{
+						Memory:    4294967296,
+						PidsLimit: testhelpers.NewInt64(1000),
+					},
+					LogConfig: dcontainer.LogConfig{
+						Type: "json-file",
+						Config: map[string]string{
+							"max-size": "10m",
+							"max-file": "3",
+						},
+					},
 				},
 			},
 			config.ContainerReference{
@@ -1498,6 +1676,7 @@ ignore:
 						"MY_CONTAINER_ENV_VAR_3=/foo2/bar2/some-other-env-var-cmd",
 					},
 					Image:       "abc123/xyz128",
+					StopSignal:  "SIGTERM",
 					StopTimeout: testhelpers.NewInt(8),
 				},
 				HostConfig: &dcontainer.HostConfig{
@@ -1514,6 +1693,17 @@ ignore:
 						"dns-search-1",
 						"dns-search-2",
 					},
+					Resources: dcontainer.Resources{
+						Memory:    4294967296,
+						PidsLimit: testhelpers.NewInt64(1000),
+					},
+					LogConfig: dcontainer.LogConfig{
+						Type: "json-file",
+						Config: map[string]string{
+							"max-size": "10m",
+							"max-file": "3",
+						},
+					},
 				},
 			},
 		},
@@ -1829,6 +2019,7 @@ var buildDeploymentFromConfigsPathTests = []struct {
 				ContainerConfig: &dcontainer.Config{
 					Domainname:  "somedomain",
 					Image:       "abc/xyz",
+					StopSignal:  "SIGTERM",
 					StopTimeout: testhelpers.NewInt(5),
 				},
 				HostConfig: &dcontainer.HostConfig{
@@ -1862,6 +2053,7 @@ var buildDeploymentFromConfigsPathTests = []struct {
 				ContainerConfig: &dcontainer.Config{
 					Domainname:  "somedomain",
 					Image:       "abc/xyz2",
+					StopSignal:  "SIGTERM",
 					StopTimeout: testhelpers.NewInt(5),
 				},
 				HostConfig: &dcontainer.HostConfig{
@@ -1891,6 +2083,7 @@ var buildDeploymentFromConfigsPathTests = []struct {
 				ContainerConfig: &dcontainer.Config{
 					Domainname:  "somedomain",
 					Image:       "abc/xyz3",
+					StopSignal:  "SIGTERM",
 					StopTimeout: testhelpers.NewInt(5),
 				},
 				HostConfig: &dcontainer.HostConfig{
@@ -1920,6 +2113,7 @@ var buildDeploymentFromConfigsPathTests = []struct {
 				ContainerConfig: &dcontainer.Config{
 					Domainname:  "somedomain",
 					Image:       "abc/xyz4",
+					StopSignal:  "SIGTERM",
 					StopTimeout: testhelpers.NewInt(5),
 				},
 				HostConfig: &dcontainer.HostConfig{
@@ -1932,6 +2126,85 @@ var buildDeploymentFromConfigsPathTests = []struct {
 			},
 		},
 	},
+	{
+		name:        "Config Env Files Relative To Configs Dir",
+		configsPath: "parse-configs-env-files",
+		want: &config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				Env: []config.ConfigEnv{
+					{
+						Var:       "DB_PASSWORD",
+						ValueFile: "secrets/db-password.txt",
+					},
+				},
+				EnvFiles: []string{
+					"common.env",
+				},
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Config: config.ContainerConfigOptions{
+						Env: []config.ConfigEnv{
+							{
+								Var:       "API_TOKEN",
+								ValueFile: "secrets/api-token.txt",
+							},
+						},
+					},
+					Image: config.ContainerImage{
+						Image: "abc/xyz",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Network: config.ContainerNetwork{
+						DomainName: "example.com",
+					},
+					Runtime: config.ContainerRuntime{
+						Env: []config.ContainerEnv{
+							{
+								Var:   "DB_PASSWORD",
+								Value: "my-db-password",
+							},
+							{
+								Var:   "API_TOKEN",
+								Value: "my-api-token",
+							},
+						},
+					},
+				},
+			},
+		},
+		wantDockerConfigs: containerDockerConfigMap{
+			config.ContainerReference{
+				Group:     "g1",
+				Container: "c1",
+			}: &containerDockerConfigs{
+				ContainerConfig: &dcontainer.Config{
+					Domainname: "example.com",
+					Env: []string{
+						"DB_PASSWORD=my-db-password",
+						"API_TOKEN=my-api-token",
+					},
+					Image: "abc/xyz",
+				},
+				HostConfig: &dcontainer.HostConfig{
+					NetworkMode: "none",
+				},
+			},
+		},
+	},
 }
 
 func TestBuildDeploymentFromConfigsPath(t *testing.T) {
@@ -1960,6 +2233,92 @@ func TestBuildDeploymentFromConfigsPath(t *testing.T) {
 	}
 }
 
+func TestBuildDeploymentFromConfigsPathSplitAcrossFiles(t *testing.T) {
+	t.Parallel()
+
+	p := fmt.Sprintf("%s/testdata/parse-configs-valid-split-across-files", testhelpers.Pwd())
+	got, gotErr := FromConfigsPath(testutils.NewVanillaTestContext(), p)
+	if gotErr != nil {
+		testhelpers.LogErrorNotNil(t, "FromConfigsPath()", "Split Across Files", gotErr)
+		return
+	}
+
+	want := &config.Homelab{
+		Global: config.Global{
+			BaseDir: "testdata/dummy-base-dir",
+		},
+		IPAM: config.IPAM{
+			Networks: config.Networks{
+				BridgeModeNetworks: []config.BridgeModeNetwork{
+					{
+						Name:              "net1",
+						HostInterfaceName: "docker-net1",
+						CIDR: config.NetworkCIDR{
+							V4: "172.18.100.0/24",
+						},
+						Priority: 1,
+						Containers: []config.ContainerIPInfo{
+							{
+								IP: config.ContainerIP{
+									IPv4: "172.18.100.11",
+								},
+								Container: config.ContainerReference{
+									Group:     "g1",
+									Container: "c1",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Groups: []config.ContainerGroup{
+			{
+				Name:  "g1",
+				Order: 1,
+			},
+		},
+		Containers: []config.Container{
+			{
+				Info: config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				Image: config.ContainerImage{
+					Image: "abc/xyz",
+				},
+				Lifecycle: config.ContainerLifecycle{
+					Order: 1,
+				},
+			},
+		},
+	}
+	testhelpers.CmpDiff(t, "FromConfigsPath()", "Split Across Files", "config", want, got.Config)
+}
+
+func TestBuildDeploymentFromConfigsPathProvenance(t *testing.T) {
+	t.Parallel()
+
+	p := fmt.Sprintf("%s/testdata/show-config-cmd", testhelpers.Pwd())
+	got, gotErr := FromConfigsPath(testutils.NewVanillaTestContext(), p)
+	if gotErr != nil {
+		testhelpers.LogErrorNotNil(t, "FromConfigsPath()", "Provenance", gotErr)
+		return
+	}
+
+	want := map[string]config.SourcePos{
+		"global.baseDir": {File: "common/global.yaml", Line: 2},
+		"ipam.networks.bridgeModeNetworks[1].cidr.v4": {File: "common/ipam.yaml", Line: 25},
+		"groups[1].order":               {File: "common/groups.yaml", Line: 5},
+		"containers[0].image.image":     {File: "g1/c1.yaml", Line: 6},
+		"containers[1].lifecycle.order": {File: "g1/c2.yaml", Line: 8},
+		"containers[2].info.container":  {File: "g2/c3.yaml", Line: 4},
+	}
+	for path, pos := range want {
+		testhelpers.CmpDiff(t, "FromConfigsPath()", "Provenance", path, pos, got.Provenance[path])
+	}
+}
+
 var buildDeploymentFromConfigsPathErrorTests = []struct {
 	name        string
 	configsPath string
@@ -1991,9 +2350,19 @@ var buildDeploymentFromConfigsPathErrorTests = []struct {
 		want:        `failed to read homelab config file [^ ]+/testdata/parse-configs-invalid-unreadable-config/invalid-symlink.yaml, reason: open [^ ]+/testdata/parse-configs-invalid-unreadable-config/invalid-symlink.yaml: no such file or directory`,
 	},
 	{
-		name:        "Deep merge configs fail",
-		configsPath: "parse-configs-invalid-deepmerge-fail",
-		want:        `failed to deep merge config file [^ ]+parse-configs-invalid-deepmerge-fail/config2.yaml, reason: error due to parameter with value of primitive type: only maps and slices/arrays can be merged, which means you cannot have define the same key twice for parameters that are not maps or slices/arrays`,
+		name:        "Merge configs conflict",
+		configsPath: "parse-configs-invalid-merge-conflict",
+		want:        `failed to merge config file [^ ]+parse-configs-invalid-merge-conflict/config2\.yaml, reason: global\.container\.stopSignal is defined with conflicting values in config1\.yaml:3 and config2\.yaml:3`,
+	},
+	{
+		name:        "Merge configs container conflict",
+		configsPath: "parse-configs-invalid-merge-conflict-container",
+		want:        `failed to merge config file [^ ]+parse-configs-invalid-merge-conflict-container/c1-b\.yaml, reason: containers\[g1/c1\]\.image\.image is defined with conflicting values in c1-a\.yaml:6 and c1-b\.yaml:6`,
+	},
+	{
+		name:        "Invalid config with source position",
+		configsPath: "validate-config-cmd-invalid",
+		want:        `common/global\.yaml:1: container stop timeout -1 cannot be negative in global container config`,
 	},
 	{
 		name:        "Invalid config key",
@@ -2203,249 +2572,790 @@ func TestBuildDeploymentFromConfigStringer(t *testing.T) {
 	}
 }
 
-var buildDeploymentFromConfigErrorTests = []struct {
-	name   string
-	config config.Homelab
-	want   string
-}{
-	{
-		name:   "Empty Base Dir",
-		config: config.Homelab{},
-		want:   `homelab base directory cannot be empty`,
-	},
-	{
-		name: "Non-Existing Base Dir Path",
-		config: config.Homelab{
-			Global: config.Global{
-				BaseDir: "/foo/bar",
+//nolint:paralleltest // Test sets environment variables.
+func TestBuildDeploymentFromConfigEnvSources(t *testing.T) {
+	testhelpers.SetTestEnv(t, testhelpers.TestEnvMap{
+		"HOMELAB_TEST_API_TOKEN": "my-api-token",
+	})
+
+	conf := config.Homelab{
+		Global: config.Global{
+			BaseDir: testhelpers.HomelabBaseDir(),
+			Env: []config.ConfigEnv{
+				{
+					Var:   "TZ",
+					Value: "Etc/UTC",
+				},
+				{
+					Var:          "API_TOKEN",
+					ValueFromEnv: "HOMELAB_TEST_API_TOKEN",
+				},
+			},
+			EnvFiles: []string{
+				"testdata/config-env-sources/common.env",
+				"testdata/config-env-sources/machine.env",
+			},
+			ProcessEnvAllowlist: []string{
+				"HOMELAB_TEST_API_TOKEN",
 			},
 		},
-		want: `os.Stat\(\) failed on homelab base directory path, reason: stat /foo/bar: no such file or directory`,
-	},
-	{
-		name: "Base Dir Path Points To A File",
-		config: config.Homelab{
-			Global: config.Global{
-				BaseDir: "testdata/dummy-base-dir/.empty",
+		Groups: []config.ContainerGroup{
+			{
+				Name:  "g1",
+				Order: 1,
 			},
 		},
-		want: `homelab base directory path testdata/dummy-base-dir/\.empty must be a directory`,
-	},
-	{
-		name: "Empty Global Config Env Var",
-		config: config.Homelab{
-			Global: config.Global{
-				BaseDir: testhelpers.HomelabBaseDir(),
-				Env: []config.ConfigEnv{
-					{
-						Value: "foo-bar",
+		Containers: []config.Container{
+			{
+				Info: config.ContainerReference{
+					Group:     "g1",
+					Container: "c1",
+				},
+				Config: config.ContainerConfigOptions{
+					Env: []config.ConfigEnv{
+						{
+							Var:       "DB_PASSWORD",
+							ValueFile: "testdata/config-env-sources/db-password.txt",
+						},
+					},
+				},
+				Image: config.ContainerImage{
+					Image: "foo/bar:123",
+				},
+				Lifecycle: config.ContainerLifecycle{
+					Order: 1,
+				},
+				Runtime: config.ContainerRuntime{
+					Env: []config.ContainerEnv{
+						{
+							Var:   "TZ",
+							Value: "$$TZ$$",
+						},
+						{
+							Var:   "DOMAIN",
+							Value: "$$DOMAIN$$",
+						},
+						{
+							Var:   "LOG_LEVEL",
+							Value: "$$LOG_LEVEL$$",
+						},
+						{
+							Var:   "API_TOKEN",
+							Value: "$$API_TOKEN$$",
+						},
+						{
+							Var:   "DB_PASSWORD",
+							Value: "$$DB_PASSWORD$$",
+						},
 					},
 				},
 			},
 		},
-		want: `empty env var in global config`,
+	}
+	want := []config.ContainerEnv{
+		{
+			Var:   "TZ",
+			Value: "America/Los_Angeles",
+		},
+		{
+			Var:   "DOMAIN",
+			Value: "example.com",
+		},
+		{
+			Var:   "LOG_LEVEL",
+			Value: "debug",
+		},
+		{
+			Var:   "API_TOKEN",
+			Value: "my-api-token",
+		},
+		{
+			Var:   "DB_PASSWORD",
+			Value: "my-db-password",
+		},
+	}
+
+	tc := "Config Env Sources"
+	got, gotErr := FromConfig(testutils.NewVanillaTestContext(), &conf)
+	if gotErr != nil {
+		testhelpers.LogErrorNotNil(t, "FromConfig()", tc, gotErr)
+		return
+	}
+	testhelpers.CmpDiff(t, "FromConfig()", tc, "container env", want, got.Config.Containers[0].Runtime.Env)
+}
+
+var queryContainersDependencyOrderTests = []struct {
+	name      string
+	group     string
+	container string
+	withDeps  bool
+	stopOrder bool
+	want      []string
+}{
+	{
+		name: "Query Containers Dependency Order - All Groups",
+		want: []string{"g1-c2", "g2-c1", "g2-c2", "g1-c1"},
 	},
 	{
-		name: "Duplicate Global Config Env Var",
-		config: config.Homelab{
-			Global: config.Global{
-				BaseDir: testhelpers.HomelabBaseDir(),
-				Env: []config.ConfigEnv{
+		name:      "Query Containers Dependency Order - All Groups - Stop Order",
+		stopOrder: true,
+		want:      []string{"g1-c2", "g1-c1", "g2-c2", "g2-c1"},
+	},
+	{
+		name:  "Query Containers Dependency Order - One Group",
+		group: "g2",
+		want:  []string{"g2-c1", "g2-c2"},
+	},
+	{
+		name:      "Query Containers Dependency Order - One Container",
+		group:     "g1",
+		container: "c1",
+		want:      []string{"g1-c1"},
+	},
+	{
+		name:      "Query Containers Dependency Order - One Container With Dependencies",
+		group:     "g1",
+		container: "c1",
+		withDeps:  true,
+		want:      []string{"g2-c1", "g2-c2", "g1-c1"},
+	},
+	{
+		name:      "Query Containers Dependency Order - One Container With Dependencies - Stop Order",
+		group:     "g1",
+		container: "c1",
+		withDeps:  true,
+		stopOrder: true,
+		want:      []string{"g1-c1", "g2-c2", "g2-c1"},
+	},
+}
+
+func TestQueryContainersDependencyOrder(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range queryContainersDependencyOrderTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := config.Homelab{
+				Global: config.Global{
+					BaseDir: testhelpers.HomelabBaseDir(),
+				},
+				Groups: []config.ContainerGroup{
 					{
-						Var:   "FOO",
-						Value: "foo-bar",
+						Name:  "g1",
+						Order: 1,
 					},
 					{
-						Var:   "FOO2",
-						Value: "foo-bar-2",
+						Name:  "g2",
+						Order: 2,
 					},
+				},
+				Containers: []config.Container{
 					{
-						Var:   "FOO",
-						Value: "foo-bar-3",
+						Info: config.ContainerReference{
+							Group:     "g1",
+							Container: "c1",
+						},
+						Image: config.ContainerImage{
+							Image: "foo/bar:123",
+						},
+						Lifecycle: config.ContainerLifecycle{
+							Order: 1,
+							DependsOn: []config.ContainerReference{
+								{
+									Group:     "g2",
+									Container: "c2",
+								},
+							},
+						},
+					},
+					{
+						Info: config.ContainerReference{
+							Group:     "g1",
+							Container: "c2",
+						},
+						Image: config.ContainerImage{
+							Image: "foo/bar:123",
+						},
+						Lifecycle: config.ContainerLifecycle{
+							Order: 2,
+						},
+					},
+					{
+						Info: config.ContainerReference{
+							Group:     "g2",
+							Container: "c1",
+						},
+						Image: config.ContainerImage{
+							Image: "foo/bar:123",
+						},
+						Lifecycle: config.ContainerLifecycle{
+							Order: 2,
+						},
+					},
+					{
+						Info: config.ContainerReference{
+							Group:     "g2",
+							Container: "c2",
+						},
+						Image: config.ContainerImage{
+							Image: "foo/bar:123",
+						},
+						Lifecycle: config.ContainerLifecycle{
+							Order: 1,
+							DependsOn: []config.ContainerReference{
+								{
+									Group:     "g2",
+									Container: "c1",
+								},
+							},
+						},
 					},
 				},
-			},
+			}
+			ctx := testutils.NewVanillaTestContext()
+			dep, gotErr := FromConfig(ctx, &conf)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+				return
+			}
+
+			var cts ContainerList
+			switch {
+			case tc.container != "":
+				cts, gotErr = dep.QueryContainer(ctx, tc.group, tc.container)
+			case tc.group != "":
+				cts, gotErr = dep.QueryAllContainersInGroup(ctx, tc.group)
+			default:
+				cts, gotErr = dep.QueryAllContainersInAllGroups(ctx)
+			}
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "Deployment.Query*()", tc.name, gotErr)
+				return
+			}
+			if tc.withDeps {
+				cts = dep.WithDependencies(ctx, cts)
+			}
+			if tc.stopOrder {
+				cts = cts.InStopOrder()
+			}
+
+			var got []string
+			for _, ct := range cts {
+				got = append(got, ct.Name())
+			}
+			if !testhelpers.CmpDiff(t, "Deployment.Query*()", tc.name, "container order", tc.want, got) {
+				return
+			}
+		})
+	}
+}
+
+var containerListOrderLevelsTests = []struct {
+	name      string
+	stopOrder bool
+	want      [][]string
+}{
+	{
+		name: "Container List Order Levels",
+		want: [][]string{
+			{"g1-c1", "g1-c2"},
+			{"g1-c3"},
+			{"g1-c4"},
+			{"g2-c1"},
 		},
-		want: `env var FOO specified more than once in global config`,
 	},
 	{
-		name: "Global Config Env Var Without Value And ValueCommand",
-		config: config.Homelab{
-			Global: config.Global{
-				BaseDir: testhelpers.HomelabBaseDir(),
-				Env: []config.ConfigEnv{
+		name:      "Container List Order Levels - Stop Order",
+		stopOrder: true,
+		want: [][]string{
+			{"g1-c2", "g1-c3"},
+			{"g1-c1"},
+			{"g1-c4"},
+			{"g2-c1"},
+		},
+	},
+}
+
+func TestContainerListOrderLevels(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range containerListOrderLevelsTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := config.Homelab{
+				Global: config.Global{
+					BaseDir: testhelpers.HomelabBaseDir(),
+				},
+				Groups: []config.ContainerGroup{
 					{
-						Var: "FOO",
+						Name:  "g1",
+						Order: 1,
+					},
+					{
+						Name:  "g2",
+						Order: 2,
+					},
+				},
+				Containers: []config.Container{
+					{
+						Info: config.ContainerReference{
+							Group:     "g1",
+							Container: "c1",
+						},
+						Image: config.ContainerImage{
+							Image: "foo/bar:123",
+						},
+						Lifecycle: config.ContainerLifecycle{
+							Order: 1,
+						},
+					},
+					{
+						Info: config.ContainerReference{
+							Group:     "g1",
+							Container: "c2",
+						},
+						Image: config.ContainerImage{
+							Image: "foo/bar:123",
+						},
+						Lifecycle: config.ContainerLifecycle{
+							Order: 1,
+						},
+					},
+					{
+						Info: config.ContainerReference{
+							Group:     "g1",
+							Container: "c3",
+						},
+						Image: config.ContainerImage{
+							Image: "foo/bar:123",
+						},
+						Lifecycle: config.ContainerLifecycle{
+							Order: 1,
+							DependsOn: []config.ContainerReference{
+								{
+									Group:     "g1",
+									Container: "c1",
+								},
+							},
+						},
+					},
+					{
+						Info: config.ContainerReference{
+							Group:     "g1",
+							Container: "c4",
+						},
+						Image: config.ContainerImage{
+							Image: "foo/bar:123",
+						},
+						Lifecycle: config.ContainerLifecycle{
+							Order: 2,
+						},
+					},
+					{
+						Info: config.ContainerReference{
+							Group:     "g2",
+							Container: "c1",
+						},
+						Image: config.ContainerImage{
+							Image: "foo/bar:123",
+						},
+						Lifecycle: config.ContainerLifecycle{
+							Order: 1,
+						},
 					},
 				},
+			}
+			ctx := testutils.NewVanillaTestContext()
+			dep, gotErr := FromConfig(ctx, &conf)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "FromConfig()", tc.name, gotErr)
+				return
+			}
+
+			cts, gotErr := dep.QueryAllContainersInAllGroups(ctx)
+			if gotErr != nil {
+				testhelpers.LogErrorNotNil(t, "Deployment.QueryAllContainersInAllGroups()", tc.name, gotErr)
+				return
+			}
+			if tc.stopOrder {
+				cts = cts.InStopOrder()
+			}
+
+			var got [][]string
+			for _, level := range cts.OrderLevels() {
+				var names []string
+				for _, ct := range level {
+					names = append(names, ct.Name())
+				}
+				got = append(got, names)
+			}
+			if !testhelpers.CmpDiff(t, "ContainerList.OrderLevels()", tc.name, "order levels", tc.want, got) {
+				return
+			}
+		})
+	}
+}
+
+var buildDeploymentFromConfigErrorTests = []struct {
+	name   string
+	config config.Homelab
+	want   string
+}{
+	{
+		name:   "Empty Base Dir",
+		config: config.Homelab{},
+		want:   `homelab base directory cannot be empty`,
+	},
+	{
+		name: "Non-Existing Base Dir Path",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: "/foo/bar",
 			},
 		},
-		want: `neither value nor valueCommand specified for env var FOO in global config`,
+		want: `os.Stat\(\) failed on homelab base directory path, reason: stat /foo/bar: no such file or directory`,
 	},
 	{
-		name: "Global Config Env Var With Both Value And ValueCommand",
+		name: "Base Dir Path Points To A File",
 		config: config.Homelab{
 			Global: config.Global{
-				BaseDir: testhelpers.HomelabBaseDir(),
-				Env: []config.ConfigEnv{
-					{
-						Var:   "FOO",
-						Value: "my-foo-bar",
-						ValueCommand: []string{
-							"/foo/bar/baz",
-						},
-					},
-				},
+				BaseDir: "testdata/dummy-base-dir/.empty",
 			},
 		},
-		want: `exactly one of value or valueCommand must be specified for env var FOO in global config`,
+		want: `homelab base directory path testdata/dummy-base-dir/\.empty must be a directory`,
 	},
 	{
-		name: "Global Config Empty Mount Def Name",
+		name: "Empty Global Config Env Var",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-				MountDefs: []config.Mount{
+				Env: []config.ConfigEnv{
 					{
-						Type:     "bind",
-						Src:      "/foo",
-						Dst:      "/bar",
-						ReadOnly: true,
+						Value: "foo-bar",
 					},
 				},
 			},
 		},
-		want: `mount name cannot be empty in global config mount defs`,
+		want: `empty env var in global config`,
 	},
 	{
-		name: "Global Config Duplicate Mount Defs",
+		name: "Duplicate Global Config Env Var",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-				MountDefs: []config.Mount{
+				Env: []config.ConfigEnv{
 					{
-						Name: "mount-foo1",
-						Type: "bind",
-						Src:  "/foo1",
-						Dst:  "/bar1",
+						Var:   "FOO",
+						Value: "foo-bar",
 					},
 					{
-						Name: "mount-foo2",
-						Type: "bind",
-						Src:  "/foo2",
-						Dst:  "/bar2",
+						Var:   "FOO2",
+						Value: "foo-bar-2",
 					},
 					{
-						Name: "mount-foo1",
-						Type: "bind",
-						Src:  "/foo3",
-						Dst:  "/bar3",
+						Var:   "FOO",
+						Value: "foo-bar-3",
 					},
 				},
 			},
 		},
-		want: `mount name mount-foo1 defined more than once in global config mount defs`,
+		want: `env var FOO specified more than once in global config`,
 	},
 	{
-		name: "Global Config Mount Def With Invalid Mount Type",
+		name: "Global Config Env Var Without Value And ValueCommand",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-				MountDefs: []config.Mount{
+				Env: []config.ConfigEnv{
 					{
-						Name: "foo",
-						Type: "garbage",
-						Src:  "/foo",
-						Dst:  "/bar",
+						Var: "FOO",
 					},
 				},
 			},
 		},
-		want: `unsupported mount type garbage for mount foo in global config mount defs`,
+		want: `none of value, valueCommand, valueFile or valueFromEnv specified for env var FOO in global config`,
 	},
 	{
-		name: "Global Config Mount Def With Empty Src",
+		name: "Global Config Env Var With Both Value And ValueCommand",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-				MountDefs: []config.Mount{
+				Env: []config.ConfigEnv{
 					{
-						Name: "foo",
-						Type: "bind",
-						Dst:  "/bar",
+						Var:   "FOO",
+						Value: "my-foo-bar",
+						ValueCommand: []string{
+							"/foo/bar/baz",
+						},
 					},
 				},
 			},
 		},
-		want: `bind mount name foo cannot have an empty value for src in global config mount defs`,
+		want: `exactly one of value, valueCommand, valueFile or valueFromEnv must be specified for env var FOO in global config`,
 	},
 	{
-		name: "Global Config Tmpfs Mount Def With Non-Empty Src",
+		name: "Global Config Undefined Env Var Reference",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
 				MountDefs: []config.Mount{
 					{
-						Name: "foo",
-						Type: "tmpfs",
-						Src:  "/foo",
-						Dst:  "/bar",
+						Name: "mount-def-1",
+						Type: "bind",
+						Src:  "$$HOMELB_BASE_DIR$$/foo",
+						Dst:  "/foo",
 					},
 				},
 			},
 		},
-		want: `tmpfs mount name foo cannot have a non-empty value for src in global config mount defs`,
+		want: `failed to apply config env to global config, reason: config env substitution failed for mountDefs\[0\]\.src, reason: config env variable HOMELB_BASE_DIR is not defined in "\$\$HOMELB_BASE_DIR\$\$/foo"`,
 	},
 	{
-		name: "Global Config Mount Def With Empty Dst",
+		name: "Global Config Env Var With Missing Value File",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-				MountDefs: []config.Mount{
+				Env: []config.ConfigEnv{
 					{
-						Name: "foo",
-						Type: "bind",
-						Src:  "/foo",
+						Var:       "FOO",
+						ValueFile: "testdata/config-env-sources/foo-bar.txt",
 					},
 				},
 			},
 		},
-		want: `mount name foo cannot have an empty value for dst in global config mount defs`,
+		want: `failed to read valueFile for env var FOO in global config, reason: open testdata/config-env-sources/foo-bar\.txt: no such file or directory`,
 	},
 	{
-		name: "Global Config Bind Mount Def With Tmpfs Size",
+		name: "Global Config Env Var With Value From Env Not In Allowlist",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-				MountDefs: []config.Mount{
+				Env: []config.ConfigEnv{
 					{
-						Name:      "foo",
-						Type:      "bind",
-						Src:       "/foo",
-						Dst:       "/bar",
-						TmpfsSize: 1000,
+						Var:          "FOO",
+						ValueFromEnv: "HOME",
 					},
 				},
 			},
 		},
-		want: `bind mount name foo cannot specify tmpfs size in global config mount defs`,
+		want: `valueFromEnv HOME for env var FOO in global config is not present in the global config processEnvAllowlist`,
 	},
 	{
-		name: "Global Config Tmpfs Mount Def With Negative Tmpfs Size",
+		name: "Global Config Env Var With Value From Env Not Set",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-				MountDefs: []config.Mount{
+				Env: []config.ConfigEnv{
 					{
-						Name:      "foo",
-						Type:      "tmpfs",
-						Dst:       "/bar",
-						TmpfsSize: -1000,
+						Var:          "FOO",
+						ValueFromEnv: "HOMELAB_TEST_UNSET_ENV_VAR",
 					},
 				},
+				ProcessEnvAllowlist: []string{
+					"HOMELAB_TEST_UNSET_ENV_VAR",
+				},
 			},
 		},
-		want: `tmpfs mount name foo cannot specify a negative tmpfs size -1000 in global config mount defs`,
+		want: `valueFromEnv HOMELAB_TEST_UNSET_ENV_VAR for env var FOO in global config is not set in the environment`,
+	},
+	{
+		name: "Global Config Empty Process Env Allowlist Entry",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				ProcessEnvAllowlist: []string{
+					"",
+				},
+			},
+		},
+		want: `empty env var in global config processEnvAllowlist`,
+	},
+	{
+		name: "Global Config Empty Env File Path",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				EnvFiles: []string{
+					"",
+				},
+			},
+		},
+		want: `empty env file path in global config`,
+	},
+	{
+		name: "Global Config Missing Env File",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				EnvFiles: []string{
+					"testdata/config-env-sources/foo-bar.env",
+				},
+			},
+		},
+		want: `failed to read env file testdata/config-env-sources/foo-bar\.env in global config, reason: open testdata/config-env-sources/foo-bar\.env: no such file or directory`,
+	},
+	{
+		name: "Global Config Invalid Env File",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				EnvFiles: []string{
+					"testdata/config-env-sources/db-password.txt",
+				},
+			},
+		},
+		want: `failed to read env file testdata/config-env-sources/db-password\.txt in global config, reason: line 1: expected VAR=value`,
+	},
+	{
+		name: "Global Config Empty Mount Def Name",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				MountDefs: []config.Mount{
+					{
+						Type:     "bind",
+						Src:      "/foo",
+						Dst:      "/bar",
+						ReadOnly: true,
+					},
+				},
+			},
+		},
+		want: `mount name cannot be empty in global config mount defs`,
+	},
+	{
+		name: "Global Config Duplicate Mount Defs",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				MountDefs: []config.Mount{
+					{
+						Name: "mount-foo1",
+						Type: "bind",
+						Src:  "/foo1",
+						Dst:  "/bar1",
+					},
+					{
+						Name: "mount-foo2",
+						Type: "bind",
+						Src:  "/foo2",
+						Dst:  "/bar2",
+					},
+					{
+						Name: "mount-foo1",
+						Type: "bind",
+						Src:  "/foo3",
+						Dst:  "/bar3",
+					},
+				},
+			},
+		},
+		want: `mount name mount-foo1 defined more than once in global config mount defs`,
+	},
+	{
+		name: "Global Config Mount Def With Invalid Mount Type",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				MountDefs: []config.Mount{
+					{
+						Name: "foo",
+						Type: "garbage",
+						Src:  "/foo",
+						Dst:  "/bar",
+					},
+				},
+			},
+		},
+		want: `unsupported mount type garbage for mount foo in global config mount defs`,
+	},
+	{
+		name: "Global Config Mount Def With Empty Src",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				MountDefs: []config.Mount{
+					{
+						Name: "foo",
+						Type: "bind",
+						Dst:  "/bar",
+					},
+				},
+			},
+		},
+		want: `bind mount name foo cannot have an empty value for src in global config mount defs`,
+	},
+	{
+		name: "Global Config Tmpfs Mount Def With Non-Empty Src",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				MountDefs: []config.Mount{
+					{
+						Name: "foo",
+						Type: "tmpfs",
+						Src:  "/foo",
+						Dst:  "/bar",
+					},
+				},
+			},
+		},
+		want: `tmpfs mount name foo cannot have a non-empty value for src in global config mount defs`,
+	},
+	{
+		name: "Global Config Mount Def With Empty Dst",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				MountDefs: []config.Mount{
+					{
+						Name: "foo",
+						Type: "bind",
+						Src:  "/foo",
+					},
+				},
+			},
+		},
+		want: `mount name foo cannot have an empty value for dst in global config mount defs`,
+	},
+	{
+		name: "Global Config Bind Mount Def With Tmpfs Size",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				MountDefs: []config.Mount{
+					{
+						Name:      "foo",
+						Type:      "bind",
+						Src:       "/foo",
+						Dst:       "/bar",
+						TmpfsSize: 1000,
+					},
+				},
+			},
+		},
+		want: `bind mount name foo cannot specify tmpfs size in global config mount defs`,
+	},
+	{
+		name: "Global Config Tmpfs Mount Def With Negative Tmpfs Size",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				MountDefs: []config.Mount{
+					{
+						Name:      "foo",
+						Type:      "tmpfs",
+						Dst:       "/bar",
+						TmpfsSize: -1000,
+					},
+				},
+			},
+		},
+		want: `tmpfs mount name foo cannot specify a negative tmpfs size -1000 in global config mount defs`,
 	},
 	{
 		name: "Global Container Config Negative Stop Timeout",
@@ -2459,6 +3369,18 @@ var buildDeploymentFromConfigErrorTests = []struct {
 		},
 		want: `container stop timeout -1 cannot be negative in global container config`,
 	},
+	{
+		name: "Global Container Config Negative Purge Grace Period",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				Container: config.GlobalContainer{
+					PurgeGracePeriod: -1,
+				},
+			},
+		},
+		want: `container purge grace period -1 cannot be negative in global container config`,
+	},
 	{
 		name: "Global Container Config Restart Policy MaxRetryCount Set With Non-On-Failure Mode",
 		config: config.Homelab{
@@ -2804,6 +3726,48 @@ var buildDeploymentFromConfigErrorTests = []struct {
 		},
 		want: `empty label value for label FOO in global container config`,
 	},
+	{
+		name: "Global Container Config Invalid Memory",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				Container: config.GlobalContainer{
+					Resources: config.ContainerResources{
+						Memory: "1foobar",
+					},
+				},
+			},
+		},
+		want: `invalid memory 1foobar in global container config, reason: invalid suffix: 'foobar'`,
+	},
+	{
+		name: "Global Container Config Negative Pids Limit",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				Container: config.GlobalContainer{
+					Resources: config.ContainerResources{
+						PidsLimit: -2,
+					},
+				},
+			},
+		},
+		want: `pids limit -2 cannot be less than -1 in global container config`,
+	},
+	{
+		name: "Global Container Config Invalid Logging Driver",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				Container: config.GlobalContainer{
+					Logging: config.ContainerLogging{
+						Driver: "foobar",
+					},
+				},
+			},
+		},
+		want: `invalid logging driver foobar in global container config, valid values are \[ 'none', 'local', 'json-file', 'syslog', 'journald', 'gelf', 'fluentd', 'awslogs', 'splunk', 'etwlogs', 'gcplogs', 'logentries' \]`,
+	},
 	{
 		name: "Empty Bridge Mode Network Name",
 		config: config.Homelab{
@@ -4915,14 +5879,1762 @@ var buildDeploymentFromConfigErrorTests = []struct {
 				},
 			},
 		},
-		want: `group g1 cannot have a non-positive order -1`,
+		want: `group g1 cannot have a non-positive order -1`,
+	},
+	{
+		name: "Container Group Definition Missing",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+				},
+			},
+		},
+		want: `group definition missing in groups config for the container {Group:g1 Container:c1} in the containers config`,
+	},
+	{
+		name: "Duplicate Container Definition",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c2",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar2:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar3:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+			},
+		},
+		want: `container {Group:g1 Container:c1} defined more than once in the containers config`,
+	},
+	{
+		name: "Empty Container Config Env Var",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Config: config.ContainerConfigOptions{
+						Env: []config.ConfigEnv{
+							{
+								Value: "foo-bar",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `empty env var in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Duplicate Container Config Env Var",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Config: config.ContainerConfigOptions{
+						Env: []config.ConfigEnv{
+							{
+								Var:   "FOO",
+								Value: "foo-bar",
+							},
+							{
+								Var:   "FOO2",
+								Value: "foo-bar-2",
+							},
+							{
+								Var:   "FOO",
+								Value: "foo-bar-3",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `env var FOO specified more than once in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Env Var Without Value And ValueCommand",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Config: config.ContainerConfigOptions{
+						Env: []config.ConfigEnv{
+							{
+								Var: "FOO",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `none of value, valueCommand, valueFile or valueFromEnv specified for env var FOO in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Env Var With Both Value And ValueCommand",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Config: config.ContainerConfigOptions{
+						Env: []config.ConfigEnv{
+							{
+								Var:   "FOO",
+								Value: "my-foo-bar",
+								ValueCommand: []string{
+									"/foo/bar/baz",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `exactly one of value, valueCommand, valueFile or valueFromEnv must be specified for env var FOO in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Undefined Env Var Reference",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "mount-1",
+								Type: "bind",
+								Src:  "$$CONTAINR_DATA_DIR$$/foo",
+								Dst:  "/foo",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `failed to apply config env to container {Group: g1 Container:c1} config, reason: config env substitution failed for fs\.mounts\[0\]\.src, reason: config env variable CONTAINR_DATA_DIR is not defined in "\$\$CONTAINR_DATA_DIR\$\$/foo"`,
+	},
+	{
+		name: "Container Config Required Env Var Not Set",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Runtime: config.ContainerRuntime{
+						Env: []config.ContainerEnv{
+							{
+								Var:   "DB_PASSWORD",
+								Value: "$$DB_PASSWORD:?must be set$$",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `failed to apply config env to container {Group: g1 Container:c1} config, reason: config env substitution failed for runtime\.env\[0\]\.value, reason: required config env variable DB_PASSWORD is not set: must be set in "\$\$DB_PASSWORD:\?must be set\$\$"`,
+	},
+	{
+		name: "Container Config Malformed Env Var Reference",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Network: config.ContainerNetwork{
+						HostName: "$$HOST-NAME$$",
+					},
+				},
+			},
+		},
+		want: `failed to apply config env to container {Group: g1 Container:c1} config, reason: config env substitution failed for network\.hostName, reason: malformed config env reference \$\$HOST-NAME\$\$ in "\$\$HOST-NAME\$\$"`,
+	},
+	{
+		name: "Container Config Malformed Env Var Reference Operator",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Runtime: config.ContainerRuntime{
+						Args: []string{
+							"--data=$$DATA_DIR:x$$",
+						},
+					},
+				},
+			},
+		},
+		want: `failed to apply config env to container {Group: g1 Container:c1} config, reason: config env substitution failed for runtime\.args\[0\], reason: malformed config env reference \$\$DATA_DIR:x\$\$ in "--data=\$\$DATA_DIR:x\$\$"`,
+	},
+	{
+		name: "Container Config Unterminated Env Var Reference",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "mount-1",
+								Type: "bind",
+								Src:  "$$DATA_DIR/foo",
+								Dst:  "/foo",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `failed to apply config env to container {Group: g1 Container:c1} config, reason: config env substitution failed for fs\.mounts\[0\]\.src, reason: malformed config env reference \$\$DATA_DIR/foo in "\$\$DATA_DIR/foo"`,
+	},
+	{
+		name: "Container Config Env Var With Value From Env Not In Allowlist",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				ProcessEnvAllowlist: []string{
+					"USER",
+				},
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Config: config.ContainerConfigOptions{
+						Env: []config.ConfigEnv{
+							{
+								Var:          "FOO",
+								ValueFromEnv: "HOME",
+							},
+						},
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+			},
+		},
+		want: `valueFromEnv HOME for env var FOO in container {Group: g1 Container:c1} config is not present in the global config processEnvAllowlist`,
+	},
+	{
+		name: "Empty Container Config Image",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+			},
+		},
+		want: `image cannot be empty in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config SkipImagePull And IgnoreImagePullFailures Both Set To True",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image:                   "foo/bar:123",
+						SkipImagePull:           true,
+						IgnoreImagePullFailures: true,
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+			},
+		},
+		want: `ignoreImagePullFailures cannot be true when skipImagePull is true in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config SkipImagePull And PullImageBeforeStop Both Set To True",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image:               "foo/bar:123",
+						SkipImagePull:       true,
+						PullImageBeforeStop: true,
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+			},
+		},
+		want: `pullImageBeforeStop cannot be true when skipImagePull is true in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Empty Container Config Label Name",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Metadata: config.ContainerMetadata{
+						Labels: []config.Label{
+							{
+								Value: "foo-bar",
+							},
+						},
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+			},
+		},
+		want: `empty label name in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Duplicate Container Config Label Name",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Metadata: config.ContainerMetadata{
+						Labels: []config.Label{
+							{
+								Name:  "FOO",
+								Value: "foo-bar",
+							},
+							{
+								Name:  "FOO2",
+								Value: "foo-bar-2",
+							},
+							{
+								Name:  "FOO",
+								Value: "foo-bar-3",
+							},
+						},
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+			},
+		},
+		want: `label name FOO specified more than once in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Empty Label Value",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Metadata: config.ContainerMetadata{
+						Labels: []config.Label{
+							{
+								Name: "FOO",
+							},
+						},
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
+			},
+		},
+		want: `empty label value for label FOO in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Empty Order",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+				},
+			},
+		},
+		want: `container order 0 cannot be non-positive in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Negative Order",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: -1,
+					},
+				},
+			},
+		},
+		want: `container order -1 cannot be non-positive in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Restart Policy MaxRetryCount Set With Non-On-Failure Mode",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+						RestartPolicy: config.ContainerRestartPolicy{
+							Mode:          "always",
+							MaxRetryCount: 5,
+						},
+					},
+				},
+			},
+		},
+		want: `restart policy max retry count can be set only when the mode is on-failure in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Invalid Restart Policy Mode",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+						RestartPolicy: config.ContainerRestartPolicy{
+							Mode: "garbage",
+						},
+					},
+				},
+			},
+		},
+		want: `invalid restart policy mode garbage in container {Group: g1 Container:c1} config, valid values are \[ 'no', 'always', 'on-failure', 'unless-stopped' \]`,
+	},
+	{
+		name: "Container Config Negative Restart Policy MaxRetryCount",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+						RestartPolicy: config.ContainerRestartPolicy{
+							Mode:          "on-failure",
+							MaxRetryCount: -1,
+						},
+					},
+				},
+			},
+		},
+		want: `restart policy max retry count -1 cannot be negative in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Negative StopTimeout",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order:       1,
+						StopTimeout: -1,
+					},
+				},
+			},
+		},
+		want: `container stop timeout -1 cannot be negative in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Negative PurgeGracePeriod",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order:            1,
+						PurgeGracePeriod: -1,
+					},
+				},
+			},
+		},
+		want: `container purge grace period -1 cannot be negative in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Negative WaitForHealthyTimeout",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order:                 1,
+						WaitForHealthy:        true,
+						WaitForHealthyTimeout: -1,
+					},
+				},
+			},
+		},
+		want: `container wait for healthy timeout -1 cannot be negative in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config WaitForHealthyTimeout Without WaitForHealthy",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order:                 1,
+						WaitForHealthyTimeout: 10,
+					},
+				},
+			},
+		},
+		want: `container wait for healthy timeout cannot be set without setting waitForHealthy in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config PrimaryUserGroup Without User",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					User: config.ContainerUser{
+						PrimaryGroup: "my-user-group",
+					},
+				},
+			},
+		},
+		want: `container user primary group cannot be set without setting the user in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Device Missing Src And Dst",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Devices: config.ContainerDevice{
+							Static: []config.Device{
+								{},
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `device src cannot be empty in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Device Missing Src",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Devices: config.ContainerDevice{
+							Static: []config.Device{
+								{
+									Dst: "/dev/my-target-dev",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `device src cannot be empty in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Dynamic Device Invalid Command",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Devices: config.ContainerDevice{
+							DynamicCommand: []string{
+								"garbage-command",
+								"garbage-arg1",
+								"garbage-arg2",
+								"garbage-arg3",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `invalid fake executor command garbage-command \["garbage-arg1" "garbage-arg2" "garbage-arg3"\]`,
+	},
+	{
+		name: "Container Config Empty Mount Name",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Type:     "bind",
+								Src:      "/foo",
+								Dst:      "/bar",
+								ReadOnly: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `mount name cannot be empty in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Duplicate Mounts Within Container Config",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "mount-foo1",
+								Type: "bind",
+								Src:  "/foo1",
+								Dst:  "/bar1",
+							},
+							{
+								Name: "mount-foo2",
+								Type: "bind",
+								Src:  "/foo2",
+								Dst:  "/bar2",
+							},
+							{
+								Name: "mount-foo1",
+								Type: "bind",
+								Src:  "/foo3",
+								Dst:  "/bar3",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `mount name mount-foo1 defined more than once in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Duplicate Mounts Within Container And Global Configs Combined",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				Container: config.GlobalContainer{
+					Mounts: []config.Mount{
+						{
+							Name: "mount-foo2",
+							Type: "bind",
+							Src:  "/foo2-global",
+							Dst:  "/bar2-global",
+						},
+						{
+							Name: "mount-foo3",
+							Type: "bind",
+							Src:  "/foo3",
+							Dst:  "/bar3",
+						},
+					},
+				},
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "mount-foo1",
+								Type: "bind",
+								Src:  "/foo1",
+								Dst:  "/bar1",
+							},
+							{
+								Name: "mount-foo2",
+								Type: "bind",
+								Src:  "/foo2",
+								Dst:  "/bar2",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `mount name mount-foo2 defined more than once in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Mount With Invalid Mount Type",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "foo",
+								Type: "garbage",
+								Src:  "/foo",
+								Dst:  "/bar",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `unsupported mount type garbage for mount foo in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Mount With Empty Src",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "foo",
+								Type: "bind",
+								Dst:  "/bar",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `bind mount name foo cannot have an empty value for src in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Tmpfs Mount With Non-Empty Src",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "foo",
+								Type: "tmpfs",
+								Src:  "/foo",
+								Dst:  "/bar",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `tmpfs mount name foo cannot have a non-empty value for src in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Mount With Empty Dst",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "foo",
+								Type: "bind",
+								Src:  "/foo",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `mount name foo cannot have an empty value for dst in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Bind Mount With Tmpfs Size",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name:      "foo",
+								Type:      "bind",
+								Src:       "/foo",
+								Dst:       "/bar",
+								TmpfsSize: 10000,
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `bind mount name foo cannot specify tmpfs size in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Tmpfs Mount With Negative Tmpfs Size",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name:      "foo",
+								Type:      "tmpfs",
+								Dst:       "/bar",
+								TmpfsSize: -10000,
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `tmpfs mount name foo cannot specify a negative tmpfs size -10000 in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Mount Def Reference Not Found",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				MountDefs: []config.Mount{
+					{
+						Name: "foo",
+						Type: "bind",
+						Src:  "/foo",
+						Dst:  "/bar",
+					},
+				},
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "foo2",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `mount specified by just the name foo2 not found in defs in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Duplicate Mount Name Only With Global Container Config Mount",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				MountDefs: []config.Mount{
+					{
+						Name: "foo",
+						Type: "bind",
+						Src:  "/foo",
+						Dst:  "/bar",
+					},
+				},
+				Container: config.GlobalContainer{
+					Mounts: []config.Mount{
+						{
+							Name: "foo1",
+							Type: "bind",
+							Src:  "/foo1",
+							Dst:  "/bar1",
+						},
+						{
+							Name: "foo2",
+							Type: "bind",
+							Src:  "/foo2",
+							Dst:  "/bar2",
+						},
+					},
+				},
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "foo2",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `mount name foo2 defined more than once in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Duplicate Mount Name And Def With Global Container Config Mount",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+				MountDefs: []config.Mount{
+					{
+						Name: "foo",
+						Type: "bind",
+						Src:  "/foo",
+						Dst:  "/bar",
+					},
+				},
+				Container: config.GlobalContainer{
+					Mounts: []config.Mount{
+						{
+							Name: "foo1",
+							Type: "bind",
+							Src:  "/foo1",
+							Dst:  "/bar1",
+						},
+						{
+							Name: "foo2",
+							Type: "bind",
+							Src:  "/foo2",
+							Dst:  "/bar2",
+						},
+					},
+				},
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Filesystem: config.ContainerFilesystem{
+						Mounts: []config.Mount{
+							{
+								Name: "foo2",
+								Type: "bind",
+								Src:  "/foo-something-else",
+								Dst:  "/bar-something-else",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `mount name foo2 defined more than once in container {Group: g1 Container:c1} config mounts`,
+	},
+	{
+		name: "Container Config Published Port - Container Port Empty",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Network: config.ContainerNetwork{
+						PublishedPorts: []config.PublishedPort{
+							{
+								Protocol: "tcp",
+								HostIP:   "127.0.0.1",
+								HostPort: "5001",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `unable to convert published container port  to an integer, reason: strconv.ParseInt: parsing "": invalid syntax`,
+	},
+	{
+		name: "Container Config Published Port - Container Port Non Integer",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Network: config.ContainerNetwork{
+						PublishedPorts: []config.PublishedPort{
+							{
+								ContainerPort: "-6365foobar9563",
+								Protocol:      "tcp",
+								HostIP:        "127.0.0.1",
+								HostPort:      "5001",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `unable to convert published container port -6365foobar9563 to an integer, reason: strconv.ParseInt: parsing "-6365foobar9563": invalid syntax`,
+	},
+	{
+		name: "Container Config Published Port - Container Port Negative",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Network: config.ContainerNetwork{
+						PublishedPorts: []config.PublishedPort{
+							{
+								ContainerPort: "-1",
+								Protocol:      "tcp",
+								HostIP:        "127.0.0.1",
+								HostPort:      "5001",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `published container port -1 cannot be non-positive in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Group Definition Missing",
+		name: "Container Config Published Port - Protocol Empty",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
 			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
+				},
+			},
 			Containers: []config.Container{
 				{
 					Info: config.ContainerReference{
@@ -4932,13 +7644,25 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Image: config.ContainerImage{
 						Image: "foo/bar:123",
 					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Network: config.ContainerNetwork{
+						PublishedPorts: []config.PublishedPort{
+							{
+								ContainerPort: "10001",
+								HostIP:        "127.0.0.1",
+								HostPort:      "5001",
+							},
+						},
+					},
 				},
 			},
 		},
-		want: `group definition missing in groups config for the container {Group:g1 Container:c1} in the containers config`,
+		want: `published container port 10001 specifies an invalid protocol  in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Duplicate Container Definition",
+		name: "Container Config Published Port - Protocol Invalid",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -4961,37 +7685,100 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
+					Network: config.ContainerNetwork{
+						PublishedPorts: []config.PublishedPort{
+							{
+								ContainerPort: "10001",
+								Protocol:      "garbage",
+								HostIP:        "127.0.0.1",
+								HostPort:      "5001",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `published container port 10001 specifies an invalid protocol garbage in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Published Port - Host IP Empty",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
 				},
+			},
+			Containers: []config.Container{
 				{
 					Info: config.ContainerReference{
 						Group:     "g1",
-						Container: "c2",
+						Container: "c1",
 					},
 					Image: config.ContainerImage{
-						Image: "foo/bar2:123",
+						Image: "foo/bar:123",
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
+					Network: config.ContainerNetwork{
+						PublishedPorts: []config.PublishedPort{
+							{
+								ContainerPort: "10001",
+								Protocol:      "tcp",
+								HostPort:      "5001",
+							},
+						},
+					},
+				},
+			},
+		},
+		want: `published host IP cannot be empty for container port 10001 in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Config Published Port - Host IP Invalid",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
 				},
+			},
+			Containers: []config.Container{
 				{
 					Info: config.ContainerReference{
 						Group:     "g1",
 						Container: "c1",
 					},
 					Image: config.ContainerImage{
-						Image: "foo/bar3:123",
+						Image: "foo/bar:123",
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
+					Network: config.ContainerNetwork{
+						PublishedPorts: []config.PublishedPort{
+							{
+								ContainerPort: "10001",
+								Protocol:      "tcp",
+								HostIP:        "abc.def.ghi.jkl",
+								HostPort:      "5001",
+							},
+						},
+					},
 				},
 			},
 		},
-		want: `container {Group:g1 Container:c1} defined more than once in the containers config`,
+		want: `published host IP abc\.def\.ghi\.jkl for container port 10001 is invalid in container {Group: g1 Container:c1} config, reason: ParseAddr\("abc\.def\.ghi\.jkl"\): unexpected character \(at "abc\.def\.ghi\.jkl"\)`,
 	},
 	{
-		name: "Empty Container Config Env Var",
+		name: "Container Config Published Port - Host Port Empty",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5014,20 +7801,22 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Config: config.ContainerConfigOptions{
-						Env: []config.ConfigEnv{
+					Network: config.ContainerNetwork{
+						PublishedPorts: []config.PublishedPort{
 							{
-								Value: "foo-bar",
+								ContainerPort: "10001",
+								Protocol:      "tcp",
+								HostIP:        "127.0.0.1",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `empty env var in container {Group: g1 Container:c1} config`,
+		want: `unable to convert published host port  to an integer, reason: strconv.ParseInt: parsing "": invalid syntax`,
 	},
 	{
-		name: "Duplicate Container Config Env Var",
+		name: "Container Config Published Port - Host Port Non Integer",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5050,29 +7839,23 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Config: config.ContainerConfigOptions{
-						Env: []config.ConfigEnv{
-							{
-								Var:   "FOO",
-								Value: "foo-bar",
-							},
-							{
-								Var:   "FOO2",
-								Value: "foo-bar-2",
-							},
+					Network: config.ContainerNetwork{
+						PublishedPorts: []config.PublishedPort{
 							{
-								Var:   "FOO",
-								Value: "foo-bar-3",
+								ContainerPort: "10001",
+								Protocol:      "tcp",
+								HostIP:        "127.0.0.1",
+								HostPort:      "9876garbage0123",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `env var FOO specified more than once in container {Group: g1 Container:c1} config`,
+		want: `unable to convert published host port 9876garbage0123 to an integer, reason: strconv.ParseInt: parsing "9876garbage0123": invalid syntax`,
 	},
 	{
-		name: "Container Config Env Var Without Value And ValueCommand",
+		name: "Container Config Published Port - Host Port Negative",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5095,20 +7878,23 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Config: config.ContainerConfigOptions{
-						Env: []config.ConfigEnv{
+					Network: config.ContainerNetwork{
+						PublishedPorts: []config.PublishedPort{
 							{
-								Var: "FOO",
+								ContainerPort: "10001",
+								Protocol:      "tcp",
+								HostIP:        "127.0.0.1",
+								HostPort:      "-1",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `neither value nor valueCommand specified for env var FOO in container {Group: g1 Container:c1} config`,
+		want: `published host port -1 cannot be non-positive in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Env Var With Both Value And ValueCommand",
+		name: "Empty Container Config Sysctl Key",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5131,24 +7917,20 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Config: config.ContainerConfigOptions{
-						Env: []config.ConfigEnv{
+					Security: config.ContainerSecurity{
+						Sysctls: []config.Sysctl{
 							{
-								Var:   "FOO",
-								Value: "my-foo-bar",
-								ValueCommand: []string{
-									"/foo/bar/baz",
-								},
+								Value: "foo-bar",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `exactly one of value or valueCommand must be specified for env var FOO in container {Group: g1 Container:c1} config`,
+		want: `empty sysctl key in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Empty Container Config Image",
+		name: "Duplicate Container Config Sysctl Key",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5165,16 +7947,35 @@ var buildDeploymentFromConfigErrorTests = []struct {
 						Group:     "g1",
 						Container: "c1",
 					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
+					Security: config.ContainerSecurity{
+						Sysctls: []config.Sysctl{
+							{
+								Key:   "FOO",
+								Value: "foo-bar",
+							},
+							{
+								Key:   "FOO2",
+								Value: "foo-bar-2",
+							},
+							{
+								Key:   "FOO",
+								Value: "foo-bar-3",
+							},
+						},
+					},
 				},
 			},
 		},
-		want: `image cannot be empty in container {Group: g1 Container:c1} config`,
+		want: `sysctl key FOO specified more than once in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config SkipImagePull And IgnoreImagePullFailures Both Set To True",
+		name: "Container Config Empty Sysctl Value",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5192,20 +7993,25 @@ var buildDeploymentFromConfigErrorTests = []struct {
 						Container: "c1",
 					},
 					Image: config.ContainerImage{
-						Image:                   "foo/bar:123",
-						SkipImagePull:           true,
-						IgnoreImagePullFailures: true,
+						Image: "foo/bar:123",
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
+					Security: config.ContainerSecurity{
+						Sysctls: []config.Sysctl{
+							{
+								Key: "FOO",
+							},
+						},
+					},
 				},
 			},
 		},
-		want: `ignoreImagePullFailures cannot be true when skipImagePull is true in container {Group: g1 Container:c1} config`,
+		want: `empty sysctl value for sysctl FOO in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config SkipImagePull And PullImageBeforeStop Both Set To True",
+		name: "Container Health Config - Negative Retries",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5223,20 +8029,21 @@ var buildDeploymentFromConfigErrorTests = []struct {
 						Container: "c1",
 					},
 					Image: config.ContainerImage{
-						Image:               "foo/bar:123",
-						SkipImagePull:       true,
-						PullImageBeforeStop: true,
+						Image: "foo/bar:123",
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
+					Health: config.ContainerHealth{
+						Retries: -1,
+					},
 				},
 			},
 		},
-		want: `pullImageBeforeStop cannot be true when skipImagePull is true in container {Group: g1 Container:c1} config`,
+		want: `health check retries -1 cannot be negative in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Empty Container Config Label Name",
+		name: "Container Health Config - Invalid Interval",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5256,23 +8063,19 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Image: config.ContainerImage{
 						Image: "foo/bar:123",
 					},
-					Metadata: config.ContainerMetadata{
-						Labels: []config.Label{
-							{
-								Value: "foo-bar",
-							},
-						},
-					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
+					Health: config.ContainerHealth{
+						Interval: "garbage",
+					},
 				},
 			},
 		},
-		want: `empty label name in container {Group: g1 Container:c1} config`,
+		want: `health check interval garbage is invalid in container {Group: g1 Container:c1} config, reason: time: invalid duration "garbage"`,
 	},
 	{
-		name: "Duplicate Container Config Label Name",
+		name: "Container Health Config - Invalid Timeout",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5292,32 +8095,19 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Image: config.ContainerImage{
 						Image: "foo/bar:123",
 					},
-					Metadata: config.ContainerMetadata{
-						Labels: []config.Label{
-							{
-								Name:  "FOO",
-								Value: "foo-bar",
-							},
-							{
-								Name:  "FOO2",
-								Value: "foo-bar-2",
-							},
-							{
-								Name:  "FOO",
-								Value: "foo-bar-3",
-							},
-						},
-					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
+					Health: config.ContainerHealth{
+						Timeout: "garbage",
+					},
 				},
 			},
 		},
-		want: `label name FOO specified more than once in container {Group: g1 Container:c1} config`,
+		want: `health check timeout garbage is invalid in container {Group: g1 Container:c1} config, reason: time: invalid duration "garbage"`,
 	},
 	{
-		name: "Container Config Empty Label Value",
+		name: "Container Health Config - Invalid StartPeriod",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5337,23 +8127,19 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Image: config.ContainerImage{
 						Image: "foo/bar:123",
 					},
-					Metadata: config.ContainerMetadata{
-						Labels: []config.Label{
-							{
-								Name: "FOO",
-							},
-						},
-					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
+					Health: config.ContainerHealth{
+						StartPeriod: "garbage",
+					},
 				},
 			},
 		},
-		want: `empty label value for label FOO in container {Group: g1 Container:c1} config`,
+		want: `health check start period garbage is invalid in container {Group: g1 Container:c1} config, reason: time: invalid duration "garbage"`,
 	},
 	{
-		name: "Container Config Empty Order",
+		name: "Container Health Config - Invalid StartInterval",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5373,13 +8159,19 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Image: config.ContainerImage{
 						Image: "foo/bar:123",
 					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Health: config.ContainerHealth{
+						StartInterval: "garbage",
+					},
 				},
 			},
 		},
-		want: `container order 0 cannot be non-positive in container {Group: g1 Container:c1} config`,
+		want: `health check start interval garbage is invalid in container {Group: g1 Container:c1} config, reason: time: invalid duration "garbage"`,
 	},
 	{
-		name: "Container Config Negative Order",
+		name: "Container ShmSize Invalid Unit",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5400,15 +8192,18 @@ var buildDeploymentFromConfigErrorTests = []struct {
 						Image: "foo/bar:123",
 					},
 					Lifecycle: config.ContainerLifecycle{
-						Order: -1,
+						Order: 1,
+					},
+					Runtime: config.ContainerRuntime{
+						ShmSize: "1foobar",
 					},
 				},
 			},
 		},
-		want: `container order -1 cannot be non-positive in container {Group: g1 Container:c1} config`,
+		want: `invalid shmSize 1foobar in container {Group: g1 Container:c1} config, reason: invalid suffix: 'foobar'`,
 	},
 	{
-		name: "Container Config Restart Policy MaxRetryCount Set With Non-On-Failure Mode",
+		name: "Container ShmSize Invalid Value",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5430,18 +8225,17 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
-						RestartPolicy: config.ContainerRestartPolicy{
-							Mode:          "always",
-							MaxRetryCount: 5,
-						},
+					},
+					Runtime: config.ContainerRuntime{
+						ShmSize: "garbage",
 					},
 				},
 			},
 		},
-		want: `restart policy max retry count can be set only when the mode is on-failure in container {Group: g1 Container:c1} config`,
+		want: `invalid shmSize garbage in container {Group: g1 Container:c1} config, reason: invalid size: 'garbage'`,
 	},
 	{
-		name: "Container Config Invalid Restart Policy Mode",
+		name: "Container Resources Invalid Memory",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5463,17 +8257,17 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
-						RestartPolicy: config.ContainerRestartPolicy{
-							Mode: "garbage",
-						},
+					},
+					Resources: config.ContainerResources{
+						Memory: "garbage",
 					},
 				},
 			},
 		},
-		want: `invalid restart policy mode garbage in container {Group: g1 Container:c1} config, valid values are \[ 'no', 'always', 'on-failure', 'unless-stopped' \]`,
+		want: `invalid memory garbage in container {Group: g1 Container:c1} config, reason: invalid size: 'garbage'`,
 	},
 	{
-		name: "Container Config Negative Restart Policy MaxRetryCount",
+		name: "Container Resources Zero Memory",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5495,18 +8289,17 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
-						RestartPolicy: config.ContainerRestartPolicy{
-							Mode:          "on-failure",
-							MaxRetryCount: -1,
-						},
+					},
+					Resources: config.ContainerResources{
+						Memory: "0m",
 					},
 				},
 			},
 		},
-		want: `restart policy max retry count -1 cannot be negative in container {Group: g1 Container:c1} config`,
+		want: `memory 0m must be positive in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Negative StopTimeout",
+		name: "Container Resources Invalid Memory Reservation",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5527,16 +8320,18 @@ var buildDeploymentFromConfigErrorTests = []struct {
 						Image: "foo/bar:123",
 					},
 					Lifecycle: config.ContainerLifecycle{
-						Order:       1,
-						StopTimeout: -1,
+						Order: 1,
+					},
+					Resources: config.ContainerResources{
+						MemoryReservation: "1foobar",
 					},
 				},
 			},
 		},
-		want: `container stop timeout -1 cannot be negative in container {Group: g1 Container:c1} config`,
+		want: `invalid memory reservation 1foobar in container {Group: g1 Container:c1} config, reason: invalid suffix: 'foobar'`,
 	},
 	{
-		name: "Container Config PrimaryUserGroup Without User",
+		name: "Container Resources Invalid Memory Swap",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5559,19 +8354,25 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					User: config.ContainerUser{
-						PrimaryGroup: "my-user-group",
+					Resources: config.ContainerResources{
+						Memory:     "1g",
+						MemorySwap: "-2",
 					},
 				},
 			},
 		},
-		want: `container user primary group cannot be set without setting the user in container {Group: g1 Container:c1} config`,
+		want: `invalid memory swap -2 in container {Group: g1 Container:c1} config, reason: invalid size: '-2'`,
 	},
 	{
-		name: "Container Config Device Missing Src And Dst",
+		name: "Container Resources Memory Reservation Greater Than Memory",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
+				Container: config.GlobalContainer{
+					Resources: config.ContainerResources{
+						Memory: "1g",
+					},
+				},
 			},
 			Groups: []config.ContainerGroup{
 				{
@@ -5591,20 +8392,16 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Devices: config.ContainerDevice{
-							Static: []config.Device{
-								{},
-							},
-						},
+					Resources: config.ContainerResources{
+						MemoryReservation: "2g",
 					},
 				},
 			},
 		},
-		want: `device src cannot be empty in container {Group: g1 Container:c1} config`,
+		want: `memory reservation 2g cannot be greater than memory 1g in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Device Missing Src",
+		name: "Container Resources Memory Swap Without Memory",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5627,22 +8424,16 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Devices: config.ContainerDevice{
-							Static: []config.Device{
-								{
-									Dst: "/dev/my-target-dev",
-								},
-							},
-						},
+					Resources: config.ContainerResources{
+						MemorySwap: "-1",
 					},
 				},
 			},
 		},
-		want: `device src cannot be empty in container {Group: g1 Container:c1} config`,
+		want: `memory swap cannot be set without setting memory in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Dynamic Device Invalid Command",
+		name: "Container Resources Memory Swap Less Than Memory",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5665,23 +8456,17 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Devices: config.ContainerDevice{
-							DynamicCommand: []string{
-								"garbage-command",
-								"garbage-arg1",
-								"garbage-arg2",
-								"garbage-arg3",
-							},
-						},
+					Resources: config.ContainerResources{
+						Memory:     "1g",
+						MemorySwap: "512m",
 					},
 				},
 			},
 		},
-		want: `invalid fake executor command garbage-command \["garbage-arg1" "garbage-arg2" "garbage-arg3"\]`,
+		want: `memory swap 512m cannot be less than memory 1g in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Empty Mount Name",
+		name: "Container Resources Negative CPUs",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5704,23 +8489,16 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
-							{
-								Type:     "bind",
-								Src:      "/foo",
-								Dst:      "/bar",
-								ReadOnly: true,
-							},
-						},
+					Resources: config.ContainerResources{
+						CPUs: -0.5,
 					},
 				},
 			},
 		},
-		want: `mount name cannot be empty in container {Group: g1 Container:c1} config mounts`,
+		want: `cpus -0.5 cannot be negative in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Duplicate Mounts Within Container Config",
+		name: "Container Resources Negative CPU Shares",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5743,54 +8521,19 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
-							{
-								Name: "mount-foo1",
-								Type: "bind",
-								Src:  "/foo1",
-								Dst:  "/bar1",
-							},
-							{
-								Name: "mount-foo2",
-								Type: "bind",
-								Src:  "/foo2",
-								Dst:  "/bar2",
-							},
-							{
-								Name: "mount-foo1",
-								Type: "bind",
-								Src:  "/foo3",
-								Dst:  "/bar3",
-							},
-						},
+					Resources: config.ContainerResources{
+						CPUShares: -1,
 					},
 				},
 			},
 		},
-		want: `mount name mount-foo1 defined more than once in container {Group: g1 Container:c1} config mounts`,
+		want: `cpu shares -1 cannot be negative in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Duplicate Mounts Within Container And Global Configs Combined",
+		name: "Container Resources Invalid CPU Set",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-				Container: config.GlobalContainer{
-					Mounts: []config.Mount{
-						{
-							Name: "mount-foo2",
-							Type: "bind",
-							Src:  "/foo2-global",
-							Dst:  "/bar2-global",
-						},
-						{
-							Name: "mount-foo3",
-							Type: "bind",
-							Src:  "/foo3",
-							Dst:  "/bar3",
-						},
-					},
-				},
 			},
 			Groups: []config.ContainerGroup{
 				{
@@ -5810,29 +8553,16 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
-							{
-								Name: "mount-foo1",
-								Type: "bind",
-								Src:  "/foo1",
-								Dst:  "/bar1",
-							},
-							{
-								Name: "mount-foo2",
-								Type: "bind",
-								Src:  "/foo2",
-								Dst:  "/bar2",
-							},
-						},
+					Resources: config.ContainerResources{
+						CPUSet: "3-1",
 					},
 				},
 			},
 		},
-		want: `mount name mount-foo2 defined more than once in container {Group: g1 Container:c1} config mounts`,
+		want: `invalid cpuset 3-1 in container {Group: g1 Container:c1} config, must be a comma separated list of cpus or cpu ranges`,
 	},
 	{
-		name: "Container Config Mount With Invalid Mount Type",
+		name: "Container Resources Negative Pids Limit",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5855,23 +8585,16 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
-							{
-								Name: "foo",
-								Type: "garbage",
-								Src:  "/foo",
-								Dst:  "/bar",
-							},
-						},
+					Resources: config.ContainerResources{
+						PidsLimit: -5,
 					},
 				},
 			},
 		},
-		want: `unsupported mount type garbage for mount foo in container {Group: g1 Container:c1} config mounts`,
+		want: `pids limit -5 cannot be less than -1 in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Mount With Empty Src",
+		name: "Container Resources Out Of Range Blkio Weight",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5894,22 +8617,16 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
-							{
-								Name: "foo",
-								Type: "bind",
-								Dst:  "/bar",
-							},
-						},
+					Resources: config.ContainerResources{
+						BlkioWeight: 5,
 					},
 				},
 			},
 		},
-		want: `bind mount name foo cannot have an empty value for src in container {Group: g1 Container:c1} config mounts`,
+		want: `blkio weight 5 must be between 10 and 1000 in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Tmpfs Mount With Non-Empty Src",
+		name: "Container Logging Invalid Driver",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5932,23 +8649,16 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
-							{
-								Name: "foo",
-								Type: "tmpfs",
-								Src:  "/foo",
-								Dst:  "/bar",
-							},
-						},
+					Logging: config.ContainerLogging{
+						Driver: "garbage",
 					},
 				},
 			},
 		},
-		want: `tmpfs mount name foo cannot have a non-empty value for src in container {Group: g1 Container:c1} config mounts`,
+		want: `invalid logging driver garbage in container {Group: g1 Container:c1} config, valid values are \[ 'none', 'local', 'json-file', 'syslog', 'journald', 'gelf', 'fluentd', 'awslogs', 'splunk', 'etwlogs', 'gcplogs', 'logentries' \]`,
 	},
 	{
-		name: "Container Config Mount With Empty Dst",
+		name: "Container Logging Options Without Driver",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -5971,22 +8681,21 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
+					Logging: config.ContainerLogging{
+						Options: []config.LoggingOption{
 							{
-								Name: "foo",
-								Type: "bind",
-								Src:  "/foo",
+								Name:  "max-size",
+								Value: "10m",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `mount name foo cannot have an empty value for dst in container {Group: g1 Container:c1} config mounts`,
+		want: `logging options cannot be set without setting the logging driver in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Bind Mount With Tmpfs Size",
+		name: "Container Logging Options With None Driver",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6009,24 +8718,22 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
+					Logging: config.ContainerLogging{
+						Driver: "none",
+						Options: []config.LoggingOption{
 							{
-								Name:      "foo",
-								Type:      "bind",
-								Src:       "/foo",
-								Dst:       "/bar",
-								TmpfsSize: 10000,
+								Name:  "max-size",
+								Value: "10m",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `bind mount name foo cannot specify tmpfs size in container {Group: g1 Container:c1} config mounts`,
+		want: `logging options cannot be set when the logging driver is none in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Tmpfs Mount With Negative Tmpfs Size",
+		name: "Container Logging Empty Option Name",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6049,34 +8756,24 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
+					Logging: config.ContainerLogging{
+						Driver: "json-file",
+						Options: []config.LoggingOption{
 							{
-								Name:      "foo",
-								Type:      "tmpfs",
-								Dst:       "/bar",
-								TmpfsSize: -10000,
+								Value: "10m",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `tmpfs mount name foo cannot specify a negative tmpfs size -10000 in container {Group: g1 Container:c1} config mounts`,
+		want: `empty logging option name in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Mount Def Reference Not Found",
+		name: "Container Logging Duplicate Option",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-				MountDefs: []config.Mount{
-					{
-						Name: "foo",
-						Type: "bind",
-						Src:  "/foo",
-						Dst:  "/bar",
-					},
-				},
 			},
 			Groups: []config.ContainerGroup{
 				{
@@ -6096,48 +8793,67 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
+					Logging: config.ContainerLogging{
+						Driver: "json-file",
+						Options: []config.LoggingOption{
 							{
-								Name: "foo2",
+								Name:  "max-size",
+								Value: "10m",
+							},
+							{
+								Name:  "max-size",
+								Value: "20m",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `mount specified by just the name foo2 not found in defs in container {Group: g1 Container:c1} config mounts`,
+		want: `logging option max-size specified more than once in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Duplicate Mount Name Only With Global Container Config Mount",
+		name: "Container Logging Empty Option Value",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-				MountDefs: []config.Mount{
-					{
-						Name: "foo",
-						Type: "bind",
-						Src:  "/foo",
-						Dst:  "/bar",
-					},
+			},
+			Groups: []config.ContainerGroup{
+				{
+					Name:  "g1",
+					Order: 1,
 				},
-				Container: config.GlobalContainer{
-					Mounts: []config.Mount{
-						{
-							Name: "foo1",
-							Type: "bind",
-							Src:  "/foo1",
-							Dst:  "/bar1",
-						},
-						{
-							Name: "foo2",
-							Type: "bind",
-							Src:  "/foo2",
-							Dst:  "/bar2",
+			},
+			Containers: []config.Container{
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+					Logging: config.ContainerLogging{
+						Driver: "json-file",
+						Options: []config.LoggingOption{
+							{
+								Name: "max-size",
+							},
 						},
 					},
 				},
 			},
+		},
+		want: `empty logging option value for logging option max-size in container {Group: g1 Container:c1} config`,
+	},
+	{
+		name: "Container Runtime Empty Ulimit Name",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
 			Groups: []config.ContainerGroup{
 				{
 					Name:  "g1",
@@ -6156,47 +8872,23 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
+					Runtime: config.ContainerRuntime{
+						Ulimits: []config.Ulimit{
 							{
-								Name: "foo2",
+								Soft: 1024,
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `mount name foo2 defined more than once in container {Group: g1 Container:c1} config mounts`,
+		want: `empty ulimit name in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Duplicate Mount Name And Def With Global Container Config Mount",
+		name: "Container Runtime Duplicate Ulimit",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-				MountDefs: []config.Mount{
-					{
-						Name: "foo",
-						Type: "bind",
-						Src:  "/foo",
-						Dst:  "/bar",
-					},
-				},
-				Container: config.GlobalContainer{
-					Mounts: []config.Mount{
-						{
-							Name: "foo1",
-							Type: "bind",
-							Src:  "/foo1",
-							Dst:  "/bar1",
-						},
-						{
-							Name: "foo2",
-							Type: "bind",
-							Src:  "/foo2",
-							Dst:  "/bar2",
-						},
-					},
-				},
 			},
 			Groups: []config.ContainerGroup{
 				{
@@ -6216,23 +8908,25 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Filesystem: config.ContainerFilesystem{
-						Mounts: []config.Mount{
+					Runtime: config.ContainerRuntime{
+						Ulimits: []config.Ulimit{
 							{
-								Name: "foo2",
-								Type: "bind",
-								Src:  "/foo-something-else",
-								Dst:  "/bar-something-else",
+								Name: "nofile",
+								Soft: 1024,
+							},
+							{
+								Name: "nofile",
+								Soft: 2048,
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `mount name foo2 defined more than once in container {Group: g1 Container:c1} config mounts`,
+		want: `ulimit nofile specified more than once in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Published Port - Container Port Empty",
+		name: "Container Runtime Invalid Ulimit Name",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6255,22 +8949,21 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Network: config.ContainerNetwork{
-						PublishedPorts: []config.PublishedPort{
+					Runtime: config.ContainerRuntime{
+						Ulimits: []config.Ulimit{
 							{
-								Protocol: "tcp",
-								HostIP:   "127.0.0.1",
-								HostPort: "5001",
+								Name: "foobar",
+								Soft: 1024,
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `unable to convert published container port  to an integer, reason: strconv.ParseInt: parsing "": invalid syntax`,
+		want: `invalid ulimit foobar in container {Group: g1 Container:c1} config, reason: invalid ulimit type: foobar`,
 	},
 	{
-		name: "Container Config Published Port - Container Port Non Integer",
+		name: "Container Runtime Ulimit Soft Greater Than Hard",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6293,23 +8986,22 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Network: config.ContainerNetwork{
-						PublishedPorts: []config.PublishedPort{
+					Runtime: config.ContainerRuntime{
+						Ulimits: []config.Ulimit{
 							{
-								ContainerPort: "-6365foobar9563",
-								Protocol:      "tcp",
-								HostIP:        "127.0.0.1",
-								HostPort:      "5001",
+								Name: "nofile",
+								Soft: 2048,
+								Hard: 1024,
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `unable to convert published container port -6365foobar9563 to an integer, reason: strconv.ParseInt: parsing "-6365foobar9563": invalid syntax`,
+		want: `invalid ulimit nofile in container {Group: g1 Container:c1} config, reason: ulimit soft limit must be less than or equal to hard limit: 2048 > 1024`,
 	},
 	{
-		name: "Container Config Published Port - Container Port Negative",
+		name: "Container Runtime Out Of Range OOM Score Adj",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6332,23 +9024,16 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Network: config.ContainerNetwork{
-						PublishedPorts: []config.PublishedPort{
-							{
-								ContainerPort: "-1",
-								Protocol:      "tcp",
-								HostIP:        "127.0.0.1",
-								HostPort:      "5001",
-							},
-						},
+					Runtime: config.ContainerRuntime{
+						OOMScoreAdj: 1001,
 					},
 				},
 			},
 		},
-		want: `published container port -1 cannot be non-positive in container {Group: g1 Container:c1} config`,
+		want: `oom score adj 1001 must be between -1000 and 1000 in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Published Port - Protocol Empty",
+		name: "Container Security Invalid Pid Mode",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6371,22 +9056,16 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Network: config.ContainerNetwork{
-						PublishedPorts: []config.PublishedPort{
-							{
-								ContainerPort: "10001",
-								HostIP:        "127.0.0.1",
-								HostPort:      "5001",
-							},
-						},
+					Security: config.ContainerSecurity{
+						PidMode: "foobar",
 					},
 				},
 			},
 		},
-		want: `published container port 10001 specifies an invalid protocol  in container {Group: g1 Container:c1} config`,
+		want: `invalid pid mode foobar in container {Group: g1 Container:c1} config, valid values are \[ 'host', 'container:<name>' \]`,
 	},
 	{
-		name: "Container Config Published Port - Protocol Invalid",
+		name: "Container Security Invalid Ipc Mode",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6409,23 +9088,16 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Network: config.ContainerNetwork{
-						PublishedPorts: []config.PublishedPort{
-							{
-								ContainerPort: "10001",
-								Protocol:      "garbage",
-								HostIP:        "127.0.0.1",
-								HostPort:      "5001",
-							},
-						},
+					Security: config.ContainerSecurity{
+						IpcMode: "container:",
 					},
 				},
 			},
 		},
-		want: `published container port 10001 specifies an invalid protocol garbage in container {Group: g1 Container:c1} config`,
+		want: `invalid ipc mode container: in container {Group: g1 Container:c1} config, valid values are \[ 'none', 'private', 'shareable', 'host', 'container:<name>' \]`,
 	},
 	{
-		name: "Container Config Published Port - Host IP Empty",
+		name: "Empty Container Env Var",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6448,22 +9120,20 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Network: config.ContainerNetwork{
-						PublishedPorts: []config.PublishedPort{
+					Runtime: config.ContainerRuntime{
+						Env: []config.ContainerEnv{
 							{
-								ContainerPort: "10001",
-								Protocol:      "tcp",
-								HostPort:      "5001",
+								Value: "foo-bar",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `published host IP cannot be empty for container port 10001 in container {Group: g1 Container:c1} config`,
+		want: `empty env var in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Published Port - Host IP Invalid",
+		name: "Duplicate Container Env Var",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6486,23 +9156,29 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Network: config.ContainerNetwork{
-						PublishedPorts: []config.PublishedPort{
+					Runtime: config.ContainerRuntime{
+						Env: []config.ContainerEnv{
 							{
-								ContainerPort: "10001",
-								Protocol:      "tcp",
-								HostIP:        "abc.def.ghi.jkl",
-								HostPort:      "5001",
+								Var:   "FOO",
+								Value: "foo-bar",
+							},
+							{
+								Var:   "FOO2",
+								Value: "foo-bar-2",
+							},
+							{
+								Var:   "FOO",
+								Value: "foo-bar-3",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `published host IP abc\.def\.ghi\.jkl for container port 10001 is invalid in container {Group: g1 Container:c1} config, reason: ParseAddr\("abc\.def\.ghi\.jkl"\): unexpected character \(at "abc\.def\.ghi\.jkl"\)`,
+		want: `env var FOO specified more than once in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Published Port - Host Port Empty",
+		name: "Container Env Var Without Value",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6525,22 +9201,20 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Network: config.ContainerNetwork{
-						PublishedPorts: []config.PublishedPort{
+					Runtime: config.ContainerRuntime{
+						Env: []config.ContainerEnv{
 							{
-								ContainerPort: "10001",
-								Protocol:      "tcp",
-								HostIP:        "127.0.0.1",
+								Var: "FOO",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `unable to convert published host port  to an integer, reason: strconv.ParseInt: parsing "": invalid syntax`,
+		want: `value not specified for env var FOO in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Published Port - Host Port Non Integer",
+		name: "Container Config DependsOn Empty Group",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6550,6 +9224,10 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Name:  "g1",
 					Order: 1,
 				},
+				{
+					Name:  "g2",
+					Order: 2,
+				},
 			},
 			Containers: []config.Container{
 				{
@@ -6562,24 +9240,20 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
-					},
-					Network: config.ContainerNetwork{
-						PublishedPorts: []config.PublishedPort{
+						DependsOn: []config.ContainerReference{
 							{
-								ContainerPort: "10001",
-								Protocol:      "tcp",
-								HostIP:        "127.0.0.1",
-								HostPort:      "9876garbage0123",
+								Group:     "",
+								Container: "c2",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `unable to convert published host port 9876garbage0123 to an integer, reason: strconv.ParseInt: parsing "9876garbage0123": invalid syntax`,
+		want: `dependency has invalid container reference in container {Group: g1 Container:c1} config, reason: container reference cannot have an empty group name`,
 	},
 	{
-		name: "Container Config Published Port - Host Port Negative",
+		name: "Container Config DependsOn Itself",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6589,6 +9263,10 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Name:  "g1",
 					Order: 1,
 				},
+				{
+					Name:  "g2",
+					Order: 2,
+				},
 			},
 			Containers: []config.Container{
 				{
@@ -6601,24 +9279,20 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
-					},
-					Network: config.ContainerNetwork{
-						PublishedPorts: []config.PublishedPort{
+						DependsOn: []config.ContainerReference{
 							{
-								ContainerPort: "10001",
-								Protocol:      "tcp",
-								HostIP:        "127.0.0.1",
-								HostPort:      "-1",
+								Group:     "g1",
+								Container: "c1",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `published host port -1 cannot be non-positive in container {Group: g1 Container:c1} config`,
+		want: `container cannot depend on itself in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Empty Container Config Sysctl Key",
+		name: "Container Config DependsOn Duplicate",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6628,6 +9302,10 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Name:  "g1",
 					Order: 1,
 				},
+				{
+					Name:  "g2",
+					Order: 2,
+				},
 			},
 			Containers: []config.Container{
 				{
@@ -6640,21 +9318,36 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
-					},
-					Security: config.ContainerSecurity{
-						Sysctls: []config.Sysctl{
+						DependsOn: []config.ContainerReference{
 							{
-								Value: "foo-bar",
+								Group:     "g1",
+								Container: "c2",
+							},
+							{
+								Group:     "g1",
+								Container: "c2",
 							},
 						},
 					},
 				},
+				{
+					Info: config.ContainerReference{
+						Group:     "g1",
+						Container: "c2",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+					},
+				},
 			},
 		},
-		want: `empty sysctl key in container {Group: g1 Container:c1} config`,
+		want: `dependency {Group:g1 Container:c2} specified more than once in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Duplicate Container Config Sysctl Key",
+		name: "Container Config DependsOn Unknown Container",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6664,6 +9357,10 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Name:  "g1",
 					Order: 1,
 				},
+				{
+					Name:  "g2",
+					Order: 2,
+				},
 			},
 			Containers: []config.Container{
 				{
@@ -6676,30 +9373,20 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
-					},
-					Security: config.ContainerSecurity{
-						Sysctls: []config.Sysctl{
-							{
-								Key:   "FOO",
-								Value: "foo-bar",
-							},
+						DependsOn: []config.ContainerReference{
 							{
-								Key:   "FOO2",
-								Value: "foo-bar-2",
-							},
-							{
-								Key:   "FOO",
-								Value: "foo-bar-3",
+								Group:     "g2",
+								Container: "c2",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `sysctl key FOO specified more than once in container {Group: g1 Container:c1} config`,
+		want: `dependency {Group:g2 Container:c2} not found in container {Group: g1 Container:c1} config`,
 	},
 	{
-		name: "Container Config Empty Sysctl Value",
+		name: "Container Config DependsOn Cycle",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6709,6 +9396,10 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Name:  "g1",
 					Order: 1,
 				},
+				{
+					Name:  "g2",
+					Order: 2,
+				},
 			},
 			Containers: []config.Container{
 				{
@@ -6721,121 +9412,147 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
-					},
-					Security: config.ContainerSecurity{
-						Sysctls: []config.Sysctl{
+						DependsOn: []config.ContainerReference{
+							{
+								Group:     "g2",
+								Container: "c1",
+							},
+						},
+					},
+				},
+				{
+					Info: config.ContainerReference{
+						Group:     "g2",
+						Container: "c1",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+						DependsOn: []config.ContainerReference{
+							{
+								Group:     "g2",
+								Container: "c2",
+							},
+						},
+					},
+				},
+				{
+					Info: config.ContainerReference{
+						Group:     "g2",
+						Container: "c2",
+					},
+					Image: config.ContainerImage{
+						Image: "foo/bar:123",
+					},
+					Lifecycle: config.ContainerLifecycle{
+						Order: 1,
+						DependsOn: []config.ContainerReference{
 							{
-								Key: "FOO",
+								Group:     "g1",
+								Container: "c1",
 							},
 						},
 					},
 				},
 			},
 		},
-		want: `empty sysctl value for sysctl FOO in container {Group: g1 Container:c1} config`,
+		want: `container dependency cycle detected: g1-c1 -> g2-c1 -> g2-c2 -> g1-c1`,
 	},
 	{
-		name: "Container Health Config - Negative Retries",
+		name: "Empty Secret Name",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
 			},
-			Groups: []config.ContainerGroup{
+			Secrets: []config.Secret{
 				{
-					Name:  "g1",
-					Order: 1,
+					File: "testdata/secrets/db-password.txt",
 				},
 			},
-			Containers: []config.Container{
+		},
+		want: `secret name cannot be empty in the secrets config`,
+	},
+	{
+		name: "Secret Defined More Than Once",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Secrets: []config.Secret{
 				{
-					Info: config.ContainerReference{
-						Group:     "g1",
-						Container: "c1",
-					},
-					Image: config.ContainerImage{
-						Image: "foo/bar:123",
-					},
-					Lifecycle: config.ContainerLifecycle{
-						Order: 1,
-					},
-					Health: config.ContainerHealth{
-						Retries: -1,
-					},
+					Name: "db-password",
+					File: "testdata/secrets/db-password.txt",
+				},
+				{
+					Name: "db-password",
+					File: "testdata/secrets/db-password.txt",
 				},
 			},
 		},
-		want: `health check retries -1 cannot be negative in container {Group: g1 Container:c1} config`,
+		want: `secret db-password defined more than once in the secrets config`,
 	},
 	{
-		name: "Container Health Config - Invalid Interval",
+		name: "Secret Without A Source",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
 			},
-			Groups: []config.ContainerGroup{
+			Secrets: []config.Secret{
 				{
-					Name:  "g1",
-					Order: 1,
+					Name: "db-password",
 				},
 			},
-			Containers: []config.Container{
+		},
+		want: `exactly one of file, command or env must be specified for secret db-password`,
+	},
+	{
+		name: "Secret With Multiple Sources",
+		config: config.Homelab{
+			Global: config.Global{
+				BaseDir: testhelpers.HomelabBaseDir(),
+			},
+			Secrets: []config.Secret{
 				{
-					Info: config.ContainerReference{
-						Group:     "g1",
-						Container: "c1",
-					},
-					Image: config.ContainerImage{
-						Image: "foo/bar:123",
-					},
-					Lifecycle: config.ContainerLifecycle{
-						Order: 1,
-					},
-					Health: config.ContainerHealth{
-						Interval: "garbage",
-					},
+					Name: "db-password",
+					File: "testdata/secrets/db-password.txt",
+					Env:  "HOMELAB_TEST_DB_PASSWORD",
 				},
 			},
 		},
-		want: `health check interval garbage is invalid in container {Group: g1 Container:c1} config, reason: time: invalid duration "garbage"`,
+		want: `exactly one of file, command or env must be specified for secret db-password`,
 	},
 	{
-		name: "Container Health Config - Invalid Timeout",
+		name: "Secret With Env Not In Allowlist",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
-			},
-			Groups: []config.ContainerGroup{
-				{
-					Name:  "g1",
-					Order: 1,
+				ProcessEnvAllowlist: []string{
+					"USER",
 				},
 			},
-			Containers: []config.Container{
+			Secrets: []config.Secret{
 				{
-					Info: config.ContainerReference{
-						Group:     "g1",
-						Container: "c1",
-					},
-					Image: config.ContainerImage{
-						Image: "foo/bar:123",
-					},
-					Lifecycle: config.ContainerLifecycle{
-						Order: 1,
-					},
-					Health: config.ContainerHealth{
-						Timeout: "garbage",
-					},
+					Name: "db-password",
+					Env:  "HOMELAB_TEST_DB_PASSWORD",
 				},
 			},
 		},
-		want: `health check timeout garbage is invalid in container {Group: g1 Container:c1} config, reason: time: invalid duration "garbage"`,
+		want: `env HOMELAB_TEST_DB_PASSWORD for secret db-password is not present in the global config processEnvAllowlist`,
 	},
 	{
-		name: "Container Health Config - Invalid StartPeriod",
+		name: "Container Config Empty Secret Name",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
 			},
+			Secrets: []config.Secret{
+				{
+					Name: "db-password",
+					File: "testdata/secrets/db-password.txt",
+				},
+			},
 			Groups: []config.ContainerGroup{
 				{
 					Name:  "g1",
@@ -6854,20 +9571,28 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Health: config.ContainerHealth{
-						StartPeriod: "garbage",
+					Secrets: []config.ContainerSecret{
+						{
+							Target: "/run/secrets/foo",
+						},
 					},
 				},
 			},
 		},
-		want: `health check start period garbage is invalid in container {Group: g1 Container:c1} config, reason: time: invalid duration "garbage"`,
+		want: `secret name cannot be empty in container \{Group: g1 Container:c1\} config`,
 	},
 	{
-		name: "Container Health Config - Invalid StartInterval",
+		name: "Container Config Undefined Secret",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
 			},
+			Secrets: []config.Secret{
+				{
+					Name: "db-password",
+					File: "testdata/secrets/db-password.txt",
+				},
+			},
 			Groups: []config.ContainerGroup{
 				{
 					Name:  "g1",
@@ -6886,20 +9611,28 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Health: config.ContainerHealth{
-						StartInterval: "garbage",
+					Secrets: []config.ContainerSecret{
+						{
+							Name: "api-token",
+						},
 					},
 				},
 			},
 		},
-		want: `health check start interval garbage is invalid in container {Group: g1 Container:c1} config, reason: time: invalid duration "garbage"`,
+		want: `secret api-token in container \{Group: g1 Container:c1\} config is not defined in the secrets config`,
 	},
 	{
-		name: "Container ShmSize Invalid Unit",
+		name: "Container Config Secret Specified More Than Once",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
 			},
+			Secrets: []config.Secret{
+				{
+					Name: "db-password",
+					File: "testdata/secrets/db-password.txt",
+				},
+			},
 			Groups: []config.ContainerGroup{
 				{
 					Name:  "g1",
@@ -6918,20 +9651,31 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Runtime: config.ContainerRuntime{
-						ShmSize: "1foobar",
+					Secrets: []config.ContainerSecret{
+						{
+							Name: "db-password",
+						},
+						{
+							Name: "db-password",
+						},
 					},
 				},
 			},
 		},
-		want: `invalid shmSize 1foobar in container {Group: g1 Container:c1} config, reason: invalid suffix: 'foobar'`,
+		want: `secret db-password specified more than once in container \{Group: g1 Container:c1\} config`,
 	},
 	{
-		name: "Container ShmSize Invalid Value",
+		name: "Container Config Secret Relative Target",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
 			},
+			Secrets: []config.Secret{
+				{
+					Name: "db-password",
+					File: "testdata/secrets/db-password.txt",
+				},
+			},
 			Groups: []config.ContainerGroup{
 				{
 					Name:  "g1",
@@ -6950,16 +9694,47 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Runtime: config.ContainerRuntime{
-						ShmSize: "garbage",
+					Secrets: []config.ContainerSecret{
+						{
+							Name:   "db-password",
+							Target: "run/secrets/db-password",
+						},
 					},
 				},
 			},
 		},
-		want: `invalid shmSize garbage in container {Group: g1 Container:c1} config, reason: invalid size: 'garbage'`,
+		want: `target run/secrets/db-password of secret db-password must be an absolute path in container \{Group: g1 Container:c1\} config`,
 	},
+}
+
+func TestBuildDeploymentFromConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range buildDeploymentFromConfigErrorTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, gotErr := FromConfig(testutils.NewVanillaTestContext(), &tc.config)
+			if gotErr == nil {
+				testhelpers.LogErrorNil(t, "Homelab.validate()", tc.name, tc.want)
+				return
+			}
+
+			if !testhelpers.RegexMatch(t, "Homelab.validate()", tc.name, "gotErr error string", tc.want, gotErr.Error()) {
+				return
+			}
+		})
+	}
+}
+
+var validateConfigTests = []struct {
+	name   string
+	config config.Homelab
+	want   []string
+}{
 	{
-		name: "Empty Container Env Var",
+		name: "Valid Config",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
@@ -6982,28 +9757,70 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Runtime: config.ContainerRuntime{
-						Env: []config.ContainerEnv{
-							{
-								Value: "foo-bar",
-							},
-						},
-					},
 				},
 			},
 		},
-		want: `empty env var in container {Group: g1 Container:c1} config`,
+		want: nil,
 	},
 	{
-		name: "Duplicate Container Env Var",
+		name: "Multiple Errors",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
+				Container: config.GlobalContainer{
+					StopTimeout:      -1,
+					PurgeGracePeriod: -2,
+				},
+			},
+			Hosts: []config.Host{
+				{
+					AllowedContainers: []config.ContainerReference{
+						{
+							Group:     "g1",
+							Container: "c1",
+						},
+					},
+				},
+			},
+			IPAM: config.IPAM{
+				Networks: config.Networks{
+					BridgeModeNetworks: []config.BridgeModeNetwork{
+						{
+							Name:              "net1",
+							HostInterfaceName: "docker-net1",
+							CIDR: config.NetworkCIDR{
+								V4: "172.18.100.0/24",
+							},
+						},
+						{
+							Name:              "net2",
+							HostInterfaceName: "docker-net2",
+							Priority:          1,
+							CIDR: config.NetworkCIDR{
+								V4: "172.18.101.0/24",
+							},
+							Containers: []config.ContainerIPInfo{
+								{
+									IP: config.ContainerIP{
+										IPv4: "172.18.102.11",
+									},
+									Container: config.ContainerReference{
+										Group:     "g2",
+										Container: "c2",
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 			Groups: []config.ContainerGroup{
 				{
-					Name:  "g1",
-					Order: 1,
+					Name: "g1",
+				},
+				{
+					Name:  "g2",
+					Order: 2,
 				},
 			},
 			Containers: []config.Container{
@@ -7012,38 +9829,63 @@ var buildDeploymentFromConfigErrorTests = []struct {
 						Group:     "g1",
 						Container: "c1",
 					},
+				},
+				{
+					Info: config.ContainerReference{
+						Group:     "g2",
+						Container: "c2",
+					},
 					Image: config.ContainerImage{
 						Image: "foo/bar:123",
 					},
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
-					},
-					Runtime: config.ContainerRuntime{
-						Env: []config.ContainerEnv{
-							{
-								Var:   "FOO",
-								Value: "foo-bar",
-							},
+						DependsOn: []config.ContainerReference{
 							{
-								Var:   "FOO2",
-								Value: "foo-bar-2",
+								Group:     "g1",
+								Container: "c1",
 							},
 							{
-								Var:   "FOO",
-								Value: "foo-bar-3",
+								Group:     "g2",
+								Container: "c9",
 							},
 						},
 					},
 				},
+				{
+					Info: config.ContainerReference{
+						Group:     "g3",
+						Container: "c3",
+					},
+				},
 			},
 		},
-		want: `env var FOO specified more than once in container {Group: g1 Container:c1} config`,
+		want: []string{
+			`global: container stop timeout -1 cannot be negative in global container config`,
+			`global: container purge grace period -2 cannot be negative in global container config`,
+			`host: host name cannot be empty in the hosts config`,
+			`network net1: network net1 cannot have a non-positive priority 0`,
+			`network net2: container {Group:g2 Container:c2} endpoint in network net2 cannot have a v4 IP 172.18.102.11 that does not belong to the network v4 CIDR 172.18.101.0/24`,
+			`group g1: group g1 cannot have a non-positive order 0`,
+			`container g1/c1: image cannot be empty in container {Group: g1 Container:c1} config`,
+			`container g1/c1: container order 0 cannot be non-positive in container {Group: g1 Container:c1} config`,
+			`container g3/c3: group definition missing in groups config for the container {Group:g3 Container:c3} in the containers config`,
+			`container g2/c2: dependency {Group:g2 Container:c9} not found in container {Group: g2 Container:c2} config`,
+		},
 	},
 	{
-		name: "Container Env Var Without Value",
+		name: "Invalid Config Env Along With Env Files",
 		config: config.Homelab{
 			Global: config.Global{
 				BaseDir: testhelpers.HomelabBaseDir(),
+				Env: []config.ConfigEnv{
+					{
+						Var: "FOO",
+					},
+				},
+				EnvFiles: []string{
+					"testdata/config-env-sources/common.env",
+				},
 			},
 			Groups: []config.ContainerGroup{
 				{
@@ -7063,37 +9905,30 @@ var buildDeploymentFromConfigErrorTests = []struct {
 					Lifecycle: config.ContainerLifecycle{
 						Order: 1,
 					},
-					Runtime: config.ContainerRuntime{
-						Env: []config.ContainerEnv{
-							{
-								Var: "FOO",
-							},
-						},
-					},
 				},
 			},
 		},
-		want: `value not specified for env var FOO in container {Group: g1 Container:c1} config`,
+		want: []string{
+			`global: none of value, valueCommand, valueFile or valueFromEnv specified for env var FOO in global config`,
+		},
 	},
 }
 
-func TestBuildDeploymentFromConfigErrors(t *testing.T) {
+func TestValidateConfig(t *testing.T) {
 	t.Parallel()
 
-	for _, test := range buildDeploymentFromConfigErrorTests {
+	for _, test := range validateConfigTests {
 		tc := test
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			_, gotErr := FromConfig(testutils.NewVanillaTestContext(), &tc.config)
-			if gotErr == nil {
-				testhelpers.LogErrorNil(t, "Homelab.validate()", tc.name, tc.want)
-				return
+			errs := ValidateConfig(testutils.NewVanillaTestContext(), &tc.config, "")
+			var got []string
+			for _, e := range errs {
+				got = append(got, fmt.Sprintf("%s: %s", e.Element, e))
 			}
 
-			if !testhelpers.RegexMatch(t, "Homelab.validate()", tc.name, "gotErr error string", tc.want, gotErr.Error()) {
-				return
-			}
+			testhelpers.CmpDiff(t, "ValidateConfig()", tc.name, "validation errors", tc.want, got)
 		})
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/netip"
+	"sync"
 
 	dnetwork "github.com/docker/docker/api/types/network"
 	"github.com/tuxgal/homelab/internal/config"
@@ -12,6 +13,9 @@ import (
 )
 
 type Network struct {
+	// mu serializes creating and deleting the network when the
+	// containers attached to it are acted upon concurrently.
+	mu                sync.Mutex
 	networkName       string
 	mode              NetworkMode
 	bridgeModeInfo    *bridgeModeNetworkInfo
@@ -66,6 +70,9 @@ func (n *Network) Create(ctx context.Context, dc *docker.Client) (bool, error) {
 		return false, fmt.Errorf("container mode network %s cannot be created", n.Name())
 	}
 
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
 	// TODO: Validate that the existing network and the new network have
 	// exactly the same properties if we choose to reuse the existing
 	// network, and display a warning when they differ.
@@ -87,6 +94,9 @@ func (n *Network) Delete(ctx context.Context, dc *docker.Client) (bool, error) {
 		return false, fmt.Errorf("container mode network %s cannot be deleted", n.Name())
 	}
 
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
 	if !dc.NetworkExists(ctx, n.Name()) {
 		return false, nil
 	}
@@ -0,0 +1,354 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tuxgal/homelab/internal/cmdexec"
+	"github.com/tuxgal/homelab/internal/config"
+	"github.com/tuxgal/homelab/internal/config/env"
+	"github.com/tuxgal/homelab/internal/dryrun"
+)
+
+const (
+	// Directory within the container base directory where the secrets
+	// of the container are written to.
+	containerSecretsDirName = "secrets"
+	// Directory within the container where the secrets are mounted at
+	// by default.
+	defaultSecretsTargetDir = "/run/secrets"
+	// The secrets directory is accessible only by the homelab user,
+	// while the secret files are readable by the container processes
+	// running as any user since they are bind mounted individually.
+	secretsDirPerms = 0o700
+	secretFilePerms = 0o444
+)
+
+// secret is a validated secret whose value is resolved only when it is
+// needed for the first time, i.e. while starting a container referring
+// to the secret. This avoids running the secret commands for the
+// commands which never start any containers.
+type secret struct {
+	config     config.Secret
+	configsDir string
+
+	once  sync.Once
+	value string
+	err   error
+}
+
+// secretMap maps the name of the secrets to the secrets.
+type secretMap map[string]*secret
+
+type containerSecretMount struct {
+	name     string
+	hostPath string
+	target   string
+}
+
+func validateSecretsConfig(v *errorCollector, parentEnv *env.ConfigEnvManager, configsDir string, conf []config.Secret, processEnvAllowlist []string) secretMap {
+	secrets := secretMap{}
+	for i, s := range conf {
+		ref := config.SecretElementRef(s.Name)
+		if err := s.ApplyConfigEnv(parentEnv); err != nil && v.add(ref, fmt.Errorf("failed to apply config env to secret %s, reason: %w", s.Name, err)) {
+			return nil
+		}
+		// This is needed to store the updated secret config after
+		// ApplyConfigEnv().
+		conf[i] = s
+
+		if len(s.Name) == 0 {
+			if v.add(ref, fmt.Errorf("secret name cannot be empty in the secrets config")) {
+				return nil
+			}
+			continue
+		}
+		if _, found := secrets[s.Name]; found {
+			if v.add(ref, fmt.Errorf("secret %s defined more than once in the secrets config", s.Name)) {
+				return nil
+			}
+			continue
+		}
+
+		// Invalid secrets are still added when collecting all the errors
+		// to avoid reporting the containers referring to them.
+		secrets[s.Name] = &secret{config: s, configsDir: configsDir}
+		if v.check(ref, validateSecretConfig(&s, processEnvAllowlist)) {
+			return nil
+		}
+	}
+	return secrets
+}
+
+func validateSecretConfig(s *config.Secret, processEnvAllowlist []string) error {
+	sources := 0
+	for _, specified := range []bool{len(s.File) > 0, len(s.Command) > 0, len(s.Env) > 0} {
+		if specified {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("exactly one of file, command or env must be specified for secret %s", s.Name)
+	}
+	if len(s.Env) > 0 && !slices.Contains(processEnvAllowlist, s.Env) {
+		return fmt.Errorf("env %s for secret %s is not present in the global config processEnvAllowlist", s.Env, s.Name)
+	}
+	return nil
+}
+
+// resolve returns the value of the secret, which is resolved only once.
+func (s *secret) resolve(exec cmdexec.Executor) (string, error) {
+	s.once.Do(func() {
+		s.value, s.err = resolveSecret(exec, &s.config, s.configsDir)
+	})
+	return s.value, s.err
+}
+
+func resolveSecret(exec cmdexec.Executor, s *config.Secret, configsDir string) (string, error) {
+	switch {
+	case len(s.File) > 0:
+		val, err := os.ReadFile(configPath(configsDir, s.File))
+		if err != nil {
+			return "", fmt.Errorf("failed to read the file for secret %s, reason: %w", s.Name, err)
+		}
+		return string(val), nil
+	case len(s.Command) > 0:
+		out, err := exec.Run(s.Command[0], s.Command[1:]...)
+		if err != nil {
+			return "", fmt.Errorf("failed to run the command for secret %s, reason: %w", s.Name, err)
+		}
+		// Similar to the shell command substitution, the trailing
+		// newlines of the command output are not part of the value.
+		return strings.TrimRight(out, "\n"), nil
+	default:
+		val, found := os.LookupEnv(s.Env)
+		if !found {
+			return "", fmt.Errorf("env %s for secret %s is not set in the environment", s.Env, s.Name)
+		}
+		return val, nil
+	}
+}
+
+func validateContainerSecretsConfig(conf []config.ContainerSecret, secrets secretMap, location string) error {
+	names := make(map[string]bool)
+	targets := make(map[string]bool)
+	for _, s := range conf {
+		if len(s.Name) == 0 {
+			return fmt.Errorf("secret name cannot be empty in %s", location)
+		}
+		if _, found := secrets[s.Name]; !found {
+			return fmt.Errorf("secret %s in %s is not defined in the secrets config", s.Name, location)
+		}
+		if names[s.Name] {
+			return fmt.Errorf("secret %s specified more than once in %s", s.Name, location)
+		}
+		names[s.Name] = true
+
+		target := containerSecretTarget(&s)
+		if !filepath.IsAbs(target) {
+			return fmt.Errorf("target %s of secret %s must be an absolute path in %s", target, s.Name, location)
+		}
+		if targets[target] {
+			return fmt.Errorf("target %s of secret %s is used by more than one secret in %s", target, s.Name, location)
+		}
+		targets[target] = true
+	}
+	return nil
+}
+
+func containerSecretTarget(s *config.ContainerSecret) string {
+	if len(s.Target) > 0 {
+		return s.Target
+	}
+	return fmt.Sprintf("%s/%s", defaultSecretsTargetDir, s.Name)
+}
+
+func containerSecretsDir(homelabBaseDir string, ct config.ContainerReference) string {
+	return fmt.Sprintf("%s/%s", containerBaseDir(homelabBaseDir, ct), containerSecretsDirName)
+}
+
+func (c *Container) secretMounts() []*containerSecretMount {
+	dir := containerSecretsDir(c.globalConfig.BaseDir, c.config.Info)
+	var res []*containerSecretMount
+	for _, s := range c.config.Secrets {
+		res = append(res, &containerSecretMount{
+			name:     s.Name,
+			hostPath: fmt.Sprintf("%s/%s", dir, s.Name),
+			target:   containerSecretTarget(&s),
+		})
+	}
+	return res
+}
+
+// resolveSecrets returns the values of the secrets of the container
+// keyed by the secret names.
+func (c *Container) resolveSecrets(ctx context.Context) (map[string]string, error) {
+	if len(c.config.Secrets) == 0 {
+		return nil, nil
+	}
+
+	exec := cmdexec.MustExecutor(ctx)
+	res := make(map[string]string)
+	for _, s := range c.config.Secrets {
+		val, err := c.secrets[s.Name].resolve(exec)
+		if err != nil {
+			return nil, err
+		}
+		res[s.Name] = val
+	}
+	return res, nil
+}
+
+// materializeSecrets writes the specified secret values of the container
+// to the files which are bind mounted into the container.
+func (c *Container) materializeSecrets(ctx context.Context, values map[string]string) error {
+	mounts := c.secretMounts()
+	if len(mounts) == 0 {
+		// Remove the secret files written previously for the secrets no
+		// longer referenced by the container.
+		return c.removeSecrets(ctx)
+	}
+
+	dir := containerSecretsDir(c.globalConfig.BaseDir, c.config.Info)
+	if rec, ok := dryrun.RecorderFromContext(ctx); ok {
+		for _, m := range mounts {
+			rec.Record("Write secret %s to %s", m.name, m.hostPath)
+		}
+		return c.pruneSecrets(ctx, dir, mounts)
+	}
+
+	if err := os.MkdirAll(dir, secretsDirPerms); err != nil {
+		return fmt.Errorf("failed to create the secrets directory %s for container %s, reason: %w", dir, c.Name(), err)
+	}
+	// Restrict the permissions even if the directory existed already.
+	if err := os.Chmod(dir, secretsDirPerms); err != nil {
+		return fmt.Errorf("failed to restrict the permissions of the secrets directory %s for container %s, reason: %w", dir, c.Name(), err)
+	}
+	for _, m := range mounts {
+		log(ctx).Debugf("Writing secret %s for container %s", m.name, c.Name())
+		if err := writeSecretFile(dir, m.hostPath, values[m.name]); err != nil {
+			return fmt.Errorf("failed to write secret %s for container %s, reason: %w", m.name, c.Name(), err)
+		}
+	}
+	return c.pruneSecrets(ctx, dir, mounts)
+}
+
+// pruneSecrets removes the files within the secrets directory of the
+// container other than the ones for the specified secret mounts.
+func (c *Container) pruneSecrets(ctx context.Context, dir string, mounts []*containerSecretMount) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the secrets directory %s for container %s, reason: %w", dir, c.Name(), err)
+	}
+
+	referenced := make(map[string]bool, len(mounts))
+	for _, m := range mounts {
+		referenced[filepath.Base(m.hostPath)] = true
+	}
+	for _, e := range entries {
+		if referenced[e.Name()] {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if rec, ok := dryrun.RecorderFromContext(ctx); ok {
+			rec.Record("Remove the unreferenced secret file %s", path)
+			continue
+		}
+		log(ctx).Debugf("Removing the unreferenced secret file %s for container %s", path, c.Name())
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("failed to remove the unreferenced secret file %s for container %s, reason: %w", path, c.Name(), err)
+		}
+	}
+	return nil
+}
+
+// secretsUpToDate returns true if the secret files written previously
+// for the container match the specified secret values. The changes to
+// the secrets are tracked through these files rather than the config
+// hash, since the config hash is visible to anyone who can inspect the
+// container.
+func (c *Container) secretsUpToDate(ctx context.Context, values map[string]string) bool {
+	for _, m := range c.secretMounts() {
+		got, err := os.ReadFile(m.hostPath)
+		if err != nil {
+			log(ctx).Debugf("Failed to read the secret file %s for container %s, reason: %v", m.hostPath, c.Name(), err)
+			return false
+		}
+		if string(got) != values[m.name] {
+			return false
+		}
+	}
+	return true
+}
+
+// removeSecrets removes the secret files written for the container (if
+// any), including the ones for the secrets no longer referenced by it.
+func (c *Container) removeSecrets(ctx context.Context) error {
+	dir := containerSecretsDir(c.globalConfig.BaseDir, c.config.Info)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	if rec, ok := dryrun.RecorderFromContext(ctx); ok {
+		rec.Record("Remove the secrets directory %s", dir)
+		return nil
+	}
+
+	log(ctx).Debugf("Removing the secrets directory %s for container %s", dir, c.Name())
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove the secrets directory %s for container %s, reason: %w", dir, c.Name(), err)
+	}
+	return nil
+}
+
+// writeSecretFile atomically replaces the secret file, since the
+// previously written secret file is read-only.
+func writeSecretFile(dir, path, value string) error {
+	f, err := os.CreateTemp(dir, ".secret-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	_, err = f.WriteString(value)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, secretFilePerms); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// RedactedConfig returns a copy of the homelab config with the values
+// of the secrets redacted. Only the secrets read from the files and the
+// process env are resolved for redacting. The secret commands are never
+// run just for showing the config, hence the values of the secrets read
+// from the commands remain visible wherever they are inlined within the
+// config (e.g. through a config env variable using the same command).
+func (d *Deployment) RedactedConfig() *config.Homelab {
+	values := make([]string, 0, len(d.secrets))
+	for _, s := range d.secrets {
+		if len(s.config.Command) > 0 {
+			continue
+		}
+		// The executor is not needed for resolving these secrets, and
+		// the secrets which cannot be resolved have nothing to redact.
+		if val, err := s.resolve(nil); err == nil {
+			values = append(values, val)
+		}
+	}
+	return config.Redacted(d.Config, values)
+}
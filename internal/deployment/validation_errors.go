@@ -0,0 +1,80 @@
+package deployment
+
+import (
+	"fmt"
+
+	"github.com/tuxgal/homelab/internal/config"
+)
+
+// ValidationError represents an error encountered while validating an
+// element of the homelab config.
+type ValidationError struct {
+	// Element is the homelab config element the error belongs to.
+	Element config.ElementRef
+	// Source is the position within the config files where the element
+	// is defined, nil if unknown.
+	Source *config.SourcePos
+	Err    error
+}
+
+// ValidationErrors represents the list of errors encountered while
+// validating the homelab config, in the order they were encountered.
+type ValidationErrors []*ValidationError
+
+type errorCollector struct {
+	collectAll bool
+	errs       ValidationErrors
+}
+
+func (v *ValidationError) Error() string {
+	if v.Source != nil {
+		return fmt.Sprintf("%s: %s", v.Source, v.Err)
+	}
+	return v.Err.Error()
+}
+
+func (v *ValidationError) Unwrap() error {
+	return v.Err
+}
+
+// AnnotateSources updates the source position of every error whose
+// element is found in the specified source index.
+func (v ValidationErrors) AnnotateSources(idx config.SourceIndex) {
+	for _, e := range v {
+		if pos, found := idx.Lookup(e.Element); found {
+			e.Source = &pos
+		}
+	}
+}
+
+// add records the error against the specified element and returns true
+// if the validation must not proceed any further.
+func (e *errorCollector) add(ref config.ElementRef, err error) bool {
+	e.errs = append(e.errs, &ValidationError{Element: ref, Err: err})
+	return !e.collectAll
+}
+
+// check records the error (if non-nil) against the specified element
+// and returns true if the validation must not proceed any further.
+func (e *errorCollector) check(ref config.ElementRef, err error) bool {
+	if err == nil {
+		return false
+	}
+	return e.add(ref, err)
+}
+
+// done returns true if the validation must not proceed any further.
+func (e *errorCollector) done() bool {
+	return !e.collectAll && e.failed()
+}
+
+func (e *errorCollector) failed() bool {
+	return len(e.errs) > 0
+}
+
+func (e *errorCollector) firstErr() error {
+	if len(e.errs) == 0 {
+		return nil
+	}
+	return e.errs[0].Err
+}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"net/netip"
 	"os"
+	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	dcontainer "github.com/docker/docker/api/types/container"
 	"github.com/docker/go-units"
 	"github.com/tuxgal/homelab/internal/cmdexec"
 	"github.com/tuxgal/homelab/internal/config"
@@ -27,29 +31,43 @@ var (
 	reservedULAPrefix = netip.PrefixFrom(netip.MustParseAddr(reservedULAAddr), reservedULAAddrBits)
 )
 
-func validateGlobalConfig(ctx context.Context, parentEnv *env.ConfigEnvManager, conf *config.Global) (*env.ConfigEnvManager, error) {
-	if err := validateBaseDir(conf.BaseDir); err != nil {
-		return nil, err
+func validateGlobalConfig(ctx context.Context, v *errorCollector, parentEnv *env.ConfigEnvManager, conf *config.Global, configsDir string) *env.ConfigEnvManager {
+	ref := config.GlobalElementRef()
+	if v.check(ref, validateBaseDir(conf.BaseDir)) {
+		return nil
 	}
 
-	newEnvMap, newEnvOrder, err := validateConfigEnv(conf.Env, "global config")
-	if err != nil {
-		return nil, err
+	if v.check(ref, validateProcessEnvAllowlist(conf.ProcessEnvAllowlist)) {
+		return nil
+	}
+	newEnvMap, newEnvOrder, err := validateConfigEnv(conf.Env, conf.ProcessEnvAllowlist, configsDir, "global config")
+	if v.check(ref, err) {
+		return nil
+	}
+	// The env files override the config env and hence are skipped when
+	// the config env itself is invalid.
+	if err == nil {
+		newEnvMap, newEnvOrder, err = validateConfigEnvFiles(conf.EnvFiles, configsDir, newEnvMap, newEnvOrder)
+		if v.check(ref, err) {
+			return nil
+		}
 	}
 
 	// Apply the config env prior to validating other info within the global config.
 	env := parentEnv.NewGlobalConfigEnvManager(ctx, conf.BaseDir, newEnvMap, newEnvOrder)
-	conf.ApplyConfigEnv(env)
+	if err := conf.ApplyConfigEnv(env); err != nil && v.add(ref, fmt.Errorf("failed to apply config env to global config, reason: %w", err)) {
+		return nil
+	}
 
-	if err := validateMountsConfig(conf.MountDefs, nil, nil, "global config mount defs"); err != nil {
-		return nil, err
+	if v.check(ref, validateMountsConfig(conf.MountDefs, nil, nil, "global config mount defs")) {
+		return nil
 	}
 
-	if err := validateGlobalContainerConfig(&conf.Container, conf.MountDefs); err != nil {
-		return nil, err
+	if validateGlobalContainerConfig(v, ref, &conf.Container, conf.MountDefs) {
+		return nil
 	}
 
-	return env, nil
+	return env
 }
 
 func validateBaseDir(baseDir string) error {
@@ -66,7 +84,16 @@ func validateBaseDir(baseDir string) error {
 	return nil
 }
 
-func validateConfigEnv(conf []config.ConfigEnv, location string) (env.EnvMap, env.EnvOrder, error) {
+func validateProcessEnvAllowlist(allowlist []string) error {
+	for _, e := range allowlist {
+		if len(e) == 0 {
+			return fmt.Errorf("empty env var in global config processEnvAllowlist")
+		}
+	}
+	return nil
+}
+
+func validateConfigEnv(conf []config.ConfigEnv, processEnvAllowlist []string, configsDir, location string) (env.EnvMap, env.EnvOrder, error) {
 	envs := env.EnvMap{}
 	envOrder := env.EnvOrder{}
 	for _, e := range conf {
@@ -77,16 +104,38 @@ func validateConfigEnv(conf []config.ConfigEnv, location string) (env.EnvMap, en
 			return nil, nil, fmt.Errorf("env var %s specified more than once in %s", e.Var, location)
 		}
 
-		if len(e.Value) == 0 && len(e.ValueCommand) == 0 {
-			return nil, nil, fmt.Errorf("neither value nor valueCommand specified for env var %s in %s", e.Var, location)
+		sources := 0
+		for _, specified := range []bool{len(e.Value) > 0, len(e.ValueCommand) > 0, len(e.ValueFile) > 0, len(e.ValueFromEnv) > 0} {
+			if specified {
+				sources++
+			}
+		}
+		if sources == 0 {
+			return nil, nil, fmt.Errorf("none of value, valueCommand, valueFile or valueFromEnv specified for env var %s in %s", e.Var, location)
 		}
-		if len(e.Value) > 0 && len(e.ValueCommand) > 0 {
-			return nil, nil, fmt.Errorf("exactly one of value or valueCommand must be specified for env var %s in %s", e.Var, location)
+		if sources > 1 {
+			return nil, nil, fmt.Errorf("exactly one of value, valueCommand, valueFile or valueFromEnv must be specified for env var %s in %s", e.Var, location)
 		}
 
-		if len(e.Value) > 0 {
+		switch {
+		case len(e.Value) > 0:
 			envs[e.Var] = e.Value
-		} else {
+		case len(e.ValueFile) > 0:
+			val, err := os.ReadFile(configPath(configsDir, e.ValueFile))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read valueFile for env var %s in %s, reason: %w", e.Var, location, err)
+			}
+			envs[e.Var] = strings.TrimSpace(string(val))
+		case len(e.ValueFromEnv) > 0:
+			if !slices.Contains(processEnvAllowlist, e.ValueFromEnv) {
+				return nil, nil, fmt.Errorf("valueFromEnv %s for env var %s in %s is not present in the global config processEnvAllowlist", e.ValueFromEnv, e.Var, location)
+			}
+			val, found := os.LookupEnv(e.ValueFromEnv)
+			if !found {
+				return nil, nil, fmt.Errorf("valueFromEnv %s for env var %s in %s is not set in the environment", e.ValueFromEnv, e.Var, location)
+			}
+			envs[e.Var] = val
+		default:
 			// TODO: Evaluate the value by running the command using the executor.
 			envs[e.Var] = "TODO" // e.ValueCommand
 		}
@@ -95,6 +144,36 @@ func validateConfigEnv(conf []config.ConfigEnv, location string) (env.EnvMap, en
 	return envs, envOrder, nil
 }
 
+// validateConfigEnvFiles reads the env files and returns the config env
+// overridden by the env read from the files in order.
+func validateConfigEnvFiles(envFiles []string, configsDir string, envs env.EnvMap, envOrder env.EnvOrder) (env.EnvMap, env.EnvOrder, error) {
+	for _, f := range envFiles {
+		if len(f) == 0 {
+			return nil, nil, fmt.Errorf("empty env file path in global config")
+		}
+		fileEnvs, fileEnvOrder, err := env.ReadEnvFile(configPath(configsDir, f))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read env file %s in global config, reason: %w", f, err)
+		}
+		for _, k := range fileEnvOrder {
+			if _, found := envs[k]; !found {
+				envOrder = append(envOrder, k)
+			}
+			envs[k] = fileEnvs[k]
+		}
+	}
+	return envs, envOrder, nil
+}
+
+// configPath returns the path resolved against the configs dir if the
+// path is relative.
+func configPath(configsDir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(configsDir, path)
+}
+
 func validateContainerEnv(conf []config.ContainerEnv, location string) error {
 	envs := utils.StringSet{}
 	for _, e := range conf {
@@ -245,6 +324,36 @@ func validateSysctlsConfig(sysctls []config.Sysctl, location string) error {
 	return nil
 }
 
+func validateUlimitsConfig(ulimits []config.Ulimit, location string) error {
+	names := utils.StringSet{}
+	for _, u := range ulimits {
+		if len(u.Name) == 0 {
+			return fmt.Errorf("empty ulimit name in %s", location)
+		}
+		if _, found := names[u.Name]; found {
+			return fmt.Errorf("ulimit %s specified more than once in %s", u.Name, location)
+		}
+		names[u.Name] = struct{}{}
+
+		if _, err := units.ParseUlimit(ulimitSpec(&u)); err != nil {
+			return fmt.Errorf("invalid ulimit %s in %s, reason: %w", u.Name, location, err)
+		}
+	}
+	return nil
+}
+
+func validateNamespaceModes(conf *config.ContainerSecurity, location string) error {
+	pidMode := dcontainer.PidMode(conf.PidMode)
+	if !pidMode.Valid() {
+		return fmt.Errorf("invalid pid mode %s in %s, valid values are [ 'host', 'container:<name>' ]", conf.PidMode, location)
+	}
+	ipcMode := dcontainer.IpcMode(conf.IpcMode)
+	if !ipcMode.Valid() || (ipcMode.IsContainer() && len(ipcMode.Container()) == 0) {
+		return fmt.Errorf("invalid ipc mode %s in %s, valid values are [ 'none', 'private', 'shareable', 'host', 'container:<name>' ]", conf.IpcMode, location)
+	}
+	return nil
+}
+
 func validateHealthConfig(conf *config.ContainerHealth, location string) error {
 	if conf.Retries < 0 {
 		return fmt.Errorf("health check retries %d cannot be negative in %s", conf.Retries, location)
@@ -272,25 +381,132 @@ func validateHealthConfig(conf *config.ContainerHealth, location string) error {
 	return nil
 }
 
-func validateGlobalContainerConfig(conf *config.GlobalContainer, globalMountDefs []config.Mount) error {
-	if conf.StopTimeout < 0 {
-		return fmt.Errorf("container stop timeout %d cannot be negative in global container config", conf.StopTimeout)
+// validateGlobalContainerConfig returns true if the validation must
+// not proceed any further.
+func validateGlobalContainerConfig(v *errorCollector, ref config.ElementRef, conf *config.GlobalContainer, globalMountDefs []config.Mount) bool {
+	if conf.StopTimeout < 0 && v.add(ref, fmt.Errorf("container stop timeout %d cannot be negative in global container config", conf.StopTimeout)) {
+		return true
 	}
-	if err := validateContainerRestartPolicy(&conf.RestartPolicy, "global container config"); err != nil {
-		return err
+	if conf.PurgeGracePeriod < 0 && v.add(ref, fmt.Errorf("container purge grace period %d cannot be negative in global container config", conf.PurgeGracePeriod)) {
+		return true
+	}
+	return v.check(ref, validateContainerRestartPolicy(&conf.RestartPolicy, "global container config")) ||
+		v.check(ref, validateContainerEnv(conf.Env, "global container config")) ||
+		v.check(ref, validateMountsConfig(conf.Mounts, nil, globalMountDefs, "global container config mounts")) ||
+		v.check(ref, validateLabelsConfig(conf.Labels, "global container config")) ||
+		v.check(ref, validateResourcesConfig(&conf.Resources, "global container config")) ||
+		v.check(ref, validateLoggingConfig(&conf.Logging, "global container config"))
+}
+
+func validateLoggingConfig(conf *config.ContainerLogging, location string) error {
+	if len(conf.Driver) == 0 {
+		if len(conf.Options) > 0 {
+			return fmt.Errorf("logging options cannot be set without setting the logging driver in %s", location)
+		}
+		return nil
+	}
+	if !docker.IsValidLogDriver(conf.Driver) {
+		return fmt.Errorf("invalid logging driver %s in %s, valid values are %s", conf.Driver, location, docker.LogDriverValidValues())
 	}
-	if err := validateContainerEnv(conf.Env, "global container config"); err != nil {
+	if conf.Driver == "none" && len(conf.Options) > 0 {
+		return fmt.Errorf("logging options cannot be set when the logging driver is none in %s", location)
+	}
+
+	opts := utils.StringSet{}
+	for _, o := range conf.Options {
+		if len(o.Name) == 0 {
+			return fmt.Errorf("empty logging option name in %s", location)
+		}
+		if _, found := opts[o.Name]; found {
+			return fmt.Errorf("logging option %s specified more than once in %s", o.Name, location)
+		}
+		opts[o.Name] = struct{}{}
+
+		if len(o.Value) == 0 {
+			return fmt.Errorf("empty logging option value for logging option %s in %s", o.Name, location)
+		}
+	}
+	return nil
+}
+
+func validateResourcesConfig(conf *config.ContainerResources, location string) error {
+	memory, err := validateRAMSize(conf.Memory, "memory", false, location)
+	if err != nil {
 		return err
 	}
-	if err := validateMountsConfig(conf.Mounts, nil, globalMountDefs, "global container config mounts"); err != nil {
+	reservation, err := validateRAMSize(conf.MemoryReservation, "memory reservation", false, location)
+	if err != nil {
 		return err
 	}
-	if err := validateLabelsConfig(conf.Labels, "global container config"); err != nil {
+	swap, err := validateRAMSize(conf.MemorySwap, "memory swap", true, location)
+	if err != nil {
 		return err
 	}
+	if memory > 0 && reservation > memory {
+		return fmt.Errorf("memory reservation %s cannot be greater than memory %s in %s", conf.MemoryReservation, conf.Memory, location)
+	}
+	if swap != 0 {
+		if memory == 0 {
+			return fmt.Errorf("memory swap cannot be set without setting memory in %s", location)
+		}
+		if swap > 0 && swap < memory {
+			return fmt.Errorf("memory swap %s cannot be less than memory %s in %s", conf.MemorySwap, conf.Memory, location)
+		}
+	}
+
+	if conf.CPUs < 0 {
+		return fmt.Errorf("cpus %v cannot be negative in %s", conf.CPUs, location)
+	}
+	if conf.CPUShares < 0 {
+		return fmt.Errorf("cpu shares %d cannot be negative in %s", conf.CPUShares, location)
+	}
+	if len(conf.CPUSet) > 0 && !isValidCPUSet(conf.CPUSet) {
+		return fmt.Errorf("invalid cpuset %s in %s, must be a comma separated list of cpus or cpu ranges", conf.CPUSet, location)
+	}
+	if conf.PidsLimit < -1 {
+		return fmt.Errorf("pids limit %d cannot be less than -1 in %s", conf.PidsLimit, location)
+	}
+	if conf.BlkioWeight != 0 && (conf.BlkioWeight < 10 || conf.BlkioWeight > 1000) {
+		return fmt.Errorf("blkio weight %d must be between 10 and 1000 in %s", conf.BlkioWeight, location)
+	}
 	return nil
 }
 
+func validateRAMSize(size, desc string, allowUnlimited bool, location string) (int64, error) {
+	if len(size) == 0 {
+		return 0, nil
+	}
+	if allowUnlimited && size == unlimitedSize {
+		return -1, nil
+	}
+	res, err := units.RAMInBytes(size)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s in %s, reason: %w", desc, size, location, err)
+	}
+	if res <= 0 {
+		return 0, fmt.Errorf("%s %s must be positive in %s", desc, size, location)
+	}
+	return res, nil
+}
+
+func isValidCPUSet(cpuSet string) bool {
+	for _, r := range strings.Split(cpuSet, ",") {
+		start, end, isRange := strings.Cut(r, "-")
+		first, err := strconv.ParseUint(start, 10, 16)
+		if err != nil {
+			return false
+		}
+		if !isRange {
+			continue
+		}
+		last, err := strconv.ParseUint(end, 10, 16)
+		if err != nil || last < first {
+			return false
+		}
+	}
+	return true
+}
+
 func validateContainerRestartPolicy(conf *config.ContainerRestartPolicy, location string) error {
 	if conf.Mode != "on-failure" && conf.MaxRetryCount != 0 {
 		return fmt.Errorf("restart policy max retry count can be set only when the mode is on-failure in %s", location)
@@ -307,208 +523,83 @@ func validateContainerRestartPolicy(conf *config.ContainerRestartPolicy, locatio
 	return nil
 }
 
-func validateIPAMConfig(ctx context.Context, conf *config.IPAM) (NetworkMap, map[config.ContainerReference]networkEndpointList, error) {
-	networks := NetworkMap{}
-	hostInterfaces := utils.StringSet{}
-	bridgeModeNetworks := conf.Networks.BridgeModeNetworks
-	v4Prefixes := make(map[netip.Prefix]string)
-	v6Prefixes := make(map[netip.Prefix]string)
-	containerEndpoints := make(map[config.ContainerReference]networkEndpointList)
-	allBridgeModeContainers := make(map[config.ContainerReference]struct{})
-
-	for _, n := range bridgeModeNetworks {
-		if len(n.Name) == 0 {
-			return nil, nil, fmt.Errorf("network name cannot be empty")
-		}
-		if _, found := networks[n.Name]; found {
-			return nil, nil, fmt.Errorf("network %s defined more than once in the IPAM config", n.Name)
-		}
+type ipamState struct {
+	networks                   NetworkMap
+	hostInterfaces             utils.StringSet
+	v4Prefixes                 map[netip.Prefix]string
+	v6Prefixes                 map[netip.Prefix]string
+	containerEndpoints         map[config.ContainerReference]networkEndpointList
+	allBridgeModeContainers    map[config.ContainerReference]struct{}
+	allContainerModeContainers map[config.ContainerReference]struct{}
+}
 
-		if len(n.HostInterfaceName) == 0 {
-			return nil, nil, fmt.Errorf("host interface name of network %s cannot be empty", n.Name)
-		}
-		if _, found := hostInterfaces[n.HostInterfaceName]; found {
-			return nil, nil, fmt.Errorf("host interface name %s of network %s is already used by another network in the IPAM config", n.HostInterfaceName, n.Name)
-		}
-		if n.Priority <= 0 {
-			return nil, nil, fmt.Errorf("network %s cannot have a non-positive priority %d", n.Name, n.Priority)
-		}
+func validateIPAMConfig(ctx context.Context, v *errorCollector, conf *config.IPAM) (NetworkMap, map[config.ContainerReference]networkEndpointList) {
+	s := &ipamState{
+		networks:                   NetworkMap{},
+		hostInterfaces:             utils.StringSet{},
+		v4Prefixes:                 make(map[netip.Prefix]string),
+		v6Prefixes:                 make(map[netip.Prefix]string),
+		containerEndpoints:         make(map[config.ContainerReference]networkEndpointList),
+		allBridgeModeContainers:    make(map[config.ContainerReference]struct{}),
+		allContainerModeContainers: make(map[config.ContainerReference]struct{}),
+	}
 
-		hostInterfaces[n.HostInterfaceName] = struct{}{}
-		v4Prefix, err := netip.ParsePrefix(n.CIDR.V4)
+	for _, n := range conf.Networks.BridgeModeNetworks {
+		ref := config.NetworkElementRef(n.Name)
+		bmn, err := s.addBridgeModeNetwork(&n)
 		if err != nil {
-			return nil, nil, fmt.Errorf("v4 CIDR %s of network %s is invalid, reason: %w", n.CIDR.V4, n.Name, err)
-		}
-		v4NetAddr := v4Prefix.Addr()
-		if !v4NetAddr.Is4() {
-			return nil, nil, fmt.Errorf("v4 CIDR %s of network %s is not an IPv4 subnet CIDR", n.CIDR.V4, n.Name)
-		}
-		if masked := v4Prefix.Masked(); masked.Addr() != v4NetAddr {
-			return nil, nil, fmt.Errorf("v4 CIDR %s of network %s is not the same as the network address %s", n.CIDR.V4, n.Name, masked)
-		}
-		if prefixLen := v4Prefix.Bits(); prefixLen > 30 {
-			return nil, nil, fmt.Errorf("v4 CIDR %s of network %s (prefix length: %d) cannot have a prefix length more than 30 which makes the network unusable for container IP address allocations", n.CIDR.V4, n.Name, prefixLen)
-		}
-		if !v4NetAddr.IsPrivate() {
-			return nil, nil, fmt.Errorf("v4 CIDR %s of network %s is not within the RFC1918 private address space", n.CIDR.V4, n.Name)
-		}
-		for pre, preNet := range v4Prefixes {
-			if v4Prefix.Overlaps(pre) {
-				return nil, nil, fmt.Errorf("v4 CIDR %s of network %s overlaps with v4 CIDR %s of network %s", n.CIDR.V4, n.Name, pre, preNet)
+			if v.add(ref, err) {
+				return nil, nil
 			}
+			continue
 		}
-		v4Prefixes[v4Prefix] = n.Name
-		v4GatewayAddr := v4NetAddr.Next()
-
-		var v6Prefix netip.Prefix
-		var v6GatewayAddr netip.Addr
-		var v6NetAddr netip.Addr
-		if n.CIDR.V6 != "" {
-			var err error
-			v6Prefix, err = netip.ParsePrefix(n.CIDR.V6)
-			if err != nil {
-				return nil, nil, fmt.Errorf("v6 CIDR %s of network %s is invalid, reason: %w", n.CIDR.V6, n.Name, err)
-			}
-			v6NetAddr = v6Prefix.Addr()
-			if !v6NetAddr.Is6() {
-				return nil, nil, fmt.Errorf("v6 CIDR %s of network %s is not an IPv6 subnet CIDR", n.CIDR.V6, n.Name)
-			}
-			if masked := v6Prefix.Masked(); masked.Addr() != v6NetAddr {
-				return nil, nil, fmt.Errorf("v6 CIDR %s of network %s is not the same as the network address %s", n.CIDR.V6, n.Name, masked)
-			}
-			if prefixLen := v6Prefix.Bits(); prefixLen != 64 {
-				return nil, nil, fmt.Errorf("v6 CIDR %s of network %s (prefix length: %d) must have a prefix length 64 as per the convention for IPv6 networks", n.CIDR.V6, n.Name, prefixLen)
-			}
-			if !v6NetAddr.IsPrivate() {
-				return nil, nil, fmt.Errorf("v6 CIDR %s of network %s is not within the ULA private address space", n.CIDR.V6, n.Name)
-			}
-			if v6Prefix.Overlaps(reservedULAPrefix) {
-				return nil, nil, fmt.Errorf("v6 CIDR %s of network %s overlaps with the reserved ULA prefix %s", n.CIDR.V6, n.Name, reservedULAPrefix)
-			}
-			for pre, preNet := range v6Prefixes {
-				if v6Prefix.Overlaps(pre) {
-					return nil, nil, fmt.Errorf("v6 CIDR %s of network %s overlaps with v6 CIDR %s of network %s", n.CIDR.V6, n.Name, pre, preNet)
-				}
-			}
-			v6Prefixes[v6Prefix] = n.Name
-			v6GatewayAddr = v6NetAddr.Next()
-		}
-
-		bmn := newBridgeModeNetwork(n.Name, n.Priority, &bridgeModeNetworkInfo{
-			priority:          n.Priority,
-			hostInterfaceName: n.HostInterfaceName,
-			v4CIDR:            v4Prefix,
-			v4Gateway:         v4GatewayAddr,
-			enableV6:          n.CIDR.V6 != "",
-			v6CIDR:            v6Prefix,
-			v6Gateway:         v6GatewayAddr,
-		})
-		networks[n.Name] = bmn
 
 		containers := make(map[config.ContainerReference]struct{})
 		containerIPs := make(map[netip.Addr]struct{})
 		for _, cip := range n.Containers {
-			ct := cip.Container
-			if err := validateContainerReference(&ct); err != nil {
-				return nil, nil, fmt.Errorf("container IP config within network %s has invalid container reference, reason: %w", n.Name, err)
-			}
-
-			ipv4 := cip.IP.IPv4
-			caddrv4, err := netip.ParseAddr(ipv4)
-			if err != nil {
-				return nil, nil, fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s has invalid v4 IP %s, reason: %w", ct.Group, ct.Container, n.Name, ipv4, err)
-			}
-			if !v4Prefix.Contains(caddrv4) {
-				return nil, nil, fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have a v4 IP %s that does not belong to the network v4 CIDR %s", ct.Group, ct.Container, n.Name, ipv4, v4Prefix)
+			if v.check(ref, s.addBridgeModeEndpoint(bmn, &cip, containers, containerIPs)) {
+				return nil, nil
 			}
-			if caddrv4.Compare(v4NetAddr) == 0 {
-				return nil, nil, fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have an IP %s matching the network address %s", ct.Group, ct.Container, n.Name, ipv4, v4NetAddr)
-			}
-			if caddrv4.Compare(v4GatewayAddr) == 0 {
-				return nil, nil, fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have an IP %s matching the gateway address %s", ct.Group, ct.Container, n.Name, ipv4, v4GatewayAddr)
-			}
-			if _, found := containerIPs[caddrv4]; found {
-				return nil, nil, fmt.Errorf("IP %s of container {Group:%s Container:%s} is already in use by another container in network %s", ipv4, ct.Group, ct.Container, n.Name)
-			}
-			containerIPs[caddrv4] = struct{}{}
-
-			ipv6 := cip.IP.IPv6
-			if ipv6 != "" {
-				caddrv6, err := netip.ParseAddr(ipv6)
-				if err != nil {
-					return nil, nil, fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s has invalid v6 IP %s, reason: %w", ct.Group, ct.Container, n.Name, ipv6, err)
-				}
-				if n.CIDR.V6 == "" {
-					return nil, nil, fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s specified a v6 IP address %s when the network has no v6 subnet CIDRs defined", ct.Group, ct.Container, n.Name, ipv6)
-				}
-				if !v6Prefix.Contains(caddrv6) {
-					return nil, nil, fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have a v6 IP %s that does not belong to the network v6 CIDR %s", ct.Group, ct.Container, n.Name, ipv6, v6Prefix)
-				}
-				if caddrv6.Compare(v6NetAddr) == 0 {
-					return nil, nil, fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have an IP %s matching the network address %s", ct.Group, ct.Container, n.Name, ipv6, v6NetAddr)
-				}
-				if caddrv6.Compare(v6GatewayAddr) == 0 {
-					return nil, nil, fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have an IP %s matching the gateway address %s", ct.Group, ct.Container, n.Name, ipv6, v6GatewayAddr)
-				}
-				if _, found := containerIPs[caddrv6]; found {
-					return nil, nil, fmt.Errorf("IP %s of container {Group:%s Container:%s} is already in use by another container in network %s", ipv6, ct.Group, ct.Container, n.Name)
-				}
-				containerIPs[caddrv6] = struct{}{}
-			}
-
-			if _, found := containers[ct]; found {
-				return nil, nil, fmt.Errorf("container {Group:%s Container:%s} cannot have multiple endpoints in network %s", ct.Group, ct.Container, n.Name)
-			}
-			containers[ct] = struct{}{}
-			allBridgeModeContainers[ct] = struct{}{}
-			containerEndpoints[ct] = append(containerEndpoints[ct], newBridgeModeEndpoint(bmn, ipv4, ipv6))
 		}
 	}
 
-	containerModeNetworks := conf.Networks.ContainerModeNetworks
-	allContainerModeContainers := make(map[config.ContainerReference]struct{})
-	for _, n := range containerModeNetworks {
-		if len(n.Name) == 0 {
-			return nil, nil, fmt.Errorf("network name cannot be empty")
-		}
-		if _, found := networks[n.Name]; found {
-			return nil, nil, fmt.Errorf("network %s defined more than once in the IPAM config", n.Name)
-		}
-		if err := validateContainerReference(&n.Container); err != nil {
-			return nil, nil, fmt.Errorf("container reference of container mode network %s is invalid, reason: %w", n.Name, err)
+	for _, n := range conf.Networks.ContainerModeNetworks {
+		ref := config.NetworkElementRef(n.Name)
+		cmn, err := s.addContainerModeNetwork(&n)
+		if err != nil {
+			if v.add(ref, err) {
+				return nil, nil
+			}
+			continue
 		}
-		cmn := newContainerModeNetwork(n.Name, &containerModeNetworkInfo{
-			container: n.Container,
-		})
-		networks[n.Name] = cmn
 
 		for _, ct := range n.AttachingContainers {
-			if err := validateContainerReference(&ct); err != nil {
-				return nil, nil, fmt.Errorf("container IP config within network %s has invalid container reference, reason: %w", n.Name, err)
-			}
-			if _, found := allContainerModeContainers[ct]; found {
-				return nil, nil, fmt.Errorf("container {Group:%s Container:%s} is connected to multiple container mode network stacks", ct.Group, ct.Container)
-			}
-			if _, found := allBridgeModeContainers[ct]; found {
-				return nil, nil, fmt.Errorf("container {Group:%s Container:%s} is connected to both bridge mode and container mode network stacks", ct.Group, ct.Container)
+			if v.check(ref, s.addContainerModeEndpoint(cmn, ct)) {
+				return nil, nil
 			}
-			allContainerModeContainers[ct] = struct{}{}
-			containerEndpoints[ct] = append(containerEndpoints[ct], newContainerModeEndpoint(cmn))
 		}
 	}
 
-	for ct, endpoints := range containerEndpoints {
+	// Iterate over the containers in a deterministic order to report
+	// the errors consistently.
+	refs := make([]config.ContainerReference, 0, len(s.containerEndpoints))
+	for ct := range s.containerEndpoints {
+		refs = append(refs, ct)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Group != refs[j].Group {
+			return refs[i].Group < refs[j].Group
+		}
+		return refs[i].Container < refs[j].Container
+	})
+	for _, ct := range refs {
+		endpoints := s.containerEndpoints[ct]
 		if len(endpoints) <= 1 {
 			continue
 		}
 
-		priorities := make(map[int]struct{})
-		for _, e := range endpoints {
-			p := e.network.bridgeModeInfo.priority
-			if _, found := priorities[p]; found {
-				return nil, nil, fmt.Errorf("container {Group:%s Container:%s} cannot have multiple bridge mode network endpoints whose networks have the same priority %d", ct.Group, ct.Container, p)
-			}
-			priorities[p] = struct{}{}
+		if v.check(config.IPAMElementRef(), validateEndpointPriorities(ct, endpoints)) {
+			return nil, nil
 		}
 
 		// Sort the networks for each container by priority (i.e. lowest
@@ -525,19 +616,219 @@ func validateIPAMConfig(ctx context.Context, conf *config.IPAM) (NetworkMap, map
 		})
 	}
 
-	return networks, containerEndpoints, nil
+	return s.networks, s.containerEndpoints
+}
+
+func (s *ipamState) addBridgeModeNetwork(n *config.BridgeModeNetwork) (*Network, error) {
+	if len(n.Name) == 0 {
+		return nil, fmt.Errorf("network name cannot be empty")
+	}
+	if _, found := s.networks[n.Name]; found {
+		return nil, fmt.Errorf("network %s defined more than once in the IPAM config", n.Name)
+	}
+
+	if len(n.HostInterfaceName) == 0 {
+		return nil, fmt.Errorf("host interface name of network %s cannot be empty", n.Name)
+	}
+	if _, found := s.hostInterfaces[n.HostInterfaceName]; found {
+		return nil, fmt.Errorf("host interface name %s of network %s is already used by another network in the IPAM config", n.HostInterfaceName, n.Name)
+	}
+	if n.Priority <= 0 {
+		return nil, fmt.Errorf("network %s cannot have a non-positive priority %d", n.Name, n.Priority)
+	}
+
+	s.hostInterfaces[n.HostInterfaceName] = struct{}{}
+	v4Prefix, err := netip.ParsePrefix(n.CIDR.V4)
+	if err != nil {
+		return nil, fmt.Errorf("v4 CIDR %s of network %s is invalid, reason: %w", n.CIDR.V4, n.Name, err)
+	}
+	v4NetAddr := v4Prefix.Addr()
+	if !v4NetAddr.Is4() {
+		return nil, fmt.Errorf("v4 CIDR %s of network %s is not an IPv4 subnet CIDR", n.CIDR.V4, n.Name)
+	}
+	if masked := v4Prefix.Masked(); masked.Addr() != v4NetAddr {
+		return nil, fmt.Errorf("v4 CIDR %s of network %s is not the same as the network address %s", n.CIDR.V4, n.Name, masked)
+	}
+	if prefixLen := v4Prefix.Bits(); prefixLen > 30 {
+		return nil, fmt.Errorf("v4 CIDR %s of network %s (prefix length: %d) cannot have a prefix length more than 30 which makes the network unusable for container IP address allocations", n.CIDR.V4, n.Name, prefixLen)
+	}
+	if !v4NetAddr.IsPrivate() {
+		return nil, fmt.Errorf("v4 CIDR %s of network %s is not within the RFC1918 private address space", n.CIDR.V4, n.Name)
+	}
+	for pre, preNet := range s.v4Prefixes {
+		if v4Prefix.Overlaps(pre) {
+			return nil, fmt.Errorf("v4 CIDR %s of network %s overlaps with v4 CIDR %s of network %s", n.CIDR.V4, n.Name, pre, preNet)
+		}
+	}
+	s.v4Prefixes[v4Prefix] = n.Name
+	v4GatewayAddr := v4NetAddr.Next()
+
+	var v6Prefix netip.Prefix
+	var v6GatewayAddr netip.Addr
+	if n.CIDR.V6 != "" {
+		var err error
+		v6Prefix, err = netip.ParsePrefix(n.CIDR.V6)
+		if err != nil {
+			return nil, fmt.Errorf("v6 CIDR %s of network %s is invalid, reason: %w", n.CIDR.V6, n.Name, err)
+		}
+		v6NetAddr := v6Prefix.Addr()
+		if !v6NetAddr.Is6() {
+			return nil, fmt.Errorf("v6 CIDR %s of network %s is not an IPv6 subnet CIDR", n.CIDR.V6, n.Name)
+		}
+		if masked := v6Prefix.Masked(); masked.Addr() != v6NetAddr {
+			return nil, fmt.Errorf("v6 CIDR %s of network %s is not the same as the network address %s", n.CIDR.V6, n.Name, masked)
+		}
+		if prefixLen := v6Prefix.Bits(); prefixLen != 64 {
+			return nil, fmt.Errorf("v6 CIDR %s of network %s (prefix length: %d) must have a prefix length 64 as per the convention for IPv6 networks", n.CIDR.V6, n.Name, prefixLen)
+		}
+		if !v6NetAddr.IsPrivate() {
+			return nil, fmt.Errorf("v6 CIDR %s of network %s is not within the ULA private address space", n.CIDR.V6, n.Name)
+		}
+		if v6Prefix.Overlaps(reservedULAPrefix) {
+			return nil, fmt.Errorf("v6 CIDR %s of network %s overlaps with the reserved ULA prefix %s", n.CIDR.V6, n.Name, reservedULAPrefix)
+		}
+		for pre, preNet := range s.v6Prefixes {
+			if v6Prefix.Overlaps(pre) {
+				return nil, fmt.Errorf("v6 CIDR %s of network %s overlaps with v6 CIDR %s of network %s", n.CIDR.V6, n.Name, pre, preNet)
+			}
+		}
+		s.v6Prefixes[v6Prefix] = n.Name
+		v6GatewayAddr = v6NetAddr.Next()
+	}
+
+	bmn := newBridgeModeNetwork(n.Name, n.Priority, &bridgeModeNetworkInfo{
+		priority:          n.Priority,
+		hostInterfaceName: n.HostInterfaceName,
+		v4CIDR:            v4Prefix,
+		v4Gateway:         v4GatewayAddr,
+		enableV6:          n.CIDR.V6 != "",
+		v6CIDR:            v6Prefix,
+		v6Gateway:         v6GatewayAddr,
+	})
+	s.networks[n.Name] = bmn
+	return bmn, nil
 }
 
-func validateHostsConfig(ctx context.Context, hosts []config.Host) (containerSet, error) {
+func (s *ipamState) addBridgeModeEndpoint(bmn *Network, cip *config.ContainerIPInfo, containers map[config.ContainerReference]struct{}, containerIPs map[netip.Addr]struct{}) error {
+	info := bmn.bridgeModeInfo
+	ct := cip.Container
+	if err := validateContainerReference(&ct); err != nil {
+		return fmt.Errorf("container IP config within network %s has invalid container reference, reason: %w", bmn.networkName, err)
+	}
+
+	ipv4 := cip.IP.IPv4
+	caddrv4, err := netip.ParseAddr(ipv4)
+	if err != nil {
+		return fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s has invalid v4 IP %s, reason: %w", ct.Group, ct.Container, bmn.networkName, ipv4, err)
+	}
+	if !info.v4CIDR.Contains(caddrv4) {
+		return fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have a v4 IP %s that does not belong to the network v4 CIDR %s", ct.Group, ct.Container, bmn.networkName, ipv4, info.v4CIDR)
+	}
+	if v4NetAddr := info.v4CIDR.Addr(); caddrv4.Compare(v4NetAddr) == 0 {
+		return fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have an IP %s matching the network address %s", ct.Group, ct.Container, bmn.networkName, ipv4, v4NetAddr)
+	}
+	if caddrv4.Compare(info.v4Gateway) == 0 {
+		return fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have an IP %s matching the gateway address %s", ct.Group, ct.Container, bmn.networkName, ipv4, info.v4Gateway)
+	}
+	if _, found := containerIPs[caddrv4]; found {
+		return fmt.Errorf("IP %s of container {Group:%s Container:%s} is already in use by another container in network %s", ipv4, ct.Group, ct.Container, bmn.networkName)
+	}
+	containerIPs[caddrv4] = struct{}{}
+
+	ipv6 := cip.IP.IPv6
+	if ipv6 != "" {
+		caddrv6, err := netip.ParseAddr(ipv6)
+		if err != nil {
+			return fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s has invalid v6 IP %s, reason: %w", ct.Group, ct.Container, bmn.networkName, ipv6, err)
+		}
+		if !info.enableV6 {
+			return fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s specified a v6 IP address %s when the network has no v6 subnet CIDRs defined", ct.Group, ct.Container, bmn.networkName, ipv6)
+		}
+		if !info.v6CIDR.Contains(caddrv6) {
+			return fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have a v6 IP %s that does not belong to the network v6 CIDR %s", ct.Group, ct.Container, bmn.networkName, ipv6, info.v6CIDR)
+		}
+		if v6NetAddr := info.v6CIDR.Addr(); caddrv6.Compare(v6NetAddr) == 0 {
+			return fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have an IP %s matching the network address %s", ct.Group, ct.Container, bmn.networkName, ipv6, v6NetAddr)
+		}
+		if caddrv6.Compare(info.v6Gateway) == 0 {
+			return fmt.Errorf("container {Group:%s Container:%s} endpoint in network %s cannot have an IP %s matching the gateway address %s", ct.Group, ct.Container, bmn.networkName, ipv6, info.v6Gateway)
+		}
+		if _, found := containerIPs[caddrv6]; found {
+			return fmt.Errorf("IP %s of container {Group:%s Container:%s} is already in use by another container in network %s", ipv6, ct.Group, ct.Container, bmn.networkName)
+		}
+		containerIPs[caddrv6] = struct{}{}
+	}
+
+	if _, found := containers[ct]; found {
+		return fmt.Errorf("container {Group:%s Container:%s} cannot have multiple endpoints in network %s", ct.Group, ct.Container, bmn.networkName)
+	}
+	containers[ct] = struct{}{}
+	s.allBridgeModeContainers[ct] = struct{}{}
+	s.containerEndpoints[ct] = append(s.containerEndpoints[ct], newBridgeModeEndpoint(bmn, ipv4, ipv6))
+	return nil
+}
+
+func (s *ipamState) addContainerModeNetwork(n *config.ContainerModeNetwork) (*Network, error) {
+	if len(n.Name) == 0 {
+		return nil, fmt.Errorf("network name cannot be empty")
+	}
+	if _, found := s.networks[n.Name]; found {
+		return nil, fmt.Errorf("network %s defined more than once in the IPAM config", n.Name)
+	}
+	if err := validateContainerReference(&n.Container); err != nil {
+		return nil, fmt.Errorf("container reference of container mode network %s is invalid, reason: %w", n.Name, err)
+	}
+	cmn := newContainerModeNetwork(n.Name, &containerModeNetworkInfo{
+		container: n.Container,
+	})
+	s.networks[n.Name] = cmn
+	return cmn, nil
+}
+
+func (s *ipamState) addContainerModeEndpoint(cmn *Network, ct config.ContainerReference) error {
+	if err := validateContainerReference(&ct); err != nil {
+		return fmt.Errorf("container IP config within network %s has invalid container reference, reason: %w", cmn.networkName, err)
+	}
+	if _, found := s.allContainerModeContainers[ct]; found {
+		return fmt.Errorf("container {Group:%s Container:%s} is connected to multiple container mode network stacks", ct.Group, ct.Container)
+	}
+	if _, found := s.allBridgeModeContainers[ct]; found {
+		return fmt.Errorf("container {Group:%s Container:%s} is connected to both bridge mode and container mode network stacks", ct.Group, ct.Container)
+	}
+	s.allContainerModeContainers[ct] = struct{}{}
+	s.containerEndpoints[ct] = append(s.containerEndpoints[ct], newContainerModeEndpoint(cmn))
+	return nil
+}
+
+func validateEndpointPriorities(ct config.ContainerReference, endpoints networkEndpointList) error {
+	priorities := make(map[int]struct{})
+	for _, e := range endpoints {
+		p := e.network.bridgeModeInfo.priority
+		if _, found := priorities[p]; found {
+			return fmt.Errorf("container {Group:%s Container:%s} cannot have multiple bridge mode network endpoints whose networks have the same priority %d", ct.Group, ct.Container, p)
+		}
+		priorities[p] = struct{}{}
+	}
+	return nil
+}
+
+func validateHostsConfig(ctx context.Context, v *errorCollector, hosts []config.Host) containerSet {
 	currentHost := host.MustHostInfo(ctx)
 	hostNames := utils.StringSet{}
 	allowedContainers := containerSet{}
 	for _, h := range hosts {
+		ref := config.HostElementRef(h.Name)
 		if len(h.Name) == 0 {
-			return nil, fmt.Errorf("host name cannot be empty in the hosts config")
+			if v.add(ref, fmt.Errorf("host name cannot be empty in the hosts config")) {
+				return nil
+			}
+			continue
 		}
 		if _, found := hostNames[h.Name]; found {
-			return nil, fmt.Errorf("host %s defined more than once in the hosts config", h.Name)
+			if v.add(ref, fmt.Errorf("host %s defined more than once in the hosts config", h.Name)) {
+				return nil
+			}
+			continue
 		}
 		hostNames[h.Name] = struct{}{}
 
@@ -545,10 +836,16 @@ func validateHostsConfig(ctx context.Context, hosts []config.Host) (containerSet
 		for _, ct := range h.AllowedContainers {
 			err := validateContainerReference(&ct)
 			if err != nil {
-				return nil, fmt.Errorf("allowed container config within host %s has invalid container reference, reason: %w", h.Name, err)
+				if v.add(ref, fmt.Errorf("allowed container config within host %s has invalid container reference, reason: %w", h.Name, err)) {
+					return nil
+				}
+				continue
 			}
 			if containers[ct] {
-				return nil, fmt.Errorf("container {Group:%s Container:%s} defined more than once in the hosts config for host %s", ct.Group, ct.Container, h.Name)
+				if v.add(ref, fmt.Errorf("container {Group:%s Container:%s} defined more than once in the hosts config for host %s", ct.Group, ct.Container, h.Name)) {
+					return nil
+				}
+				continue
 			}
 			containers[ct] = true
 			if h.Name == currentHost.HostName {
@@ -556,118 +853,248 @@ func validateHostsConfig(ctx context.Context, hosts []config.Host) (containerSet
 			}
 		}
 	}
-	return allowedContainers, nil
+	return allowedContainers
 }
 
-func validateGroupsConfig(groups []config.ContainerGroup) (ContainerGroupMap, error) {
+func validateGroupsConfig(v *errorCollector, groups []config.ContainerGroup) ContainerGroupMap {
 	containerGroups := ContainerGroupMap{}
 	for _, g := range groups {
+		ref := config.GroupElementRef(g.Name)
 		if len(g.Name) == 0 {
-			return nil, fmt.Errorf("group name cannot be empty in the groups config")
+			if v.add(ref, fmt.Errorf("group name cannot be empty in the groups config")) {
+				return nil
+			}
+			continue
 		}
 		if _, found := containerGroups[g.Name]; found {
-			return nil, fmt.Errorf("group %s defined more than once in the groups config", g.Name)
+			if v.add(ref, fmt.Errorf("group %s defined more than once in the groups config", g.Name)) {
+				return nil
+			}
+			continue
 		}
-		if g.Order < 1 {
-			return nil, fmt.Errorf("group %s cannot have a non-positive order %d", g.Name, g.Order)
+		// The group is still retained after reporting an invalid order
+		// to avoid reporting its containers as missing their group.
+		if g.Order < 1 && v.add(ref, fmt.Errorf("group %s cannot have a non-positive order %d", g.Name, g.Order)) {
+			return nil
 		}
 
 		containerGroups[g.Name] = NewContainerGroup(&g)
 	}
-	return containerGroups, nil
+	return containerGroups
 }
 
-func validateContainersConfig(ctx context.Context, parentEnv *env.ConfigEnvManager, containersConfig []config.Container, groups ContainerGroupMap, globalConfig *config.Global, containerEndpoints map[config.ContainerReference]networkEndpointList, allowedContainers containerSet) error {
+func validateContainersConfig(ctx context.Context, v *errorCollector, parentEnv *env.ConfigEnvManager, configsDir string, containersConfig []config.Container, groups ContainerGroupMap, globalConfig *config.Global, containerEndpoints map[config.ContainerReference]networkEndpointList, allowedContainers containerSet, secrets secretMap) {
 	exec := cmdexec.MustExecutor(ctx)
 	for i, ct := range containersConfig {
+		ref := config.ContainerElementRef(ct.Info)
 		g, found := groups[ct.Info.Group]
 		if !found {
-			return fmt.Errorf("group definition missing in groups config for the container {Group:%s Container:%s} in the containers config", ct.Info.Group, ct.Info.Container)
+			if v.add(ref, fmt.Errorf("group definition missing in groups config for the container {Group:%s Container:%s} in the containers config", ct.Info.Group, ct.Info.Container)) {
+				return
+			}
+			continue
 		}
 		if _, found := g.containers[ct.Info]; found {
-			return fmt.Errorf("container {Group:%s Container:%s} defined more than once in the containers config", ct.Info.Group, ct.Info.Container)
+			if v.add(ref, fmt.Errorf("container {Group:%s Container:%s} defined more than once in the containers config", ct.Info.Group, ct.Info.Container)) {
+				return
+			}
+			continue
 		}
 
-		loc := fmt.Sprintf("container {Group: %s Container:%s} config", ct.Info.Group, ct.Info.Container)
-		ctConfigEnvMap, ctConfigEnvOrder, err := validateConfigEnv(ct.Config.Env, loc)
-		if err != nil {
-			return err
-		}
-		ctEnv := parentEnv.NewContainerConfigEnvManager(ctx, containerGroupBaseDir(globalConfig.BaseDir, ct.Info), containerBaseDir(globalConfig.BaseDir, ct.Info), ctConfigEnvMap, ctConfigEnvOrder)
-		ct.ApplyConfigEnv(ctEnv)
-		if err := ct.ApplyCmdExecutor(exec); err != nil {
-			return err
+		if validateContainerConfig(ctx, v, ref, parentEnv, configsDir, exec, &ct, globalConfig, secrets) {
+			return
 		}
 
-		if len(ct.Image.Image) == 0 {
-			return fmt.Errorf("image cannot be empty in %s", loc)
-		}
-		if ct.Image.SkipImagePull {
-			if ct.Image.IgnoreImagePullFailures {
-				return fmt.Errorf("ignoreImagePullFailures cannot be true when skipImagePull is true in %s", loc)
-			}
-			if ct.Image.PullImageBeforeStop {
-				return fmt.Errorf("pullImageBeforeStop cannot be true when skipImagePull is true in %s", loc)
-			}
-		}
+		// Invalid containers are still added when collecting all the
+		// errors to avoid reporting their dependents as missing a
+		// dependency.
+		g.addContainer(&ct, globalConfig, containerEndpoints[ct.Info], allowedContainers[ct.Info], secrets)
+		// This is needed to store the updated container config after
+		// ApplyConfigEnv().
+		containersConfig[i] = ct
+	}
 
-		if err := validateLabelsConfig(ct.Metadata.Labels, loc); err != nil {
-			return err
-		}
+	validateContainerDependencies(v, groups)
+}
 
-		if ct.Lifecycle.Order <= 0 {
-			return fmt.Errorf("container order %d cannot be non-positive in %s", ct.Lifecycle.Order, loc)
-		}
-		if err := validateContainerRestartPolicy(&ct.Lifecycle.RestartPolicy, loc); err != nil {
-			return err
-		}
-		if ct.Lifecycle.StopTimeout < 0 {
-			return fmt.Errorf("container stop timeout %d cannot be negative in %s", ct.Lifecycle.StopTimeout, loc)
+// validateContainerConfig returns true if the validation must not
+// proceed any further.
+func validateContainerConfig(ctx context.Context, v *errorCollector, ref config.ElementRef, parentEnv *env.ConfigEnvManager, configsDir string, exec cmdexec.Executor, ct *config.Container, globalConfig *config.Global, secrets secretMap) bool {
+	loc := fmt.Sprintf("container {Group: %s Container:%s} config", ct.Info.Group, ct.Info.Container)
+	ctConfigEnvMap, ctConfigEnvOrder, err := validateConfigEnv(ct.Config.Env, globalConfig.ProcessEnvAllowlist, configsDir, loc)
+	if v.check(ref, err) {
+		return true
+	}
+	ctEnv := parentEnv.NewContainerConfigEnvManager(ctx, containerGroupBaseDir(globalConfig.BaseDir, ct.Info), containerBaseDir(globalConfig.BaseDir, ct.Info), ctConfigEnvMap, ctConfigEnvOrder)
+	if err := ct.ApplyConfigEnv(ctEnv); err != nil && v.add(ref, fmt.Errorf("failed to apply config env to %s, reason: %w", loc, err)) {
+		return true
+	}
+	if v.check(ref, ct.ApplyCmdExecutor(exec)) {
+		return true
+	}
+
+	if len(ct.Image.Image) == 0 && v.add(ref, fmt.Errorf("image cannot be empty in %s", loc)) {
+		return true
+	}
+	if ct.Image.SkipImagePull {
+		if ct.Image.IgnoreImagePullFailures && v.add(ref, fmt.Errorf("ignoreImagePullFailures cannot be true when skipImagePull is true in %s", loc)) {
+			return true
 		}
-		if ct.Lifecycle.WaitAfterStartDelay < 0 {
-			return fmt.Errorf("container wait after start delay %d cannot be negative in %s", ct.Lifecycle.WaitAfterStartDelay, loc)
+		if ct.Image.PullImageBeforeStop && v.add(ref, fmt.Errorf("pullImageBeforeStop cannot be true when skipImagePull is true in %s", loc)) {
+			return true
 		}
+	}
 
-		if len(ct.User.PrimaryGroup) > 0 && len(ct.User.User) == 0 {
-			return fmt.Errorf("container user primary group cannot be set without setting the user in %s", loc)
-		}
+	if v.check(ref, validateLabelsConfig(ct.Metadata.Labels, loc)) {
+		return true
+	}
 
-		if err := validateDevicesConfig(ct.Filesystem.Devices.Static, loc); err != nil {
-			return err
+	if ct.Lifecycle.Order <= 0 && v.add(ref, fmt.Errorf("container order %d cannot be non-positive in %s", ct.Lifecycle.Order, loc)) {
+		return true
+	}
+	if v.check(ref, validateContainerRestartPolicy(&ct.Lifecycle.RestartPolicy, loc)) {
+		return true
+	}
+	if ct.Lifecycle.StopTimeout < 0 && v.add(ref, fmt.Errorf("container stop timeout %d cannot be negative in %s", ct.Lifecycle.StopTimeout, loc)) {
+		return true
+	}
+	if ct.Lifecycle.PurgeGracePeriod < 0 && v.add(ref, fmt.Errorf("container purge grace period %d cannot be negative in %s", ct.Lifecycle.PurgeGracePeriod, loc)) {
+		return true
+	}
+	if ct.Lifecycle.WaitAfterStartDelay < 0 && v.add(ref, fmt.Errorf("container wait after start delay %d cannot be negative in %s", ct.Lifecycle.WaitAfterStartDelay, loc)) {
+		return true
+	}
+	if ct.Lifecycle.WaitForHealthyTimeout < 0 && v.add(ref, fmt.Errorf("container wait for healthy timeout %d cannot be negative in %s", ct.Lifecycle.WaitForHealthyTimeout, loc)) {
+		return true
+	}
+	if ct.Lifecycle.WaitForHealthyTimeout > 0 && !ct.Lifecycle.WaitForHealthy && v.add(ref, fmt.Errorf("container wait for healthy timeout cannot be set without setting waitForHealthy in %s", loc)) {
+		return true
+	}
+	if v.check(ref, validateContainerDependsOn(ct.Info, ct.Lifecycle.DependsOn, loc)) {
+		return true
+	}
+
+	if len(ct.User.PrimaryGroup) > 0 && len(ct.User.User) == 0 && v.add(ref, fmt.Errorf("container user primary group cannot be set without setting the user in %s", loc)) {
+		return true
+	}
+
+	if v.check(ref, validateDevicesConfig(ct.Filesystem.Devices.Static, loc)) ||
+		v.check(ref, validateMountsConfig(ct.Filesystem.Mounts, globalConfig.Container.Mounts, globalConfig.MountDefs, fmt.Sprintf("%s mounts", loc))) ||
+		v.check(ref, validatePublishedPortsConfig(ct.Network.PublishedPorts, loc)) ||
+		v.check(ref, validateSysctlsConfig(ct.Security.Sysctls, loc)) ||
+		v.check(ref, validateNamespaceModes(&ct.Security, loc)) ||
+		v.check(ref, validateHealthConfig(&ct.Health, loc)) ||
+		v.check(ref, validateContainerSecretsConfig(ct.Secrets, secrets, loc)) {
+		return true
+	}
+
+	if len(ct.Runtime.ShmSize) > 0 {
+		if _, err := units.RAMInBytes(ct.Runtime.ShmSize); err != nil && v.add(ref, fmt.Errorf("invalid shmSize %s in %s, reason: %w", ct.Runtime.ShmSize, loc, err)) {
+			return true
 		}
+	}
+	if v.check(ref, validateContainerEnv(ct.Runtime.Env, loc)) ||
+		v.check(ref, validateUlimitsConfig(ct.Runtime.Ulimits, loc)) {
+		return true
+	}
+	if (ct.Runtime.OOMScoreAdj < -1000 || ct.Runtime.OOMScoreAdj > 1000) && v.add(ref, fmt.Errorf("oom score adj %d must be between -1000 and 1000 in %s", ct.Runtime.OOMScoreAdj, loc)) {
+		return true
+	}
 
-		if err := validateMountsConfig(ct.Filesystem.Mounts, globalConfig.Container.Mounts, globalConfig.MountDefs, fmt.Sprintf("%s mounts", loc)); err != nil {
-			return err
+	return v.check(ref, validateResourcesConfig(effectiveResources(&ct.Resources, &globalConfig.Container.Resources), loc)) ||
+		v.check(ref, validateLoggingConfig(&ct.Logging, loc))
+}
+
+func validateContainerDependsOn(ref config.ContainerReference, dependsOn []config.ContainerReference, location string) error {
+	deps := make(map[config.ContainerReference]bool)
+	for _, dep := range dependsOn {
+		if err := validateContainerReference(&dep); err != nil {
+			return fmt.Errorf("dependency has invalid container reference in %s, reason: %w", location, err)
+		}
+		if dep == ref {
+			return fmt.Errorf("container cannot depend on itself in %s", location)
 		}
+		if deps[dep] {
+			return fmt.Errorf("dependency {Group:%s Container:%s} specified more than once in %s", dep.Group, dep.Container, location)
+		}
+		deps[dep] = true
+	}
+	return nil
+}
 
-		if err := validatePublishedPortsConfig(ct.Network.PublishedPorts, loc); err != nil {
-			return err
+// validateContainerDependencies resolves the dependencies of all the
+// containers, ensuring that every dependency refers to a container in
+// the deployment and that there are no dependency cycles.
+func validateContainerDependencies(v *errorCollector, groups ContainerGroupMap) {
+	all := make(containerMap)
+	for _, g := range groups {
+		for ref, ct := range g.containers {
+			all[ref] = ct
 		}
+	}
 
-		if err := validateSysctlsConfig(ct.Security.Sysctls, loc); err != nil {
-			return err
+	containers := make(ContainerList, 0, len(all))
+	for _, ct := range all {
+		containers = append(containers, ct)
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].Name() < containers[j].Name()
+	})
+
+	for _, ct := range containers {
+		ct.dependencies = nil
+		for _, dep := range ct.config.Lifecycle.DependsOn {
+			d, found := all[dep]
+			if !found {
+				if v.add(config.ContainerElementRef(ct.config.Info), fmt.Errorf("dependency {Group:%s Container:%s} not found in container {Group: %s Container:%s} config", dep.Group, dep.Container, ct.config.Info.Group, ct.config.Info.Container)) {
+					return
+				}
+				continue
+			}
+			ct.dependencies = append(ct.dependencies, d)
 		}
+	}
 
-		if err := validateHealthConfig(&ct.Health, loc); err != nil {
-			return err
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*Container]int)
+	var path []*Container
+	var visit func(ct *Container) error
+	visit = func(ct *Container) error {
+		switch state[ct] {
+		case visited:
+			return nil
+		case visiting:
+			var sb strings.Builder
+			for i := slices.Index(path, ct); i < len(path); i++ {
+				fmt.Fprintf(&sb, "%s -> ", path[i].Name())
+			}
+			sb.WriteString(ct.Name())
+			return fmt.Errorf("container dependency cycle detected: %s", sb.String())
 		}
 
-		if len(ct.Runtime.ShmSize) > 0 {
-			if _, err := units.RAMInBytes(ct.Runtime.ShmSize); err != nil {
-				return fmt.Errorf("invalid shmSize %s in %s, reason: %w", ct.Runtime.ShmSize, loc, err)
+		state[ct] = visiting
+		path = append(path, ct)
+		for _, d := range ct.dependencies {
+			if err := visit(d); err != nil {
+				return err
 			}
 		}
-		if err := validateContainerEnv(ct.Runtime.Env, loc); err != nil {
-			return err
+		path = path[:len(path)-1]
+		state[ct] = visited
+		return nil
+	}
+	// Only the first dependency cycle is reported since the containers
+	// in the cycle are left in an intermediate state.
+	for _, ct := range containers {
+		if err := visit(ct); err != nil {
+			v.add(config.ContainerElementRef(ct.config.Info), err)
+			return
 		}
-
-		g.addContainer(&ct, globalConfig, containerEndpoints[ct.Info], allowedContainers[ct.Info])
-		// This is needed to store the updated container config after
-		// ApplyConfigEnv().
-		containersConfig[i] = ct
 	}
-
-	return nil
 }
 
 func validateContainerReference(ref *config.ContainerReference) error {
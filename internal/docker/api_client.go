@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 
+	dtypes "github.com/docker/docker/api/types"
 	dcontainer "github.com/docker/docker/api/types/container"
 	dimage "github.com/docker/docker/api/types/image"
 	dnetwork "github.com/docker/docker/api/types/network"
@@ -15,11 +16,19 @@ type APIClient interface {
 	Close() error
 
 	ContainerCreate(ctx context.Context, config *dcontainer.Config, hostConfig *dcontainer.HostConfig, networkingConfig *dnetwork.NetworkingConfig, platform *ocispec.Platform, containerName string) (dcontainer.CreateResponse, error)
+	ContainerExecAttach(ctx context.Context, execID string, options dcontainer.ExecAttachOptions) (dtypes.HijackedResponse, error)
+	ContainerExecCreate(ctx context.Context, containerName string, options dcontainer.ExecOptions) (dcontainer.ExecCreateResponse, error)
+	ContainerExecInspect(ctx context.Context, execID string) (dcontainer.ExecInspect, error)
 	ContainerInspect(ctx context.Context, containerName string) (dcontainer.InspectResponse, error)
 	ContainerKill(ctx context.Context, containerName, signal string) error
+	ContainerList(ctx context.Context, options dcontainer.ListOptions) ([]dcontainer.Summary, error)
+	ContainerLogs(ctx context.Context, containerName string, options dcontainer.LogsOptions) (io.ReadCloser, error)
+	ContainerPause(ctx context.Context, containerName string) error
+	ContainerRestart(ctx context.Context, containerName string, options dcontainer.StopOptions) error
 	ContainerRemove(ctx context.Context, containerName string, options dcontainer.RemoveOptions) error
 	ContainerStart(ctx context.Context, containerName string, options dcontainer.StartOptions) error
 	ContainerStop(ctx context.Context, containerName string, options dcontainer.StopOptions) error
+	ContainerUnpause(ctx context.Context, containerName string) error
 
 	ImageList(ctx context.Context, options dimage.ListOptions) ([]dimage.Summary, error)
 	ImagePull(ctx context.Context, refStr string, options dimage.PullOptions) (io.ReadCloser, error)
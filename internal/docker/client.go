@@ -4,9 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	cerrdefs "github.com/containerd/errdefs"
 	dcontainer "github.com/docker/docker/api/types/container"
@@ -20,29 +21,30 @@ import (
 	"golang.org/x/sys/unix"
 
 	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/moby/term"
 )
 
 const (
-	defaultContainerPurgeKillAttempts = 250
+	defaultContainerPurgeGracePeriod = 5 * time.Second
 )
 
 type Client struct {
-	client                     APIClient
-	platform                   string
-	ociPlatform                ocispec.Platform
-	containerPurgeKillAttempts uint32
-	debug                      bool
+	client                    APIClient
+	platform                  string
+	ociPlatform               ocispec.Platform
+	containerPurgeGracePeriod time.Duration
+	debug                     bool
 }
 
 func NewClient(ctx context.Context) *Client {
 	h := host.MustHostInfo(ctx)
 	return &Client{
-		client:                     MustAPIClient(ctx),
-		platform:                   h.DockerPlatform,
-		ociPlatform:                ocispec.Platform{Architecture: h.Arch},
-		containerPurgeKillAttempts: evalContainerPurgeKillAttempts(ctx),
-		debug:                      dockerDebugFromInspect(ctx),
+		client:                    MustAPIClient(ctx),
+		platform:                  h.DockerPlatform,
+		ociPlatform:               ocispec.Platform{Architecture: h.Arch},
+		containerPurgeGracePeriod: evalContainerPurgeGracePeriod(ctx),
+		debug:                     dockerDebugFromInspect(ctx),
 	}
 }
 
@@ -67,8 +69,15 @@ func (d *Client) PullImage(ctx context.Context, imageName string) error {
 		} else {
 			log(ctx).Debugf("Pulling image: %s", imageName)
 		}
-		termFd, isTerm := term.GetFdInfo(os.Stdout)
-		err = jsonmessage.DisplayJSONMessagesStream(progress, os.Stdout, termFd, isTerm, nil)
+		if pullProgressDisabled(ctx) {
+			// The progress of concurrent image pulls cannot be rendered
+			// on the same terminal without garbling each other.
+			_, err = io.Copy(io.Discard, progress)
+		} else {
+			w := pullProgressWriter(ctx)
+			termFd, isTerm := term.GetFdInfo(w)
+			err = jsonmessage.DisplayJSONMessagesStream(progress, w, termFd, isTerm, nil)
+		}
 	} else {
 		_, err = io.Copy(io.Discard, progress)
 	}
@@ -151,9 +160,9 @@ func (d *Client) StartContainer(ctx context.Context, containerName string) error
 	return nil
 }
 
-func (d *Client) StopContainer(ctx context.Context, containerName string) error {
+func (d *Client) StopContainer(ctx context.Context, containerName, signal string, timeout *int) error {
 	log(ctx).Debugf("Stopping container %s ...", containerName)
-	err := d.client.ContainerStop(ctx, containerName, dcontainer.StopOptions{})
+	err := d.client.ContainerStop(ctx, containerName, dcontainer.StopOptions{Signal: signal, Timeout: timeout})
 	if err != nil {
 		log(ctx).Debugf("err: %s", reflect.TypeOf(err))
 		return fmt.Errorf("failed to stop the container, reason: %w", err)
@@ -163,6 +172,42 @@ func (d *Client) StopContainer(ctx context.Context, containerName string) error
 	return nil
 }
 
+func (d *Client) RestartContainer(ctx context.Context, containerName, signal string, timeout *int) error {
+	log(ctx).Debugf("Restarting container %s ...", containerName)
+	err := d.client.ContainerRestart(ctx, containerName, dcontainer.StopOptions{Signal: signal, Timeout: timeout})
+	if err != nil {
+		log(ctx).Debugf("err: %s", reflect.TypeOf(err))
+		return fmt.Errorf("failed to restart the container, reason: %w", err)
+	}
+
+	log(ctx).Debugf("Container %s restarted successfully", containerName)
+	return nil
+}
+
+func (d *Client) PauseContainer(ctx context.Context, containerName string) error {
+	log(ctx).Debugf("Pausing container %s ...", containerName)
+	err := d.client.ContainerPause(ctx, containerName)
+	if err != nil {
+		log(ctx).Debugf("err: %s", reflect.TypeOf(err))
+		return fmt.Errorf("failed to pause the container, reason: %w", err)
+	}
+
+	log(ctx).Debugf("Container %s paused successfully", containerName)
+	return nil
+}
+
+func (d *Client) UnpauseContainer(ctx context.Context, containerName string) error {
+	log(ctx).Debugf("Unpausing container %s ...", containerName)
+	err := d.client.ContainerUnpause(ctx, containerName)
+	if err != nil {
+		log(ctx).Debugf("err: %s", reflect.TypeOf(err))
+		return fmt.Errorf("failed to unpause the container, reason: %w", err)
+	}
+
+	log(ctx).Debugf("Container %s unpaused successfully", containerName)
+	return nil
+}
+
 func (d *Client) KillContainer(ctx context.Context, containerName string) error {
 	log(ctx).Debugf("Killing container %s ...", containerName)
 	err := d.client.ContainerKill(ctx, containerName, unix.SignalName(unix.SIGKILL))
@@ -198,6 +243,136 @@ func (d *Client) GetContainerState(ctx context.Context, containerName string) (C
 	return containerStateFromString(c.State.Status), nil
 }
 
+func (d *Client) GetContainerInfo(ctx context.Context, containerName string) (*ContainerInfo, error) {
+	c, err := d.client.ContainerInspect(ctx, containerName)
+	if cerrdefs.IsNotFound(err) {
+		return &ContainerInfo{Name: containerName, State: ContainerStateNotFound}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the container info, reason: %w", err)
+	}
+	return containerInfoFromInspect(containerName, &c), nil
+}
+
+// ContainerLogs writes the logs of the container to the specified
+// writers, demultiplexing the stdout and stderr streams unless the
+// container is attached to a TTY.
+func (d *Client) ContainerLogs(ctx context.Context, containerName string, opts ContainerLogsOptions, stdout, stderr io.Writer) error {
+	c, err := d.client.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve the container info, reason: %w", err)
+	}
+
+	logs, err := d.client.ContainerLogs(ctx, containerName, dcontainer.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     opts.Follow,
+		Since:      opts.Since,
+		Tail:       opts.Tail,
+	})
+	if err != nil {
+		log(ctx).Debugf("err: %s", reflect.TypeOf(err))
+		return fmt.Errorf("failed to retrieve the container logs, reason: %w", err)
+	}
+	//nolint:errcheck
+	defer logs.Close()
+
+	if c.Config != nil && c.Config.Tty {
+		_, err = io.Copy(stdout, logs)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, logs)
+	}
+	if err != nil {
+		return fmt.Errorf("failed while reading the container logs, reason: %w", err)
+	}
+	return nil
+}
+
+// ExecInContainer runs the command inside the running container with
+// the standard streams of the command attached to the specified
+// streams, and returns the exit code of the command. The terminal
+// backing stdin (if any) is put in raw mode while the command runs when
+// a TTY is requested.
+func (d *Client) ExecInContainer(ctx context.Context, containerName string, opts ContainerExecOptions, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	fd, isTerm := term.GetFdInfo(stdin)
+	var consoleSize *[2]uint
+	if opts.Tty && isTerm {
+		if ws, err := term.GetWinsize(fd); err == nil {
+			consoleSize = &[2]uint{uint(ws.Height), uint(ws.Width)}
+		}
+	}
+
+	exec, err := d.client.ContainerExecCreate(ctx, containerName, dcontainer.ExecOptions{
+		User:         opts.User,
+		Tty:          opts.Tty,
+		ConsoleSize:  consoleSize,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          opts.Cmd,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create the exec instance, reason: %w", err)
+	}
+
+	resp, err := d.client.ContainerExecAttach(ctx, exec.ID, dcontainer.ExecAttachOptions{
+		Tty:         opts.Tty,
+		ConsoleSize: consoleSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to attach to the exec instance, reason: %w", err)
+	}
+	defer resp.Close()
+
+	if opts.Tty && isTerm {
+		state, err := term.SetRawTerminal(fd)
+		if err != nil {
+			return 0, fmt.Errorf("failed to put the terminal in raw mode, reason: %w", err)
+		}
+		//nolint:errcheck
+		defer term.RestoreTerminal(fd, state)
+	}
+
+	go func() {
+		//nolint:errcheck
+		io.Copy(resp.Conn, stdin)
+		//nolint:errcheck
+		resp.CloseWrite()
+	}()
+
+	if opts.Tty {
+		_, err = io.Copy(stdout, resp.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, resp.Reader)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed while reading the output of the exec instance, reason: %w", err)
+	}
+
+	res, err := d.client.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve the exit code of the exec instance, reason: %w", err)
+	}
+	return res.ExitCode, nil
+}
+
+func (d *Client) ListContainerNames(ctx context.Context) ([]string, error) {
+	containers, err := d.client.ContainerList(ctx, dcontainer.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the containers, reason: %w", err)
+	}
+
+	var names []string
+	for _, c := range containers {
+		for _, n := range c.Names {
+			// Docker prefixes the container names with a '/'.
+			names = append(names, strings.TrimPrefix(n, "/"))
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
 func (d *Client) CreateNetwork(ctx context.Context, networkName string, options dnetwork.CreateOptions) error {
 	log(ctx).Debugf("Creating network %s ...", networkName)
 	resp, err := d.client.NetworkCreate(ctx, networkName, options)
@@ -273,8 +448,10 @@ func (d *Client) DisconnectContainerFromNetwork(ctx context.Context, containerNa
 	return nil
 }
 
-func (d *Client) ContainerPurgeKillAttempts() uint32 {
-	return d.containerPurgeKillAttempts
+// ContainerPurgeGracePeriod returns the default grace period for the
+// containers to be purged after being stopped, before giving up.
+func (d *Client) ContainerPurgeGracePeriod() time.Duration {
+	return d.containerPurgeGracePeriod
 }
 
 func (d *Client) Close() {
@@ -287,9 +464,9 @@ func dockerDebugFromInspect(ctx context.Context) bool {
 	return lvl == inspect.HomelabInspectLevelDebug || lvl == inspect.HomelabInspectLevelTrace
 }
 
-func evalContainerPurgeKillAttempts(ctx context.Context) uint32 {
-	if delay, ok := getContainerPurgeKillAttempts(ctx); ok {
-		return delay
+func evalContainerPurgeGracePeriod(ctx context.Context) time.Duration {
+	if period, ok := getContainerPurgeGracePeriod(ctx); ok {
+		return period
 	}
-	return defaultContainerPurgeKillAttempts
+	return defaultContainerPurgeGracePeriod
 }
@@ -0,0 +1,12 @@
+package docker
+
+// ContainerExecOptions represents the options for running a command
+// inside a container.
+type ContainerExecOptions struct {
+	// Cmd is the command along with its arguments.
+	Cmd []string
+	// User is the user (and optionally the group) to run the command as.
+	User string
+	// Tty attaches a TTY to the command.
+	Tty bool
+}
@@ -0,0 +1,38 @@
+package docker
+
+const (
+	ContainerHealthNone ContainerHealth = iota
+	ContainerHealthStarting
+	ContainerHealthHealthy
+	ContainerHealthUnhealthy
+)
+
+type ContainerHealth uint8
+
+func (c ContainerHealth) String() string {
+	switch c {
+	case ContainerHealthNone:
+		return "None"
+	case ContainerHealthStarting:
+		return "Starting"
+	case ContainerHealthHealthy:
+		return "Healthy"
+	case ContainerHealthUnhealthy:
+		return "Unhealthy"
+	default:
+		panic("Invalid scenario in ContainerHealth stringer, possibly indicating a bug in the code")
+	}
+}
+
+func containerHealthFromString(health string) ContainerHealth {
+	switch health {
+	case "starting":
+		return ContainerHealthStarting
+	case "healthy":
+		return ContainerHealthHealthy
+	case "unhealthy":
+		return ContainerHealthUnhealthy
+	default:
+		return ContainerHealthNone
+	}
+}
@@ -0,0 +1,57 @@
+package docker
+
+import (
+	dcontainer "github.com/docker/docker/api/types/container"
+)
+
+// ContainerInfo represents the live information about a container
+// as reported by the docker host.
+type ContainerInfo struct {
+	Name      string
+	State     ContainerState
+	Health    ContainerHealth
+	Image     string
+	ImageID   string
+	Labels    map[string]string
+	Endpoints map[string]*ContainerEndpointInfo
+}
+
+// ContainerEndpointInfo represents the live information about a network
+// endpoint of a container as reported by the docker host.
+type ContainerEndpointInfo struct {
+	IPv4 string
+	IPv6 string
+}
+
+func containerInfoFromInspect(containerName string, c *dcontainer.InspectResponse) *ContainerInfo {
+	res := &ContainerInfo{
+		Name:      containerName,
+		State:     ContainerStateUnknown,
+		Endpoints: make(map[string]*ContainerEndpointInfo),
+	}
+	if c.ContainerJSONBase != nil {
+		res.ImageID = c.Image
+		if c.State != nil {
+			res.State = containerStateFromString(c.State.Status)
+			if c.State.Health != nil {
+				res.Health = containerHealthFromString(c.State.Health.Status)
+			}
+		}
+	}
+	if c.Config != nil {
+		res.Image = c.Config.Image
+		res.Labels = c.Config.Labels
+	}
+	if c.NetworkSettings != nil {
+		for n, e := range c.NetworkSettings.Networks {
+			if e == nil {
+				continue
+			}
+			res.Endpoints[n] = &ContainerEndpointInfo{
+				IPv4: e.IPAddress,
+				IPv6: e.GlobalIPv6Address,
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,14 @@
+package docker
+
+// ContainerLogsOptions represents the options for retrieving the logs of
+// a container.
+type ContainerLogsOptions struct {
+	// Follow keeps streaming the new logs until the container stops.
+	Follow bool
+	// Since only retrieves the logs since the specified timestamp or
+	// relative duration (e.g. 42m).
+	Since string
+	// Tail only retrieves the specified number of lines from the end of
+	// the logs, or all the logs if set to all.
+	Tail string
+}
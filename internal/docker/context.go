@@ -2,15 +2,22 @@ package docker
 
 import (
 	"context"
+	"io"
+	"os"
+	"time"
 )
 
 var (
-	dockerAPIClientKey            = ctxKeyAPIClient{}
-	containerPurgeKillAttemptsKey = ctxKeyContainerPurgeKillAttempts{}
+	dockerAPIClientKey           = ctxKeyAPIClient{}
+	containerPurgeGracePeriodKey = ctxKeyContainerPurgeGracePeriod{}
+	pullProgressDisabledKey      = ctxKeyPullProgressDisabled{}
+	pullProgressWriterKey        = ctxKeyPullProgressWriter{}
 )
 
 type ctxKeyAPIClient struct{}
-type ctxKeyContainerPurgeKillAttempts struct{}
+type ctxKeyContainerPurgeGracePeriod struct{}
+type ctxKeyPullProgressDisabled struct{}
+type ctxKeyPullProgressWriter struct{}
 
 func APIClientFromContext(ctx context.Context) (APIClient, bool) {
 	client, ok := ctx.Value(dockerAPIClientKey).(APIClient)
@@ -29,11 +36,38 @@ func WithAPIClient(ctx context.Context, client APIClient) context.Context {
 	return context.WithValue(ctx, dockerAPIClientKey, client)
 }
 
-func getContainerPurgeKillAttempts(ctx context.Context) (uint32, bool) {
-	delay, ok := ctx.Value(containerPurgeKillAttemptsKey).(uint32)
-	return delay, ok
+func getContainerPurgeGracePeriod(ctx context.Context) (time.Duration, bool) {
+	period, ok := ctx.Value(containerPurgeGracePeriodKey).(time.Duration)
+	return period, ok
 }
 
-func WithContainerPurgeKillAttempts(ctx context.Context, attempts uint32) context.Context {
-	return context.WithValue(ctx, containerPurgeKillAttemptsKey, attempts)
+// WithContainerPurgeGracePeriod returns a context that overrides the
+// default grace period for purging the containers which do not
+// configure one.
+func WithContainerPurgeGracePeriod(ctx context.Context, period time.Duration) context.Context {
+	return context.WithValue(ctx, containerPurgeGracePeriodKey, period)
+}
+
+func pullProgressDisabled(ctx context.Context) bool {
+	disabled, ok := ctx.Value(pullProgressDisabledKey).(bool)
+	return ok && disabled
+}
+
+// WithPullProgressDisabled returns a context that suppresses rendering
+// the progress of the image pulls on the terminal.
+func WithPullProgressDisabled(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pullProgressDisabledKey, true)
+}
+
+func pullProgressWriter(ctx context.Context) io.Writer {
+	if w, ok := ctx.Value(pullProgressWriterKey).(io.Writer); ok {
+		return w
+	}
+	return os.Stdout
+}
+
+// WithPullProgressWriter returns a context that renders the progress of
+// the image pulls to the specified writer instead of stdout.
+func WithPullProgressWriter(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, pullProgressWriterKey, w)
 }
@@ -0,0 +1,288 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strings"
+
+	dtypes "github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	dimage "github.com/docker/docker/api/types/image"
+	dnetwork "github.com/docker/docker/api/types/network"
+	derrdefs "github.com/docker/docker/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/tuxgal/homelab/internal/dryrun"
+)
+
+// dryRunAPIClient forwards all the read-only operations to the wrapped
+// API client while recording every mutating operation instead of
+// executing it. The outcome of the recorded operations is tracked so
+// that the subsequent reads reflect the state the docker host would
+// have been in, had the operations been executed.
+type dryRunAPIClient struct {
+	client     APIClient
+	rec        *dryrun.Recorder
+	containers map[string]*dryRunContainerInfo
+	networks   map[string]bool
+	images     map[string]bool
+}
+
+type dryRunContainerInfo struct {
+	// status is empty if the container would have been removed.
+	status string
+	// config and endpoints are only set for the containers that would
+	// have been created.
+	config    *dcontainer.Config
+	endpoints map[string]*dnetwork.EndpointSettings
+}
+
+// NewDryRunAPIClient returns an API client that records all the mutating
+// operations in the specified recorder instead of executing them against
+// the specified API client.
+func NewDryRunAPIClient(client APIClient, rec *dryrun.Recorder) APIClient {
+	return &dryRunAPIClient{
+		client:     client,
+		rec:        rec,
+		containers: make(map[string]*dryRunContainerInfo),
+		networks:   make(map[string]bool),
+		images:     make(map[string]bool),
+	}
+}
+
+func (d *dryRunAPIClient) Close() error {
+	return d.client.Close()
+}
+
+func (d *dryRunAPIClient) ContainerCreate(ctx context.Context, config *dcontainer.Config, hostConfig *dcontainer.HostConfig, networkingConfig *dnetwork.NetworkingConfig, platform *ocispec.Platform, containerName string) (dcontainer.CreateResponse, error) {
+	ct := &dryRunContainerInfo{
+		status:    "created",
+		config:    config,
+		endpoints: make(map[string]*dnetwork.EndpointSettings),
+	}
+	var networks []string
+	if networkingConfig != nil {
+		for n, e := range networkingConfig.EndpointsConfig {
+			ct.endpoints[n] = dryRunEndpointSettings(e)
+			networks = append(networks, fmt.Sprintf("%s (%s)", n, dryRunEndpointIPs(e)))
+		}
+	}
+	if len(networks) > 0 {
+		slices.Sort(networks)
+		d.rec.Record("Create container %s with image %s attached to network %s", containerName, config.Image, strings.Join(networks, ", "))
+	} else {
+		d.rec.Record("Create container %s with image %s", containerName, config.Image)
+	}
+	d.containers[containerName] = ct
+	return dcontainer.CreateResponse{ID: fmt.Sprintf("dry-run-%s", containerName)}, nil
+}
+
+func (d *dryRunAPIClient) ContainerExecAttach(ctx context.Context, execID string, options dcontainer.ExecAttachOptions) (dtypes.HijackedResponse, error) {
+	// The command is never run in dry run mode, hence the output stream
+	// ends right away.
+	client, server := net.Pipe()
+	//nolint:errcheck
+	server.Close()
+	return dtypes.NewHijackedResponse(client, ""), nil
+}
+
+func (d *dryRunAPIClient) ContainerExecCreate(ctx context.Context, containerName string, options dcontainer.ExecOptions) (dcontainer.ExecCreateResponse, error) {
+	if len(options.User) > 0 {
+		d.rec.Record("Execute command %q in container %s as user %s", strings.Join(options.Cmd, " "), containerName, options.User)
+	} else {
+		d.rec.Record("Execute command %q in container %s", strings.Join(options.Cmd, " "), containerName)
+	}
+	return dcontainer.ExecCreateResponse{ID: fmt.Sprintf("dry-run-exec-%s", containerName)}, nil
+}
+
+func (d *dryRunAPIClient) ContainerExecInspect(ctx context.Context, execID string) (dcontainer.ExecInspect, error) {
+	return dcontainer.ExecInspect{ExecID: execID}, nil
+}
+
+func (d *dryRunAPIClient) ContainerInspect(ctx context.Context, containerName string) (dcontainer.InspectResponse, error) {
+	ct, found := d.containers[containerName]
+	if !found {
+		return d.client.ContainerInspect(ctx, containerName)
+	}
+	if ct.status == "" {
+		return dcontainer.InspectResponse{}, derrdefs.NotFound(fmt.Errorf("container %s would have been removed in dry run mode", containerName))
+	}
+
+	if ct.config != nil {
+		return dcontainer.InspectResponse{
+			ContainerJSONBase: &dcontainer.ContainerJSONBase{
+				State: &dcontainer.State{Status: ct.status},
+				Image: ct.config.Image,
+				Name:  containerName,
+			},
+			Config: ct.config,
+			NetworkSettings: &dcontainer.NetworkSettings{
+				Networks: ct.endpoints,
+			},
+		}, nil
+	}
+
+	resp, err := d.client.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return resp, err
+	}
+	if resp.ContainerJSONBase != nil {
+		base := *resp.ContainerJSONBase
+		base.State = &dcontainer.State{Status: ct.status}
+		resp.ContainerJSONBase = &base
+	}
+	return resp, nil
+}
+
+func (d *dryRunAPIClient) ContainerKill(ctx context.Context, containerName, signal string) error {
+	d.rec.Record("Kill container %s", containerName)
+	d.setContainerStatus(containerName, "exited")
+	return nil
+}
+
+func (d *dryRunAPIClient) ContainerList(ctx context.Context, options dcontainer.ListOptions) ([]dcontainer.Summary, error) {
+	return d.client.ContainerList(ctx, options)
+}
+
+func (d *dryRunAPIClient) ContainerLogs(ctx context.Context, containerName string, options dcontainer.LogsOptions) (io.ReadCloser, error) {
+	return d.client.ContainerLogs(ctx, containerName, options)
+}
+
+func (d *dryRunAPIClient) ContainerPause(ctx context.Context, containerName string) error {
+	d.rec.Record("Pause container %s", containerName)
+	d.setContainerStatus(containerName, "paused")
+	return nil
+}
+
+func (d *dryRunAPIClient) ContainerRemove(ctx context.Context, containerName string, options dcontainer.RemoveOptions) error {
+	d.rec.Record("Remove container %s", containerName)
+	d.containers[containerName] = &dryRunContainerInfo{}
+	return nil
+}
+
+func (d *dryRunAPIClient) ContainerRestart(ctx context.Context, containerName string, options dcontainer.StopOptions) error {
+	d.rec.Record("Restart container %s", containerName)
+	d.setContainerStatus(containerName, "running")
+	return nil
+}
+
+func (d *dryRunAPIClient) ContainerStart(ctx context.Context, containerName string, options dcontainer.StartOptions) error {
+	d.rec.Record("Start container %s", containerName)
+	d.setContainerStatus(containerName, "running")
+	return nil
+}
+
+func (d *dryRunAPIClient) ContainerStop(ctx context.Context, containerName string, options dcontainer.StopOptions) error {
+	d.rec.Record("Stop container %s", containerName)
+	d.setContainerStatus(containerName, "exited")
+	return nil
+}
+
+func (d *dryRunAPIClient) ContainerUnpause(ctx context.Context, containerName string) error {
+	d.rec.Record("Unpause container %s", containerName)
+	d.setContainerStatus(containerName, "running")
+	return nil
+}
+
+func (d *dryRunAPIClient) ImageList(ctx context.Context, options dimage.ListOptions) ([]dimage.Summary, error) {
+	images, err := d.client.ImageList(ctx, options)
+	if err != nil || len(images) > 0 {
+		return images, err
+	}
+	// Pretend the images that would have been pulled are available locally.
+	for _, ref := range options.Filters.Get("reference") {
+		if d.images[ref] {
+			return []dimage.Summary{{ID: fmt.Sprintf("dry-run-%s", ref)}}, nil
+		}
+	}
+	return images, nil
+}
+
+func (d *dryRunAPIClient) ImagePull(ctx context.Context, refStr string, options dimage.PullOptions) (io.ReadCloser, error) {
+	d.rec.Record("Pull image %s", refStr)
+	d.images[refStr] = true
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+func (d *dryRunAPIClient) NetworkConnect(ctx context.Context, networkName, containerName string, config *dnetwork.EndpointSettings) error {
+	d.rec.Record("Connect container %s to network %s (%s)", containerName, networkName, dryRunEndpointIPs(config))
+	if ct, found := d.containers[containerName]; found && ct.endpoints != nil {
+		ct.endpoints[networkName] = dryRunEndpointSettings(config)
+	}
+	return nil
+}
+
+func (d *dryRunAPIClient) NetworkCreate(ctx context.Context, networkName string, options dnetwork.CreateOptions) (dnetwork.CreateResponse, error) {
+	d.rec.Record("Create network %s", networkName)
+	d.networks[networkName] = true
+	return dnetwork.CreateResponse{ID: fmt.Sprintf("dry-run-%s", networkName)}, nil
+}
+
+func (d *dryRunAPIClient) NetworkDisconnect(ctx context.Context, networkName, containerName string, force bool) error {
+	d.rec.Record("Disconnect container %s from network %s", containerName, networkName)
+	if ct, found := d.containers[containerName]; found && ct.endpoints != nil {
+		delete(ct.endpoints, networkName)
+	}
+	return nil
+}
+
+func (d *dryRunAPIClient) NetworkList(ctx context.Context, options dnetwork.ListOptions) ([]dnetwork.Summary, error) {
+	networks, err := d.client.NetworkList(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	names := options.Filters.Get("name")
+	var res []dnetwork.Summary
+	for _, n := range networks {
+		if exists, found := d.networks[n.Name]; found && !exists {
+			continue
+		}
+		res = append(res, n)
+	}
+	for _, name := range names {
+		listed := slices.ContainsFunc(res, func(n dnetwork.Summary) bool { return n.Name == name })
+		if d.networks[name] && !listed {
+			res = append(res, dnetwork.Summary{Name: name, ID: fmt.Sprintf("dry-run-%s", name)})
+		}
+	}
+	return res, nil
+}
+
+func (d *dryRunAPIClient) NetworkRemove(ctx context.Context, networkName string) error {
+	d.rec.Record("Remove network %s", networkName)
+	d.networks[networkName] = false
+	return nil
+}
+
+func (d *dryRunAPIClient) setContainerStatus(containerName, status string) {
+	if ct, found := d.containers[containerName]; found {
+		if ct.status != "" {
+			ct.status = status
+		}
+		return
+	}
+	d.containers[containerName] = &dryRunContainerInfo{status: status}
+}
+
+func dryRunEndpointSettings(config *dnetwork.EndpointSettings) *dnetwork.EndpointSettings {
+	es := &dnetwork.EndpointSettings{}
+	if config != nil && config.IPAMConfig != nil {
+		es.IPAddress = config.IPAMConfig.IPv4Address
+		es.GlobalIPv6Address = config.IPAMConfig.IPv6Address
+	}
+	return es
+}
+
+func dryRunEndpointIPs(config *dnetwork.EndpointSettings) string {
+	es := dryRunEndpointSettings(config)
+	if es.GlobalIPv6Address != "" {
+		return fmt.Sprintf("IPv4 %s, IPv6 %s", es.IPAddress, es.GlobalIPv6Address)
+	}
+	if es.IPAddress != "" {
+		return fmt.Sprintf("IPv4 %s", es.IPAddress)
+	}
+	return "dynamic IP"
+}
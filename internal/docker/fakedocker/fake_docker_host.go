@@ -1,20 +1,27 @@
 package fakedocker
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"fmt"
 	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/sasha-s/go-deadlock"
 	"github.com/tuxgal/homelab/internal/docker"
 	"github.com/tuxgal/homelab/internal/utils"
 
+	dtypes "github.com/docker/docker/api/types"
 	dcontainer "github.com/docker/docker/api/types/container"
 	dimage "github.com/docker/docker/api/types/image"
 	dnetwork "github.com/docker/docker/api/types/network"
 	derrdefs "github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -23,13 +30,22 @@ type FakeDockerHost struct {
 	containers           fakeContainerMap
 	networks             fakeNetworkMap
 	images               fakeImageMap
+	execs                fakeExecMap
+	lastStopOptions      map[string]*dcontainer.StopOptions
 	warnContainerCreate  utils.StringSet
 	failContainerCreate  utils.StringSet
+	failContainerExec    utils.StringSet
 	failContainerInspect utils.StringSet
 	failContainerKill    utils.StringSet
+	failContainerLogs    utils.StringSet
+	failContainerPause   utils.StringSet
 	failContainerRemove  utils.StringSet
+	failContainerRestart utils.StringSet
 	failContainerStart   utils.StringSet
 	failContainerStop    utils.StringSet
+	failContainerUnpause utils.StringSet
+	unhealthyContainers  utils.StringSet
+	startingContainers   utils.StringSet
 	validImagesForPull   utils.StringSet
 	failImagePull        utils.StringSet
 	noImageAfterPull     utils.StringSet
@@ -43,12 +59,26 @@ type fakeContainerInfo struct {
 	name                 string
 	id                   string
 	state                docker.ContainerState
+	health               string
 	containerStopIssued  bool
+	stopOptions          *dcontainer.StopOptions
+	restartOptions       *dcontainer.StopOptions
 	pendingRequiredStops int
 	pendingRequiredKills int
+	logs                 []string
+	execExitCode         int
 	containerConfig      *dcontainer.Config
 	hostConfig           *dcontainer.HostConfig
 	networkConfig        *dnetwork.NetworkingConfig
+	endpoints            map[string]*dnetwork.EndpointSettings
+}
+
+type fakeExecInfo struct {
+	id            string
+	containerID   string
+	containerName string
+	options       dcontainer.ExecOptions
+	exitCode      int
 }
 
 type fakeNetworkInfo struct {
@@ -65,9 +95,13 @@ type fakeImageInfo struct {
 type FakeContainerInitInfo struct {
 	Name               string
 	Image              string
+	Labels             map[string]string
 	State              docker.ContainerState
 	RequiredExtraStops int
 	RequiredExtraKills int
+	Logs               []string
+	Tty                bool
+	ExecExitCode       int
 }
 
 type FakeNetworkInitInfo struct {
@@ -77,6 +111,7 @@ type FakeNetworkInitInfo struct {
 type fakeContainerMap map[string]*fakeContainerInfo
 type fakeNetworkMap map[string]*fakeNetworkInfo
 type fakeImageMap map[string]*fakeImageInfo
+type fakeExecMap map[string]*fakeExecInfo
 
 type FakeDockerHostInitInfo struct {
 	Containers           []*FakeContainerInitInfo
@@ -84,11 +119,18 @@ type FakeDockerHostInitInfo struct {
 	ExistingImages       utils.StringSet
 	WarnContainerCreate  utils.StringSet
 	FailContainerCreate  utils.StringSet
+	FailContainerExec    utils.StringSet
 	FailContainerInspect utils.StringSet
 	FailContainerKill    utils.StringSet
+	FailContainerLogs    utils.StringSet
+	FailContainerPause   utils.StringSet
 	FailContainerRemove  utils.StringSet
+	FailContainerRestart utils.StringSet
 	FailContainerStart   utils.StringSet
 	FailContainerStop    utils.StringSet
+	FailContainerUnpause utils.StringSet
+	UnhealthyContainers  utils.StringSet
+	StartingContainers   utils.StringSet
 	ValidImagesForPull   utils.StringSet
 	FailImagePull        utils.StringSet
 	NoImageAfterPull     utils.StringSet
@@ -120,13 +162,22 @@ func NewFakeDockerHost(initInfo *FakeDockerHostInitInfo) *FakeDockerHost {
 		containers:           fakeContainerMap{},
 		networks:             fakeNetworkMap{},
 		images:               fakeImageMap{},
+		execs:                fakeExecMap{},
+		lastStopOptions:      map[string]*dcontainer.StopOptions{},
 		warnContainerCreate:  utils.StringSet{},
 		failContainerCreate:  utils.StringSet{},
+		failContainerExec:    utils.StringSet{},
 		failContainerInspect: utils.StringSet{},
 		failContainerKill:    utils.StringSet{},
+		failContainerLogs:    utils.StringSet{},
+		failContainerPause:   utils.StringSet{},
 		failContainerRemove:  utils.StringSet{},
+		failContainerRestart: utils.StringSet{},
 		failContainerStart:   utils.StringSet{},
 		failContainerStop:    utils.StringSet{},
+		failContainerUnpause: utils.StringSet{},
+		unhealthyContainers:  utils.StringSet{},
+		startingContainers:   utils.StringSet{},
 		validImagesForPull:   utils.StringSet{},
 		failImagePull:        utils.StringSet{},
 		noImageAfterPull:     utils.StringSet{},
@@ -142,12 +193,14 @@ func NewFakeDockerHost(initInfo *FakeDockerHostInitInfo) *FakeDockerHost {
 	for _, ct := range initInfo.Containers {
 		ctInfo := newFakeContainerInfo(
 			ct.Name,
-			&dcontainer.Config{Image: ct.Image},
+			&dcontainer.Config{Image: ct.Image, Labels: ct.Labels, Tty: ct.Tty},
 			&dcontainer.HostConfig{},
 			&dnetwork.NetworkingConfig{})
 		ctInfo.state = ct.State
 		ctInfo.pendingRequiredStops = ct.RequiredExtraStops
 		ctInfo.pendingRequiredKills = ct.RequiredExtraKills
+		ctInfo.logs = ct.Logs
+		ctInfo.execExitCode = ct.ExecExitCode
 		f.containers[ct.Name] = ctInfo
 	}
 	for _, n := range initInfo.Networks {
@@ -162,21 +215,42 @@ func NewFakeDockerHost(initInfo *FakeDockerHostInitInfo) *FakeDockerHost {
 	for c := range initInfo.FailContainerCreate {
 		f.failContainerCreate[c] = struct{}{}
 	}
+	for c := range initInfo.FailContainerExec {
+		f.failContainerExec[c] = struct{}{}
+	}
 	for c := range initInfo.FailContainerInspect {
 		f.failContainerInspect[c] = struct{}{}
 	}
 	for c := range initInfo.FailContainerKill {
 		f.failContainerKill[c] = struct{}{}
 	}
+	for c := range initInfo.FailContainerLogs {
+		f.failContainerLogs[c] = struct{}{}
+	}
+	for c := range initInfo.FailContainerPause {
+		f.failContainerPause[c] = struct{}{}
+	}
 	for c := range initInfo.FailContainerRemove {
 		f.failContainerRemove[c] = struct{}{}
 	}
+	for c := range initInfo.FailContainerRestart {
+		f.failContainerRestart[c] = struct{}{}
+	}
 	for c := range initInfo.FailContainerStart {
 		f.failContainerStart[c] = struct{}{}
 	}
 	for c := range initInfo.FailContainerStop {
 		f.failContainerStop[c] = struct{}{}
 	}
+	for c := range initInfo.FailContainerUnpause {
+		f.failContainerUnpause[c] = struct{}{}
+	}
+	for c := range initInfo.UnhealthyContainers {
+		f.unhealthyContainers[c] = struct{}{}
+	}
+	for c := range initInfo.StartingContainers {
+		f.startingContainers[c] = struct{}{}
+	}
 	for i := range initInfo.ValidImagesForPull {
 		f.validImagesForPull[i] = struct{}{}
 	}
@@ -202,6 +276,12 @@ func NewFakeDockerHost(initInfo *FakeDockerHostInitInfo) *FakeDockerHost {
 }
 
 func newFakeContainerInfo(containerName string, cConfig *dcontainer.Config, hConfig *dcontainer.HostConfig, nConfig *dnetwork.NetworkingConfig) *fakeContainerInfo {
+	endpoints := make(map[string]*dnetwork.EndpointSettings)
+	if nConfig != nil {
+		for n, e := range nConfig.EndpointsConfig {
+			endpoints[n] = fakeEndpointSettings(e)
+		}
+	}
 	return &fakeContainerInfo{
 		name:                containerName,
 		id:                  randomSHA256ID(),
@@ -210,6 +290,7 @@ func newFakeContainerInfo(containerName string, cConfig *dcontainer.Config, hCon
 		containerConfig:     cConfig,
 		hostConfig:          hConfig,
 		networkConfig:       nConfig,
+		endpoints:           endpoints,
 	}
 }
 
@@ -267,6 +348,82 @@ func (f *FakeDockerHost) ContainerCreate(ctx context.Context, cConfig *dcontaine
 	return resp, nil
 }
 
+func (f *FakeDockerHost) ContainerExecAttach(ctx context.Context, execID string, options dcontainer.ExecAttachOptions) (dtypes.HijackedResponse, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	exec, found := f.execs[execID]
+	if !found {
+		return dtypes.HijackedResponse{}, derrdefs.NotFound(fmt.Errorf("exec instance %s not found on the fake docker host", execID))
+	}
+
+	// The fake command only writes a line describing itself to its
+	// output, which is multiplexed over a single stream unless attached
+	// to a TTY just like the real docker host.
+	buf := new(bytes.Buffer)
+	w := io.Writer(buf)
+	if !options.Tty {
+		w = stdcopy.NewStdWriter(buf, stdcopy.Stdout)
+	}
+	user := exec.options.User
+	if len(user) == 0 {
+		user = "root"
+	}
+	fmt.Fprintf(w, "Executed %q as user %s in container %s (tty: %t)\n", strings.Join(exec.options.Cmd, " "), user, exec.containerName, options.Tty)
+
+	client, server := net.Pipe()
+	go func() {
+		//nolint:errcheck
+		server.Write(buf.Bytes())
+		//nolint:errcheck
+		server.Close()
+	}()
+	return dtypes.NewHijackedResponse(client, ""), nil
+}
+
+func (f *FakeDockerHost) ContainerExecCreate(ctx context.Context, containerName string, options dcontainer.ExecOptions) (dcontainer.ExecCreateResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ct, found := f.containers[containerName]
+	if !found {
+		return dcontainer.ExecCreateResponse{}, derrdefs.NotFound(fmt.Errorf("container %s not found on the fake docker host", containerName))
+	}
+
+	if _, found := f.failContainerExec[containerName]; found {
+		return dcontainer.ExecCreateResponse{}, fmt.Errorf("failed to create exec instance in container %s on the fake docker host", containerName)
+	}
+
+	if ct.state != docker.ContainerStateRunning {
+		return dcontainer.ExecCreateResponse{}, derrdefs.Conflict(fmt.Errorf("container %s is not running on the fake docker host", containerName))
+	}
+
+	exec := &fakeExecInfo{
+		id:            randomSHA256ID(),
+		containerID:   ct.id,
+		containerName: containerName,
+		options:       options,
+		exitCode:      ct.execExitCode,
+	}
+	f.execs[exec.id] = exec
+	return dcontainer.ExecCreateResponse{ID: exec.id}, nil
+}
+
+func (f *FakeDockerHost) ContainerExecInspect(ctx context.Context, execID string) (dcontainer.ExecInspect, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	exec, found := f.execs[execID]
+	if !found {
+		return dcontainer.ExecInspect{}, derrdefs.NotFound(fmt.Errorf("exec instance %s not found on the fake docker host", execID))
+	}
+	return dcontainer.ExecInspect{
+		ExecID:      exec.id,
+		ContainerID: exec.containerID,
+		ExitCode:    exec.exitCode,
+	}, nil
+}
+
 func (f *FakeDockerHost) ContainerInspect(ctx context.Context, containerName string) (dcontainer.InspectResponse, error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
@@ -283,10 +440,14 @@ func (f *FakeDockerHost) ContainerInspect(ctx context.Context, containerName str
 	return dcontainer.InspectResponse{
 		ContainerJSONBase: &dcontainer.ContainerJSONBase{
 			ID:    ct.id,
-			State: fakeDockerContainerState(ct.state),
+			State: fakeDockerContainerState(ct.state, ct.health),
 			Image: ct.containerConfig.Image,
 			Name:  ct.name,
 		},
+		Config: ct.containerConfig,
+		NetworkSettings: &dcontainer.NetworkSettings{
+			Networks: ct.endpoints,
+		},
 	}, nil
 }
 
@@ -322,6 +483,87 @@ func (f *FakeDockerHost) ContainerKill(ctx context.Context, containerName, signa
 	}
 }
 
+func (f *FakeDockerHost) ContainerList(ctx context.Context, options dcontainer.ListOptions) ([]dcontainer.Summary, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if !options.All {
+		return nil, fmt.Errorf("listing only running containers on the fake docker host is unsupported")
+	}
+	if options.Filters.Len() != 0 {
+		return nil, fmt.Errorf("filters are unsupported while listing containers on the fake docker host")
+	}
+
+	names := make([]string, 0, len(f.containers))
+	for n := range f.containers {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+
+	res := make([]dcontainer.Summary, 0, len(names))
+	for _, n := range names {
+		ct := f.containers[n]
+		res = append(res, dcontainer.Summary{
+			ID:     ct.id,
+			Names:  []string{fmt.Sprintf("/%s", ct.name)},
+			Image:  ct.containerConfig.Image,
+			Labels: ct.containerConfig.Labels,
+			State:  fakeDockerContainerState(ct.state, ct.health).Status,
+		})
+	}
+	return res, nil
+}
+
+func (f *FakeDockerHost) ContainerLogs(ctx context.Context, containerName string, options dcontainer.LogsOptions) (io.ReadCloser, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	ct, found := f.containers[containerName]
+	if !found {
+		return nil, derrdefs.NotFound(fmt.Errorf("container %s not found on the fake docker host", containerName))
+	}
+
+	if _, found := f.failContainerLogs[containerName]; found {
+		return nil, fmt.Errorf("failed to retrieve logs of container %s on the fake docker host", containerName)
+	}
+
+	lines := ct.logs
+	if tail, err := strconv.Atoi(options.Tail); err == nil && tail < len(lines) {
+		lines = lines[len(lines)-tail:]
+	}
+
+	// Logs of the containers not attached to a TTY are multiplexed over
+	// a single stream, just like the real docker host.
+	buf := new(bytes.Buffer)
+	w := io.Writer(buf)
+	if !ct.containerConfig.Tty {
+		w = stdcopy.NewStdWriter(buf, stdcopy.Stdout)
+	}
+	for _, l := range lines {
+		fmt.Fprintln(w, l)
+	}
+	return io.NopCloser(buf), nil
+}
+
+func (f *FakeDockerHost) ContainerPause(ctx context.Context, containerName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ct, found := f.containers[containerName]
+	if !found {
+		return derrdefs.NotFound(fmt.Errorf("container %s not found on the fake docker host", containerName))
+	}
+	if ct.state != docker.ContainerStateRunning {
+		return fmt.Errorf("container in state %s on the fake docker host cannot be paused", ct.state)
+	}
+	if _, found := f.failContainerPause[containerName]; found {
+		return fmt.Errorf("failed to pause container %s on the fake docker host", containerName)
+	}
+
+	ct.state = docker.ContainerStatePaused
+	return nil
+}
+
 func (f *FakeDockerHost) ContainerRemove(ctx context.Context, containerName string, options dcontainer.RemoveOptions) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -350,6 +592,24 @@ func (f *FakeDockerHost) ContainerRemove(ctx context.Context, containerName stri
 	}
 }
 
+func (f *FakeDockerHost) ContainerRestart(ctx context.Context, containerName string, options dcontainer.StopOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ct, found := f.containers[containerName]
+	if !found {
+		return derrdefs.NotFound(fmt.Errorf("container %s not found on the fake docker host", containerName))
+	}
+
+	if _, found := f.failContainerRestart[containerName]; found {
+		return fmt.Errorf("failed to restart container %s on the fake docker host", containerName)
+	}
+
+	ct.restartOptions = &options
+	ct.state = docker.ContainerStateRunning
+	return nil
+}
+
 func (f *FakeDockerHost) ContainerStart(ctx context.Context, containerName string, options dcontainer.StartOptions) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -368,6 +628,16 @@ func (f *FakeDockerHost) ContainerStart(ctx context.Context, containerName strin
 	}
 
 	ct.state = docker.ContainerStateRunning
+	// Containers with a health check are reported as healthy right after
+	// the start unless requested otherwise.
+	if hc := ct.containerConfig.Healthcheck; hc != nil && len(hc.Test) > 0 {
+		ct.health = "healthy"
+		if _, found := f.unhealthyContainers[containerName]; found {
+			ct.health = "unhealthy"
+		} else if _, found := f.startingContainers[containerName]; found {
+			ct.health = "starting"
+		}
+	}
 	return nil
 }
 
@@ -380,6 +650,8 @@ func (f *FakeDockerHost) ContainerStop(ctx context.Context, containerName string
 		return derrdefs.NotFound(fmt.Errorf("container %s not found on the fake docker host", containerName))
 	}
 	ct.containerStopIssued = true
+	ct.stopOptions = &options
+	f.lastStopOptions[containerName] = &options
 
 	switch ct.state {
 	case docker.ContainerStateRunning, docker.ContainerStatePaused, docker.ContainerStateRestarting:
@@ -407,6 +679,25 @@ func (f *FakeDockerHost) ContainerStop(ctx context.Context, containerName string
 	}
 }
 
+func (f *FakeDockerHost) ContainerUnpause(ctx context.Context, containerName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ct, found := f.containers[containerName]
+	if !found {
+		return derrdefs.NotFound(fmt.Errorf("container %s not found on the fake docker host", containerName))
+	}
+	if ct.state != docker.ContainerStatePaused {
+		return fmt.Errorf("container in state %s on the fake docker host cannot be unpaused", ct.state)
+	}
+	if _, found := f.failContainerUnpause[containerName]; found {
+		return fmt.Errorf("failed to unpause container %s on the fake docker host", containerName)
+	}
+
+	ct.state = docker.ContainerStateRunning
+	return nil
+}
+
 func (f *FakeDockerHost) ImageList(ctx context.Context, options dimage.ListOptions) ([]dimage.Summary, error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
@@ -449,7 +740,14 @@ func (f *FakeDockerHost) ImagePull(ctx context.Context, imageName string, option
 		return nil, fmt.Errorf("image %s not found or invalid and cannot be pulled by the fake docker host", imageName)
 	}
 
+	// The fake pull reports its progress using a single status message
+	// just like the real docker host streams the JSON messages.
+	var progress io.Reader
 	return io.NopCloser(wrappedReader(func(p []byte) (int, error) {
+		if progress != nil {
+			return progress.Read(p)
+		}
+
 		f.mu.Lock()
 		defer f.mu.Unlock()
 
@@ -460,7 +758,8 @@ func (f *FakeDockerHost) ImagePull(ctx context.Context, imageName string, option
 		if _, found := f.noImageAfterPull[imageName]; !found {
 			f.images[imageName] = newFakeImageInfo(imageName)
 		}
-		return 0, io.EOF
+		progress = strings.NewReader(fmt.Sprintf("{\"status\":\"Pulled image %s on the fake docker host\"}\n", imageName))
+		return progress.Read(p)
 	})), nil
 }
 
@@ -478,6 +777,9 @@ func (f *FakeDockerHost) NetworkConnect(ctx context.Context, networkName, contai
 
 	// TODO: Perform more validations of the network endpoint within
 	// the network.
+	if ct, found := f.containers[containerName]; found {
+		ct.endpoints[networkName] = fakeEndpointSettings(config)
+	}
 	return nil
 }
 
@@ -584,8 +886,11 @@ func (f *FakeDockerHost) ContainerStopIssued(containerName string) bool {
 	return false
 }
 
-func fakeDockerContainerState(state docker.ContainerState) *dcontainer.State {
+func fakeDockerContainerState(state docker.ContainerState, health string) *dcontainer.State {
 	st := &dcontainer.State{}
+	if health != "" {
+		st.Health = &dcontainer.Health{Status: health}
+	}
 	switch state {
 	case docker.ContainerStateCreated:
 		st.Status = "created"
@@ -616,6 +921,15 @@ func fakeDockerContainerState(state docker.ContainerState) *dcontainer.State {
 	return st
 }
 
+func fakeEndpointSettings(config *dnetwork.EndpointSettings) *dnetwork.EndpointSettings {
+	es := &dnetwork.EndpointSettings{}
+	if config != nil && config.IPAMConfig != nil {
+		es.IPAddress = config.IPAMConfig.IPv4Address
+		es.GlobalIPv6Address = config.IPAMConfig.IPv6Address
+	}
+	return es
+}
+
 func randomSHA256ID() string {
 	b := make([]byte, 32)
 	_, err := rand.Read(b)
@@ -626,3 +940,33 @@ func randomSHA256ID() string {
 	h.Write(b)
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
+
+func (f *FakeDockerHost) ContainerRestartOptions(containerName string) *dcontainer.StopOptions {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if ct, found := f.containers[containerName]; found {
+		return ct.restartOptions
+	}
+	return nil
+}
+
+// LastContainerStopOptions returns the options of the last stop issued
+// for the container with the specified name, even if the container was
+// removed (and possibly recreated) since then.
+func (f *FakeDockerHost) LastContainerStopOptions(containerName string) *dcontainer.StopOptions {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.lastStopOptions[containerName]
+}
+
+func (f *FakeDockerHost) ContainerStopOptions(containerName string) *dcontainer.StopOptions {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if ct, found := f.containers[containerName]; found {
+		return ct.stopOptions
+	}
+	return nil
+}
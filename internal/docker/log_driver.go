@@ -0,0 +1,37 @@
+package docker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	logDrivers = []string{
+		"none",
+		"local",
+		"json-file",
+		"syslog",
+		"journald",
+		"gelf",
+		"fluentd",
+		"awslogs",
+		"splunk",
+		"etwlogs",
+		"gcplogs",
+		"logentries",
+	}
+)
+
+func IsValidLogDriver(driver string) bool {
+	return slices.Contains(logDrivers, driver)
+}
+
+// LogDrivers returns the supported logging drivers.
+func LogDrivers() []string {
+	return slices.Clone(logDrivers)
+}
+
+func LogDriverValidValues() string {
+	return fmt.Sprintf("[ '%s' ]", strings.Join(logDrivers, "', '"))
+}
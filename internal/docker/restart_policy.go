@@ -2,6 +2,7 @@ package docker
 
 import (
 	"fmt"
+	"strings"
 
 	dcontainer "github.com/docker/docker/api/types/container"
 )
@@ -29,6 +30,11 @@ func MustRestartPolicyModeFromString(pol string) dcontainer.RestartPolicyMode {
 	return rpm
 }
 
+// RestartPolicyModes returns the valid restart policy mode strings.
+func RestartPolicyModes() []string {
+	return []string{"no", "always", "on-failure", "unless-stopped"}
+}
+
 func RestartPolicyModeValidValues() string {
-	return "[ 'no', 'always', 'on-failure', 'unless-stopped' ]"
+	return fmt.Sprintf("[ '%s' ]", strings.Join(RestartPolicyModes(), "', '"))
 }
@@ -0,0 +1,18 @@
+package dryrun
+
+import "context"
+
+var (
+	recorderKey = ctxKeyRecorder{}
+)
+
+type ctxKeyRecorder struct{}
+
+func RecorderFromContext(ctx context.Context) (*Recorder, bool) {
+	rec, ok := ctx.Value(recorderKey).(*Recorder)
+	return rec, ok
+}
+
+func WithRecorder(ctx context.Context, rec *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey, rec)
+}
@@ -0,0 +1,67 @@
+package dryrun
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Recorder records the mutating actions that would have been performed
+// on the docker host and the system while running in dry run mode. The
+// actions are grouped by the subject (i.e. a container or a network)
+// for which they were performed, in the order they were recorded.
+type Recorder struct {
+	mu      sync.Mutex
+	subject string
+	actions map[string][]string
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		actions: make(map[string][]string),
+	}
+}
+
+// Begin marks the specified subject as the one for which all the
+// subsequently recorded actions are performed.
+func (r *Recorder) Begin(subject string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subject = subject
+	r.actions[subject] = nil
+}
+
+func (r *Recorder) Record(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.actions[r.subject] = append(r.actions[r.subject], fmt.Sprintf(format, args...))
+}
+
+func (r *Recorder) Actions(subject string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := make([]string, len(r.actions[subject]))
+	copy(res, r.actions[subject])
+	return res
+}
+
+// Plan returns the human friendly ordered list of actions recorded for
+// the specified subject.
+func (r *Recorder) Plan(subject string) string {
+	actions := r.Actions(subject)
+	if len(actions) == 0 {
+		return "  No actions"
+	}
+
+	var sb strings.Builder
+	for i, a := range actions {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "  %d - %s", i+1, a)
+	}
+	return sb.String()
+}
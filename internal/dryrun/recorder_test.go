@@ -0,0 +1,52 @@
+package dryrun
+
+import (
+	"testing"
+
+	"github.com/tuxgal/homelab/internal/testhelpers"
+)
+
+var recorderPlanTests = []struct {
+	name    string
+	subject string
+	want    string
+}{
+	{
+		name:    "Recorder Plan - Multiple Actions",
+		subject: "g1-c1",
+		want: `  1 - Pull image abc/xyz
+  2 - Start container g1-c1`,
+	},
+	{
+		name:    "Recorder Plan - Subject Without Actions",
+		subject: "g1-c2",
+		want:    "  No actions",
+	},
+	{
+		name:    "Recorder Plan - Unknown Subject",
+		subject: "g1-c3",
+		want:    "  No actions",
+	},
+}
+
+func TestRecorderPlan(t *testing.T) {
+	t.Parallel()
+
+	rec := NewRecorder()
+	rec.Begin("g1-c1")
+	rec.Record("Pull image %s", "abc/xyz")
+	rec.Record("Start container %s", "g1-c1")
+	rec.Begin("g1-c2")
+
+	for _, test := range recorderPlanTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := rec.Plan(tc.subject)
+			if !testhelpers.CmpDiff(t, "Recorder.Plan()", tc.name, "plan", tc.want, got) {
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tuxgal/tuxlogi"
+)
+
+type prefixLogger struct {
+	logger tuxlogi.Logger
+	prefix string
+}
+
+// NewPrefixLogger returns a logger that prefixes every non-empty line
+// logged using the specified logger with the specified prefix.
+func NewPrefixLogger(logger tuxlogi.Logger, prefix string) tuxlogi.Logger {
+	return &prefixLogger{
+		logger: logger,
+		prefix: prefix,
+	}
+}
+
+func (p *prefixLogger) Trace(args ...interface{}) {
+	p.logger.Trace(p.prefixMessage(args))
+}
+
+func (p *prefixLogger) Tracef(format string, args ...interface{}) {
+	p.logger.Tracef(p.prefixFormat(format), p.prefixArgs(args)...)
+}
+
+func (p *prefixLogger) TraceEmpty() {
+	p.logger.TraceEmpty()
+}
+
+func (p *prefixLogger) Debug(args ...interface{}) {
+	p.logger.Debug(p.prefixMessage(args))
+}
+
+func (p *prefixLogger) Debugf(format string, args ...interface{}) {
+	p.logger.Debugf(p.prefixFormat(format), p.prefixArgs(args)...)
+}
+
+func (p *prefixLogger) DebugEmpty() {
+	p.logger.DebugEmpty()
+}
+
+func (p *prefixLogger) Info(args ...interface{}) {
+	p.logger.Info(p.prefixMessage(args))
+}
+
+func (p *prefixLogger) Infof(format string, args ...interface{}) {
+	p.logger.Infof(p.prefixFormat(format), p.prefixArgs(args)...)
+}
+
+func (p *prefixLogger) InfoEmpty() {
+	p.logger.InfoEmpty()
+}
+
+func (p *prefixLogger) Warn(args ...interface{}) {
+	p.logger.Warn(p.prefixMessage(args))
+}
+
+func (p *prefixLogger) Warnf(format string, args ...interface{}) {
+	p.logger.Warnf(p.prefixFormat(format), p.prefixArgs(args)...)
+}
+
+func (p *prefixLogger) WarnEmpty() {
+	p.logger.WarnEmpty()
+}
+
+func (p *prefixLogger) Error(args ...interface{}) {
+	p.logger.Error(p.prefixMessage(args))
+}
+
+func (p *prefixLogger) Errorf(format string, args ...interface{}) {
+	p.logger.Errorf(p.prefixFormat(format), p.prefixArgs(args)...)
+}
+
+func (p *prefixLogger) ErrorEmpty() {
+	p.logger.ErrorEmpty()
+}
+
+func (p *prefixLogger) Fatal(args ...interface{}) {
+	p.logger.Fatal(p.prefixMessage(args))
+}
+
+func (p *prefixLogger) Fatalf(format string, args ...interface{}) {
+	p.logger.Fatalf(p.prefixFormat(format), p.prefixArgs(args)...)
+}
+
+func (p *prefixLogger) Print(args ...interface{}) {
+	p.logger.Print(p.prefixMessage(args))
+}
+
+func (p *prefixLogger) Printf(format string, args ...interface{}) {
+	p.logger.Printf(p.prefixFormat(format), p.prefixArgs(args)...)
+}
+
+func (p *prefixLogger) prefixFormat(format string) string {
+	return "%s " + format
+}
+
+// prefixMessage returns the message built from the args separated by
+// spaces (just like the default format of the logger), along with the
+// prefix. The message is passed on as a single operand so that the
+// prefix is separated from it irrespective of how the underlying logger
+// joins multiple operands.
+func (p *prefixLogger) prefixMessage(args []interface{}) string {
+	return p.prefix + " " + strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
+
+func (p *prefixLogger) prefixArgs(args []interface{}) []interface{} {
+	return append([]interface{}{p.prefix}, args...)
+}
@@ -0,0 +1,125 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tuxgal/homelab/internal/testhelpers"
+	"github.com/tuxgal/tuxlog"
+	"github.com/tuxgal/tuxlogi"
+)
+
+var prefixLoggerTests = []struct {
+	name string
+	fn   func(tuxlogi.Logger)
+	want string
+}{
+	{
+		name: "Prefix Logger - Formatted",
+		fn: func(l tuxlogi.Logger) {
+			l.Infof("Starting container %s", "g1-c1")
+		},
+		want: "[g1-c1] Starting container g1-c1\n",
+	},
+	{
+		name: "Prefix Logger - Default Format",
+		fn: func(l tuxlogi.Logger) {
+			l.Warn("Container", "g1-c1", "not found")
+		},
+		want: "[g1-c1] Container g1-c1 not found\n",
+	},
+	{
+		name: "Prefix Logger - Default Format - Single Operand",
+		fn: func(l tuxlogi.Logger) {
+			l.Info("Starting container")
+		},
+		want: "[g1-c1] Starting container\n",
+	},
+	{
+		name: "Prefix Logger - Empty",
+		fn: func(l tuxlogi.Logger) {
+			l.InfoEmpty()
+		},
+		want: "\n",
+	},
+	{
+		name: "Prefix Logger - Below Max Level",
+		fn: func(l tuxlogi.Logger) {
+			l.Tracef("Pulling image %s", "foo/bar:123")
+		},
+		want: "",
+	},
+}
+
+// sprintLogger is a logger which joins the operands of the non-f logging
+// methods using fmt.Sprint, i.e. without any spaces between the string
+// operands.
+type sprintLogger struct {
+	tuxlogi.Logger
+	out *strings.Builder
+}
+
+func (s *sprintLogger) Info(args ...interface{}) {
+	s.out.WriteString(fmt.Sprint(args...) + "\n")
+}
+
+func (s *sprintLogger) Print(args ...interface{}) {
+	s.out.WriteString(fmt.Sprint(args...) + "\n")
+}
+
+var prefixLoggerNonFormattedTests = []struct {
+	name string
+	fn   func(tuxlogi.Logger)
+	want string
+}{
+	{
+		name: "Prefix Logger - Non Formatted - Single Operand",
+		fn: func(l tuxlogi.Logger) {
+			l.Info("Starting container")
+		},
+		want: "[g1-c1] Starting container\n",
+	},
+	{
+		name: "Prefix Logger - Non Formatted - Multiple Operands",
+		fn: func(l tuxlogi.Logger) {
+			l.Print("Container", "g1-c1", "started after", 3, "attempts")
+		},
+		want: "[g1-c1] Container g1-c1 started after 3 attempts\n",
+	},
+}
+
+func TestPrefixLoggerNonFormatted(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range prefixLoggerNonFormattedTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := new(strings.Builder)
+			tc.fn(NewPrefixLogger(&sprintLogger{out: out}, "[g1-c1]"))
+
+			testhelpers.CmpDiff(t, "NewPrefixLogger()", tc.name, "output", tc.want, out.String())
+		})
+	}
+}
+
+func TestPrefixLogger(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range prefixLoggerTests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := new(strings.Builder)
+			config := tuxlog.NewVanillaLoggerConfig()
+			config.MaxLevel = tuxlog.LvlDebug
+			config.Dest = out
+			tc.fn(NewPrefixLogger(tuxlog.NewLogger(config), "[g1-c1]"))
+
+			testhelpers.CmpDiff(t, "NewPrefixLogger()", tc.name, "output", tc.want, out.String())
+		})
+	}
+}
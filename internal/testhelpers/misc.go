@@ -22,6 +22,14 @@ func NewLogLevel(lvl tuxlog.Level) *tuxlog.Level {
 	return &lvl
 }
 
+func NewBool(b bool) *bool {
+	return &b
+}
+
 func NewInt(i int) *int {
 	return &i
 }
+
+func NewInt64(i int64) *int64 {
+	return &i
+}
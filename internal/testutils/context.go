@@ -2,6 +2,8 @@ package testutils
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/tuxgal/homelab/internal/cli/version"
 	"github.com/tuxgal/homelab/internal/cmdexec"
@@ -18,15 +20,15 @@ import (
 )
 
 type TestContextInfo struct {
-	InspectLevel               inspect.HomelabInspectLevel
-	Logger                     tuxlogi.Logger
-	Version                    *version.VersionInfo
-	Executor                   cmdexec.Executor
-	DockerHost                 docker.APIClient
-	ContainerPurgeKillAttempts uint32
-	UseRealUserInfo            bool
-	UseRealHostInfo            bool
-	UseRealExecutor            bool
+	InspectLevel              inspect.HomelabInspectLevel
+	Logger                    tuxlogi.Logger
+	Version                   *version.VersionInfo
+	Executor                  cmdexec.Executor
+	DockerHost                docker.APIClient
+	ContainerPurgeGracePeriod time.Duration
+	UseRealUserInfo           bool
+	UseRealHostInfo           bool
+	UseRealExecutor           bool
 }
 
 func NewVanillaTestContext() context.Context {
@@ -62,9 +64,12 @@ func NewTestContext(info *TestContextInfo) context.Context {
 	}
 	if info.DockerHost != nil {
 		ctx = docker.WithAPIClient(ctx, info.DockerHost)
+		// Keep the image pull progress out of the test output, unless
+		// the command under test routes it elsewhere.
+		ctx = docker.WithPullProgressWriter(ctx, io.Discard)
 	}
-	if info.ContainerPurgeKillAttempts != 0 {
-		ctx = docker.WithContainerPurgeKillAttempts(ctx, info.ContainerPurgeKillAttempts)
+	if info.ContainerPurgeGracePeriod != 0 {
+		ctx = docker.WithContainerPurgeGracePeriod(ctx, info.ContainerPurgeGracePeriod)
 	}
 	return ctx
 }
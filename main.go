@@ -63,12 +63,20 @@ func updateHomelabInspectLevel(ctx context.Context) context.Context {
 
 func runWithContext(ctx context.Context, outW io.Writer, errW io.Writer, args ...string) int {
 	ctx = updateHomelabInspectLevel(ctx)
-	ctx = log.WithLogger(ctx, buildLogger(ctx, outW))
-	err := cli.Exec(ctx, outW, errW, args...)
+	ctx = log.WithLogger(ctx, buildLogger(ctx, cli.LogWriter(ctx, outW, errW, args...)))
+	err := cli.Exec(ctx, os.Stdin, outW, errW, args...)
 	if err == nil {
 		return 0
 	}
 
+	// Commands executed inside the containers report their own failures,
+	// so only their exit code is propagated.
+	cee := &clierrors.CommandExitError{}
+	if errors.As(err, &cee) {
+		log.Log(ctx).Debugf("%s", cee)
+		return cee.ExitCode()
+	}
+
 	// Only log homelab runtime errors. Other errors are from cobra flag
 	// and command parsing. These errors are displayed already by cobra
 	// along with the usage.
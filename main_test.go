@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/tuxgal/homelab/internal/docker"
 	"github.com/tuxgal/homelab/internal/docker/fakedocker"
 	"github.com/tuxgal/homelab/internal/inspect"
 	"github.com/tuxgal/homelab/internal/testhelpers"
@@ -101,6 +102,33 @@ Use "homelab \[command\] --help" for more information about a command\.`,
 		wantStatus: 1,
 		wantOutput: `.+ERROR.+start failed while parsing the configs, reason: os\.Stat\(\) failed on homelab configs path, reason: stat .+/homelab/testdata/foobar: no such file or directory`,
 	},
+	{
+		name: "Main - runWithContext() - Containers Exec - Non Zero Exit Code",
+		args: []string{
+			"containers",
+			"exec",
+			"g1/c1",
+			"--configs-dir",
+			fmt.Sprintf("%s/testdata/containers-and-groups-cmds", testhelpers.Pwd()),
+			"--",
+			"false",
+		},
+		ctxInfo: &testutils.TestContextInfo{
+			DockerHost: fakedocker.NewFakeDockerHost(&fakedocker.FakeDockerHostInitInfo{
+				Containers: []*fakedocker.FakeContainerInitInfo{
+					{
+						Name:         "g1-c1",
+						Image:        "abc/xyz",
+						State:        docker.ContainerStateRunning,
+						ExecExitCode: 3,
+					},
+				},
+			}),
+		},
+		wantStatus: 3,
+		// The TTY depends on whether the stdin of the test is a terminal.
+		wantOutput: `Executed "false" as user root in container g1-c1 \(tty: (true|false)\)`,
+	},
 }
 
 func TestMainRunWithContext(t *testing.T) {